@@ -39,6 +39,15 @@ const (
 	SucceedConditionType ConditionType = "Succeed"
 	// BuiltConditionType describes the condition of a resource that needs to be build.
 	BuiltConditionType ConditionType = "Built"
+	// ReconciledConditionType describes whether the workflow conditions can be handled by a reconciliation state.
+	// It's only reported while they can't.
+	ReconciledConditionType ConditionType = "Reconciled"
+	// FieldsAppliedConditionType describes whether the operator owns the fields it applies to the workflow objects with
+	// server-side apply. It's only reported while other field managers own some of them.
+	FieldsAppliedConditionType ConditionType = "FieldsApplied"
+	// ServiceDiscoveryConditionType describes whether the service discovery properties of the workflow are resolved.
+	// It's only reported for the workflows using the service discovery.
+	ServiceDiscoveryConditionType ConditionType = "ServiceDiscovery"
 )
 
 const (
@@ -54,6 +63,18 @@ const (
 	BuildSkippedReason              = "BuildSkipped"
 	BuildSuccessfulReason           = "BuildSuccessful"
 	BuildMarkedToRestartReason      = "BuildMarkedToRestart"
+	RolloutStartedReason            = "RolloutStarted"
+	RolloutPromotingReason          = "RolloutPromoting"
+	RolloutPromotedReason           = "RolloutPromoted"
+	RolloutRolledBackReason         = "RolloutRolledBack"
+	UnknownStateReason              = "UnknownState"
+	FieldConflictReason             = "FieldConflict"
+	VersionDrainingReason           = "VersionDraining"
+	VersionRemovedReason            = "VersionRemoved"
+	HibernatedReason                = "Hibernated"
+	WokenUpReason                   = "WokenUp"
+	DiscoveryResolvedReason         = "DiscoveryResolved"
+	DiscoveryFailedReason           = "DiscoveryFailed"
 )
 
 // Condition describes the common structure for conditions in our types
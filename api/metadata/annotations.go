@@ -32,6 +32,10 @@ const (
 	OperatorIDAnnotation        = Domain + "/operator.id"
 	RestartedAt                 = Domain + "/restartedAt"
 	Checksum                    = Domain + "/checksum-config"
+	// SharedFromAnnotation records the "namespace/name" of the object a copy shared by a SonataFlowClusterPlatform comes from
+	SharedFromAnnotation = Domain + "/shared-from"
+	// WakeUp scales a workflow hibernated by its idle policy back up when set to a new value, a timestamp for example
+	WakeUp = Domain + "/wake-up"
 )
 
 const (
@@ -0,0 +1,73 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// ConcurrencyAutoscalingMetric is the number of simultaneous requests handled by each pod. Only supported by the
+	// knative deployment model.
+	ConcurrencyAutoscalingMetric = "concurrency"
+	// RPSAutoscalingMetric is the number of requests per second handled by each pod. Only supported by the knative
+	// deployment model.
+	RPSAutoscalingMetric = "rps"
+)
+
+// AutoscalingSpec configures the horizontal autoscaling of the workflow pods.
+// For the "kubernetes" deployment model, the operator owns a HorizontalPodAutoscaler targeting the workflow Deployment.
+// For the "knative" deployment model, the settings are mapped to the Knative autoscaling annotations of the revision
+// template. Knative scales on a single metric, custom metrics take precedence over the CPU target, which takes precedence
+// over the memory target.
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1. Zero is only accepted by the
+	// "knative" deployment model, where it enables scale to zero.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the target average CPU utilization of the pods, as a percentage of the requested CPU.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetMemoryUtilizationPercentage is the target average memory utilization of the pods, as a percentage of the
+	// requested memory. The "knative" deployment model requires a memory request in the workflow container.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+	// Metrics are the custom per pod metrics to scale on. The "kubernetes" deployment model reads them from the
+	// custom metrics API, the "knative" deployment model only supports the "concurrency" and "rps" metrics.
+	// +optional
+	Metrics []AutoscalingMetric `json:"metrics,omitempty"`
+}
+
+// AutoscalingMetric is a custom metric describing each pod of the workflow.
+type AutoscalingMetric struct {
+	// Name of the metric.
+	Name string `json:"name"`
+	// TargetAverageValue is the target value of the average of the metric across all the pods.
+	TargetAverageValue resource.Quantity `json:"targetAverageValue"`
+}
+
+// GetMinReplicas returns the lower limit for the number of replicas, 1 if not set.
+func (a *AutoscalingSpec) GetMinReplicas() int32 {
+	if a.MinReplicas == nil {
+		return 1
+	}
+	return *a.MinReplicas
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+const (
+	// SonataFlowFinalizer cleans up the workflow resources that can't be garbage collected through owner references.
+	SonataFlowFinalizer = "sonataflow.org/workflow-cleanup"
+	// SonataFlowPlatformFinalizer holds the platform deletion while workflows still depend on it.
+	SonataFlowPlatformFinalizer = "sonataflow.org/platform-cleanup"
+	// SonataFlowClusterPlatformFinalizer removes the references to the cluster platform from the platforms status.
+	SonataFlowClusterPlatformFinalizer = "sonataflow.org/clusterplatform-cleanup"
+)
+
+// DeletionPolicy defines what happens to a resource produced by the operator when its owner is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type DeletionPolicy string
+
+const (
+	// DeleteDeletionPolicy deletes the resource together with its owner.
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+	// RetainDeletionPolicy keeps the resource after its owner is deleted. Default policy.
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+)
+
+// DeletionPoliciesSpec configures the cleanup of the resources that outlive the workflows and the platform.
+type DeletionPoliciesSpec struct {
+	// Images policy applied to the images pushed to the registry by the builds of a workflow when the workflow is
+	// deleted. Images built with the "platform" build strategy are managed by the cluster and never deleted.
+	// Defaults to "Retain".
+	// +optional
+	Images DeletionPolicy `json:"images,omitempty"`
+	// BuildCache policy applied to the Kaniko cache PersistentVolumeClaim of the platform when the platform is deleted.
+	// Defaults to "Retain".
+	// +optional
+	BuildCache DeletionPolicy `json:"buildCache,omitempty"`
+}
+
+// GetImagesPolicy returns the deletion policy of the workflow images, defaulting to RetainDeletionPolicy.
+func (d *DeletionPoliciesSpec) GetImagesPolicy() DeletionPolicy {
+	if d == nil || len(d.Images) == 0 {
+		return RetainDeletionPolicy
+	}
+	return d.Images
+}
+
+// GetBuildCachePolicy returns the deletion policy of the build cache, defaulting to RetainDeletionPolicy.
+func (d *DeletionPoliciesSpec) GetBuildCachePolicy() DeletionPolicy {
+	if d == nil || len(d.BuildCache) == 0 {
+		return RetainDeletionPolicy
+	}
+	return d.BuildCache
+}
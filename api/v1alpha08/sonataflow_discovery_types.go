@@ -0,0 +1,72 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+// UnresolvedDiscoveryPolicy what to do with a workflow when some of its service discovery properties can't be resolved.
+// +kubebuilder:validation:Enum=Warn;Block
+type UnresolvedDiscoveryPolicy string
+
+const (
+	// WarnUnresolvedDiscoveryPolicy deploys the workflow without the unresolved properties, reporting them in the
+	// ServiceDiscovery condition.
+	WarnUnresolvedDiscoveryPolicy UnresolvedDiscoveryPolicy = "Warn"
+	// BlockUnresolvedDiscoveryPolicy keeps the workflow from being deployed until every property is resolved.
+	BlockUnresolvedDiscoveryPolicy UnresolvedDiscoveryPolicy = "Block"
+)
+
+// ServiceDiscoverySpec configures how the operator handles the service discovery of the workflow, like
+// "${kubernetes:services.v1/my-namespace/my-service}" property values.
+type ServiceDiscoverySpec struct {
+	// UnresolvedPolicy is either "Warn", the default, to deploy the workflow anyway, or "Block" to wait for every
+	// discovery property to be resolved before deploying the workflow.
+	// +optional
+	UnresolvedPolicy UnresolvedDiscoveryPolicy `json:"unresolvedPolicy,omitempty"`
+}
+
+// IsBlocking returns true if the workflow must not be deployed while some properties are unresolved.
+func (s *ServiceDiscoverySpec) IsBlocking() bool {
+	return s != nil && s.UnresolvedPolicy == BlockUnresolvedDiscoveryPolicy
+}
+
+// DiscoveredPropertyStatus the result of the service discovery of a property, or of a Knative function operation.
+type DiscoveredPropertyStatus struct {
+	// Property is the name of the property holding the discovery uri, empty for a function.
+	// +optional
+	Property string `json:"property,omitempty"`
+	// Function is the name of the function invoking the discovered Knative service, empty for a property.
+	// +optional
+	Function string `json:"function,omitempty"`
+	// Uri is the discovery uri, like "kubernetes:services.v1/my-namespace/my-service".
+	Uri string `json:"uri"`
+	// Address is the address the uri was resolved into.
+	// +optional
+	Address string `json:"address,omitempty"`
+	// Error is the reason the uri couldn't be resolved.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// IsResolved returns true if the uri was resolved into an address.
+func (d *DiscoveredPropertyStatus) IsResolved() bool {
+	return len(d.Error) == 0
+}
+
+// GetName returns the property or the function name.
+func (d *DiscoveredPropertyStatus) GetName() string {
+	if len(d.Property) > 0 {
+		return d.Property
+	}
+	return "function " + d.Function
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// EventingMode defines the messaging system the workflows produce and consume events with.
+// +kubebuilder:validation:Enum=knative;kafka
+type EventingMode string
+
+const (
+	// KnativeEventingMode delivers the events with Knative Eventing Brokers, Channels and SinkBindings. Default mode.
+	KnativeEventingMode EventingMode = "knative"
+	// KafkaEventingMode produces and consumes the events directly from Kafka topics, without Knative.
+	KafkaEventingMode EventingMode = "kafka"
+)
+
+// EventBackoffPolicy the backoff policy used between the delivery retries of an event.
+// +kubebuilder:validation:Enum=linear;exponential
+type EventBackoffPolicy string
+
+const (
+	// LinearEventBackoffPolicy waits backoffDelay * <numberOfRetries> between the retries.
+	LinearEventBackoffPolicy EventBackoffPolicy = "linear"
+	// ExponentialEventBackoffPolicy waits backoffDelay * 2^<numberOfRetries> between the retries.
+	ExponentialEventBackoffPolicy EventBackoffPolicy = "exponential"
+)
+
+// EventDeliverySpec defines how the events are delivered to the workflow when the first attempt fails.
+type EventDeliverySpec struct {
+	// DeadLetterSink is the sink receiving the events that couldn't be delivered after the retries.
+	// +optional
+	DeadLetterSink *duckv1.Destination `json:"deadLetterSink,omitempty"`
+	// Retry is the minimum number of retries before sending the event to the dead letter sink.
+	// +optional
+	Retry *int32 `json:"retry,omitempty"`
+	// BackoffPolicy is the retry backoff policy. One of "linear" or "exponential".
+	// +optional
+	BackoffPolicy *EventBackoffPolicy `json:"backoffPolicy,omitempty"`
+	// BackoffDelay is the delay before retrying, as an ISO 8601 duration. For example, "PT0.5S".
+	// +optional
+	BackoffDelay *string `json:"backoffDelay,omitempty"`
+	// Timeout of each delivery attempt, as an ISO 8601 duration.
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// KafkaEventingSpec describes the Kafka cluster used by the kafka eventing mode.
+type KafkaEventingSpec struct {
+	// BootstrapServers is the comma separated list of the Kafka brokers. For example, "my-cluster-kafka-bootstrap:9092".
+	// +kubebuilder:validation:Required
+	BootstrapServers string `json:"bootstrapServers"`
+	// Topics configures the Strimzi KafkaTopics created for the missing topics. No KafkaTopic is created when not set.
+	// +optional
+	Topics *KafkaTopicsSpec `json:"topics,omitempty"`
+}
+
+// KafkaTopicsSpec describes the Strimzi KafkaTopics created by the operator. The KafkaTopics are created in the
+// workflow namespace, where the Strimzi Topic Operator must be watching, and are never updated nor deleted.
+type KafkaTopicsSpec struct {
+	// Cluster is the name of the Strimzi Kafka cluster the topics belong to.
+	// +kubebuilder:validation:Required
+	Cluster string `json:"cluster"`
+	// Partitions of the created topics. Defaults to the Kafka cluster default.
+	// +optional
+	Partitions *int32 `json:"partitions,omitempty"`
+	// Replicas of the created topics. Defaults to the Kafka cluster default.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// EventingBackendSpec selects the messaging system of the events.
+type EventingBackendSpec struct {
+	// Mode is the messaging system the events are produced and consumed with. One of "knative" or "kafka".
+	// Defaults to "knative".
+	// +optional
+	Mode EventingMode `json:"mode,omitempty"`
+	// Kafka describes the Kafka cluster used by the "kafka" mode.
+	// +optional
+	Kafka *KafkaEventingSpec `json:"kafka,omitempty"`
+}
+
+// EventingOptionsSpec describes where the consumed events come from and how they are delivered.
+type EventingOptionsSpec struct {
+	// Broker is the name of the Knative Broker, in the workflow namespace, the events are consumed from with a Trigger.
+	// Defaults to "default".
+	// +optional
+	Broker string `json:"broker,omitempty"`
+	// Channel is the Knative Channel the events are consumed from with a Subscription, instead of a Broker.
+	// Channels don't filter, the workflow receives every event sent to the Channel.
+	// +optional
+	Channel *duckv1.KReference `json:"channel,omitempty"`
+	// Delivery configures the retries and the dead letter sink of the Triggers or Subscriptions.
+	// +optional
+	Delivery *EventDeliverySpec `json:"delivery,omitempty"`
+}
+
+// ConsumedEventSpec overrides the eventing options of one of the events consumed by the workflow.
+type ConsumedEventSpec struct {
+	// Name of the consumed event, as declared in the workflow definition.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	EventingOptionsSpec `json:",inline"`
+
+	// Topic is the Kafka topic of the event in the "kafka" mode. Defaults to the event type.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+	// Filter adds CloudEvents attributes, typically extension attributes, the events must match to be delivered.
+	// The type, the source and the correlation attributes with a value declared in the workflow definition
+	// are always part of the filter.
+	// +optional
+	Filter map[string]string `json:"filter,omitempty"`
+}
+
+// EventingSpec describes how the workflow consumes events with Knative Eventing.
+type EventingSpec struct {
+	EventingOptionsSpec `json:",inline"`
+	EventingBackendSpec `json:",inline"`
+	// Events overrides the eventing options for the given consumed events. Only the topic applies to the produced
+	// events.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Events []ConsumedEventSpec `json:"events,omitempty"`
+}
+
+// PlatformEventingSpec describes the eventing of the platform services, and the defaults of the workflows.
+type PlatformEventingSpec struct {
+	EventingOptionsSpec `json:",inline"`
+	EventingBackendSpec `json:",inline"`
+}
+
+// GetEvent returns the options of the consumed event with the given name or nil if not found.
+func (e *EventingSpec) GetEvent(name string) *ConsumedEventSpec {
+	if e == nil {
+		return nil
+	}
+	for i := range e.Events {
+		if e.Events[i].Name == name {
+			return &e.Events[i]
+		}
+	}
+	return nil
+}
+
+// GetConsumedEventOptions returns the options used to consume the given event: the first of the event, the workflow
+// or the platform options setting either a Broker or a Channel gives the source of the event, and the first one
+// setting a Delivery gives the delivery.
+func GetConsumedEventOptions(workflow *SonataFlow, platform *SonataFlowPlatform, eventName string) EventingOptionsSpec {
+	var candidates []*EventingOptionsSpec
+	if event := workflow.Spec.Eventing.GetEvent(eventName); event != nil {
+		candidates = append(candidates, &event.EventingOptionsSpec)
+	}
+	if workflow.Spec.Eventing != nil {
+		candidates = append(candidates, &workflow.Spec.Eventing.EventingOptionsSpec)
+	}
+	if platform != nil && platform.Spec.Eventing != nil {
+		candidates = append(candidates, &platform.Spec.Eventing.EventingOptionsSpec)
+	}
+	options := EventingOptionsSpec{}
+	sourceSet := false
+	for _, candidate := range candidates {
+		if !sourceSet && (len(candidate.Broker) > 0 || candidate.Channel != nil) {
+			options.Broker = candidate.Broker
+			options.Channel = candidate.Channel
+			sourceSet = true
+		}
+		if options.Delivery == nil {
+			options.Delivery = candidate.Delivery
+		}
+	}
+	return options
+}
+
+// GetWorkflowEventingBackend returns the eventing mode and the Kafka cluster of the given workflow, defaulting to the
+// ones of the platform.
+func GetWorkflowEventingBackend(workflow *SonataFlow, platform *SonataFlowPlatform) EventingBackendSpec {
+	backend := EventingBackendSpec{}
+	if workflow != nil && workflow.Spec.Eventing != nil {
+		backend = workflow.Spec.Eventing.EventingBackendSpec
+	}
+	if platform != nil && platform.Spec.Eventing != nil {
+		if len(backend.Mode) == 0 {
+			backend.Mode = platform.Spec.Eventing.Mode
+		}
+		if backend.Kafka == nil {
+			backend.Kafka = platform.Spec.Eventing.Kafka
+		}
+	}
+	if len(backend.Mode) == 0 {
+		backend.Mode = KnativeEventingMode
+	}
+	return backend
+}
+
+// IsKafka whether the events are produced and consumed from the Kafka cluster.
+func (e EventingBackendSpec) IsKafka() bool {
+	return e.Mode == KafkaEventingMode && e.Kafka != nil
+}
+
+// GetEventTopic returns the Kafka topic of the given event of the workflow.
+func GetEventTopic(workflow *SonataFlow, eventName, eventType string) string {
+	if event := workflow.Spec.Eventing.GetEvent(eventName); event != nil && len(event.Topic) > 0 {
+		return event.Topic
+	}
+	return eventType
+}
+
+// GetPlatformEventingBackend returns the eventing mode and the Kafka cluster used by the platform services.
+func GetPlatformEventingBackend(platform *SonataFlowPlatform) EventingBackendSpec {
+	if platform == nil || platform.Spec.Eventing == nil {
+		return EventingBackendSpec{Mode: KnativeEventingMode}
+	}
+	backend := platform.Spec.Eventing.EventingBackendSpec
+	if len(backend.Mode) == 0 {
+		backend.Mode = KnativeEventingMode
+	}
+	return backend
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+// ExposureType defines the kind of resource used to expose a workflow outside the cluster
+// +kubebuilder:validation:Enum=ingress;httpRoute
+type ExposureType string
+
+const (
+	// IngressExposureType exposes the workflow with a networking.k8s.io/v1 Ingress. Default type.
+	IngressExposureType ExposureType = "ingress"
+	// HTTPRouteExposureType exposes the workflow with a Gateway API HTTPRoute attached to an existing Gateway.
+	HTTPRouteExposureType ExposureType = "httpRoute"
+)
+
+const defaultExposurePath = "/"
+
+// ExposureSpec describes how the workflow is exposed outside the cluster.
+type ExposureSpec struct {
+	// Type of the resource created to expose the workflow. One of "ingress" or "httpRoute". Defaults to "ingress".
+	// +optional
+	Type ExposureType `json:"type,omitempty"`
+	// Host is the fully qualified domain name the workflow is reachable at. When empty, the workflow is reachable at
+	// the address the ingress controller or the Gateway reports.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Path prefix routed to the workflow. Defaults to "/".
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// TLSSecretName is the name of the Secret holding the TLS certificate for the host. Only used by the "ingress" type,
+	// a Gateway terminates TLS in its listeners.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// IngressClassName is the name of the IngressClass handling the Ingress. Only used by the "ingress" type.
+	// When empty, the cluster default IngressClass is used.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Gateway the HTTPRoute is attached to. Required by the "httpRoute" type.
+	// +optional
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+	// Annotations added to the Ingress or HTTPRoute, for example to configure the ingress controller.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GatewayReference references the Gateway API Gateway a workflow HTTPRoute is attached to.
+type GatewayReference struct {
+	// Name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the Gateway. Defaults to the workflow namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener the HTTPRoute is attached to. When empty, the route is
+	// attached to every listener allowing it.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// GetType returns the exposure type, defaulting to IngressExposureType.
+func (e *ExposureSpec) GetType() ExposureType {
+	if len(e.Type) == 0 {
+		return IngressExposureType
+	}
+	return e.Type
+}
+
+// GetPath returns the path prefix routed to the workflow, defaulting to "/".
+func (e *ExposureSpec) GetPath() string {
+	if len(e.Path) == 0 {
+		return defaultExposurePath
+	}
+	return e.Path
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultIdleQuietPeriod    = 30 * time.Minute
+	defaultIdleWakeUpLeadTime = 2 * time.Minute
+)
+
+// IdlePolicySpec hibernates the workflow Deployment, scaling it to zero, once the workflow is idle.
+//
+// The workflow is idle when its HTTP and incoming events activity, read from its Prometheus metrics endpoint, hasn't
+// changed during the quiet period, and no Jobs Service schedule is due within this period.
+// A hibernated workflow is scaled back up ahead of its next Jobs Service schedule, as reported by the Data Index, or
+// when the "sonataflow.org/wake-up" annotation is set to a new value.
+type IdlePolicySpec struct {
+	// QuietPeriod is the period without activity after which the workflow is hibernated, for example "30m". Defaults to 30 minutes.
+	// +optional
+	QuietPeriod *metav1.Duration `json:"quietPeriod,omitempty"`
+	// WakeUpLeadTime is how long before its next Jobs Service schedule a hibernated workflow is scaled back up, for
+	// example "2m". Defaults to 2 minutes.
+	// +optional
+	WakeUpLeadTime *metav1.Duration `json:"wakeUpLeadTime,omitempty"`
+}
+
+// GetQuietPeriod returns the quiet period, defaulting to 30 minutes.
+func (i *IdlePolicySpec) GetQuietPeriod() time.Duration {
+	if i.QuietPeriod == nil || i.QuietPeriod.Duration <= 0 {
+		return defaultIdleQuietPeriod
+	}
+	return i.QuietPeriod.Duration
+}
+
+// GetWakeUpLeadTime returns the wake-up lead time, defaulting to 2 minutes.
+func (i *IdlePolicySpec) GetWakeUpLeadTime() time.Duration {
+	if i.WakeUpLeadTime == nil || i.WakeUpLeadTime.Duration < 0 {
+		return defaultIdleWakeUpLeadTime
+	}
+	return i.WakeUpLeadTime.Duration
+}
+
+// IdleStatus describes the activity of a workflow with an idle policy.
+type IdleStatus struct {
+	// Hibernated is true while the workflow Deployment is scaled to zero.
+	Hibernated bool `json:"hibernated"`
+	// LastActivityTime is the last time an activity of the workflow has been observed.
+	// +optional
+	LastActivityTime metav1.Time `json:"lastActivityTime,omitempty"`
+	// ObservedActivity is the last total of the activity metrics read from the workflow.
+	// +optional
+	ObservedActivity int64 `json:"observedActivity,omitempty"`
+	// PodActivity is the last total of the activity metrics read from each workflow pod, by pod name.
+	// +optional
+	PodActivity map[string]int64 `json:"podActivity,omitempty"`
+	// NextScheduleTime is the time of the next Jobs Service schedule of the workflow, as reported by the Data Index.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// WakeUpRequest is the last value of the "sonataflow.org/wake-up" annotation handled by the operator.
+	// +optional
+	WakeUpRequest string `json:"wakeUpRequest,omitempty"`
+	// Message describes why the workflow is kept running or has been hibernated.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsHibernated whether the workflow Deployment is scaled to zero by its idle policy.
+func (s *SonataFlowStatus) IsHibernated() bool {
+	return s.Idle != nil && s.Idle.Hibernated
+}
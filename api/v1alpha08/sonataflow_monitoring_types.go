@@ -0,0 +1,68 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+// MonitorType defines the kind of Prometheus Operator resource used to scrape the metrics
+// +kubebuilder:validation:Enum=serviceMonitor;podMonitor
+type MonitorType string
+
+const (
+	// ServiceMonitorType scrapes the metrics through the Service endpoints. Default type.
+	ServiceMonitorType MonitorType = "serviceMonitor"
+	// PodMonitorType scrapes the metrics directly from the pods.
+	PodMonitorType MonitorType = "podMonitor"
+)
+
+// MonitoringSpec configures the Prometheus metrics of workflows and platform services.
+type MonitoringSpec struct {
+	// Enabled exposes the Prometheus metrics endpoint, and creates the Prometheus Operator monitor scraping it when
+	// the monitoring.coreos.com API is available in the cluster.
+	Enabled bool `json:"enabled"`
+	// Type of the Prometheus Operator monitor. One of "serviceMonitor" or "podMonitor". Defaults to "serviceMonitor".
+	// Workflows using the knative deployment model are always scraped with a PodMonitor.
+	// +optional
+	Type MonitorType `json:"type,omitempty"`
+	// Interval at which the metrics are scraped, for example "30s". Defaults to the Prometheus global interval.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// Labels added to the monitors, usually required by the Prometheus instance monitor selector.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// GetType returns the monitor type, defaulting to ServiceMonitorType.
+func (m *MonitoringSpec) GetType() MonitorType {
+	if len(m.Type) == 0 {
+		return ServiceMonitorType
+	}
+	return m.Type
+}
+
+// IsEnabled returns true if the monitoring is configured and enabled.
+func (m *MonitoringSpec) IsEnabled() bool {
+	return m != nil && m.Enabled
+}
+
+// GetWorkflowMonitoring returns the monitoring of the given workflow, defaulting to the monitoring of the platform.
+func GetWorkflowMonitoring(workflow *SonataFlow, platform *SonataFlowPlatform) *MonitoringSpec {
+	if workflow.Spec.Monitoring != nil {
+		return workflow.Spec.Monitoring
+	}
+	if platform != nil {
+		return platform.Spec.Monitoring
+	}
+	return nil
+}
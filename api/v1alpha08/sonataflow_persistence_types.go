@@ -14,6 +14,11 @@
 
 package v1alpha08
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 // PlatformPersistenceOptionsSpec configures the DataBase in the platform spec. This specification can
 // be used by workflows and platform services when they don't provide one of their own.
 // +optional
@@ -22,6 +27,56 @@ type PlatformPersistenceOptionsSpec struct {
 	// Connect configured services to a postgresql database.
 	// +optional
 	PostgreSQL *PlatformPersistencePostgreSQL `json:"postgresql,omitempty"`
+	// Connect configured services to a mongodb database.
+	// +optional
+	MongoDB *PersistenceMongoDB `json:"mongodb,omitempty"`
+	// Connect configured services to an infinispan server.
+	// +optional
+	Infinispan *PersistenceInfinispan `json:"infinispan,omitempty"`
+	// Provision a postgresql database in the platform namespace, and connect configured services and workflows to it.
+	// Every service and workflow uses its own schema. Intended for development and test namespaces.
+	// +optional
+	ProvisionedPostgreSQL *ProvisionedPostgreSQLOptions `json:"provisionedPostgresql,omitempty"`
+}
+
+// ProvisionedPostgreSQLOptions configures the postgresql database the platform provisions: a single replica StatefulSet
+// storing its data in a PersistentVolumeClaim, the Service to reach it, and a Secret with generated credentials.
+type ProvisionedPostgreSQLOptions struct {
+	// Image of the postgresql server. Must accept the POSTGRES_USER, POSTGRES_PASSWORD and POSTGRES_DB environment
+	// variables. Defaults to the image configured in the operator.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Name of the database created in the server. Defaults to "sonataflow".
+	// +optional
+	DatabaseName string `json:"databaseName,omitempty"`
+	// Size of the PersistentVolumeClaim storing the database. Defaults to 1Gi.
+	// +optional
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+	// StorageClassName of the PersistentVolumeClaim storing the database. Defaults to the cluster default storage class.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Resources of the postgresql server container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// PlatformPersistenceStatus displays the persistence provisioned by the platform.
+// +k8s:openapi-gen=true
+type PlatformPersistenceStatus struct {
+	// PostgreSQL displays the postgresql database provisioned by the platform.
+	// +optional
+	PostgreSQL *ProvisionedPostgreSQLStatus `json:"postgresql,omitempty"`
+}
+
+// ProvisionedPostgreSQLStatus displays the postgresql database provisioned by the platform.
+// +k8s:openapi-gen=true
+type ProvisionedPostgreSQLStatus struct {
+	// ServiceRef the k8s service to reach the database.
+	ServiceRef SQLServiceOptions `json:"serviceRef"`
+	// SecretRef the secret holding the generated credentials of the database user.
+	SecretRef PostgreSQLSecretOptions `json:"secretRef"`
+	// Ready whether the database server is ready to accept connections.
+	Ready bool `json:"ready"`
 }
 
 // PlatformPersistencePostgreSQL configure postgresql connection in a platform to be shared
@@ -42,7 +97,7 @@ type PlatformPersistencePostgreSQL struct {
 
 // PersistenceOptionsSpec configures the DataBase support for both platform services and workflows. For services, it allows
 // configuring a generic database connectivity if the service does not come with its own configured. In case of workflows,
-// the operator will add the necessary JDBC properties to in the workflow's application.properties so that it can communicate
+// the operator will add the necessary persistence properties to in the workflow's application.properties so that it can communicate
 // with the persistence service based on the spec provided here.
 // +optional
 // +kubebuilder:validation:MaxProperties=1
@@ -50,6 +105,12 @@ type PersistenceOptionsSpec struct {
 	// Connect configured services to a postgresql database.
 	// +optional
 	PostgreSQL *PersistencePostgreSQL `json:"postgresql,omitempty"`
+	// Connect configured services to a mongodb database.
+	// +optional
+	MongoDB *PersistenceMongoDB `json:"mongodb,omitempty"`
+	// Connect configured services to an infinispan server.
+	// +optional
+	Infinispan *PersistenceInfinispan `json:"infinispan,omitempty"`
 }
 
 // PersistencePostgreSQL configure postgresql connection for service(s).
@@ -100,3 +161,69 @@ type PostgreSQLServiceOptions struct {
 	// +optional
 	DatabaseSchema string `json:"databaseSchema,omitempty"`
 }
+
+// PersistenceMongoDB configure mongodb connection for service(s) and workflows.
+type PersistenceMongoDB struct {
+	// Secret reference to the database user credentials
+	SecretRef MongoDBSecretOptions `json:"secretRef"`
+	// Service reference to the mongodb server. Mutually exclusive to connectionString.
+	// +optional
+	ServiceRef *NoSQLServiceOptions `json:"serviceRef,omitempty"`
+	// MongoDB connection string. Mutually exclusive to serviceRef.
+	// e.g. "mongodb://host:port"
+	// +optional
+	ConnectionString string `json:"connectionString,omitempty"`
+	// Name of mongodb database to be used. Defaults to "sonataflow". When shared from the platform, every service and
+	// workflow uses its own database named after it, prefixed with this name when set.
+	// +optional
+	DatabaseName string `json:"databaseName,omitempty"`
+}
+
+// MongoDBSecretOptions use credential secret for mongodb connection.
+type MongoDBSecretOptions struct {
+	// Name of the mongodb credentials secret.
+	Name string `json:"name"`
+	// Defaults to MONGODB_USER
+	// +optional
+	UserKey string `json:"userKey,omitempty"`
+	// Defaults to MONGODB_PASSWORD
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// PersistenceInfinispan configure infinispan connection for service(s) and workflows.
+type PersistenceInfinispan struct {
+	// Secret reference to the infinispan user credentials
+	SecretRef InfinispanSecretOptions `json:"secretRef"`
+	// Service reference to the infinispan server. Mutually exclusive to hosts.
+	// +optional
+	ServiceRef *NoSQLServiceOptions `json:"serviceRef,omitempty"`
+	// Comma separated list of infinispan servers. Mutually exclusive to serviceRef.
+	// e.g. "host1:11222,host2:11222"
+	// +optional
+	Hosts string `json:"hosts,omitempty"`
+}
+
+// InfinispanSecretOptions use credential secret for infinispan connection.
+type InfinispanSecretOptions struct {
+	// Name of the infinispan credentials secret.
+	Name string `json:"name"`
+	// Defaults to INFINISPAN_USER
+	// +optional
+	UserKey string `json:"userKey,omitempty"`
+	// Defaults to INFINISPAN_PASSWORD
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// NoSQLServiceOptions use k8s service to configure the connection to a mongodb or infinispan server.
+type NoSQLServiceOptions struct {
+	// Name of the k8s service.
+	Name string `json:"name"`
+	// Namespace of the k8s service. Defaults to the SonataFlowPlatform's or SonataFlow's local namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Port to use when connecting to the k8s service. Defaults to 27017 for mongodb and 11222 for infinispan.
+	// +optional
+	Port *int `json:"port,omitempty"`
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+// SecretPropertiesDelivery how the Secret-backed properties of a workflow reach the workflow container.
+// +kubebuilder:validation:Enum=env;file
+type SecretPropertiesDelivery string
+
+const (
+	// EnvSecretPropertiesDelivery delivers every Secret-backed property as an environment variable named after the
+	// property following the MicroProfile Config rules, for example "my.api-key" as "MY_API_KEY".
+	EnvSecretPropertiesDelivery SecretPropertiesDelivery = "env"
+	// FileSecretPropertiesDelivery mounts every Secret-backed property as a file named after the property, read by the
+	// SmallRye Config file system config source.
+	FileSecretPropertiesDelivery SecretPropertiesDelivery = "file"
+)
+
+// PropertyFlowSpec defines the properties of a single SonataFlow, added on top of the ones shared by the platform.
+type PropertyFlowSpec struct {
+	// Properties of the workflow. Literal and ConfigMap-backed values are added to the managed properties ConfigMap,
+	// Secret-backed values are never written to it, they are delivered as configured by secretsDelivery instead.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Flow []PropertyVar `json:"flow,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// SecretsDelivery is either "env", the default, to deliver the Secret-backed properties as environment variables,
+	// or "file" to mount them as files.
+	// +optional
+	SecretsDelivery SecretPropertiesDelivery `json:"secretsDelivery,omitempty"`
+}
+
+// GetSecretsDelivery returns how the Secret-backed properties are delivered, "env" when not set.
+func (p *PropertyFlowSpec) GetSecretsDelivery() SecretPropertiesDelivery {
+	if p == nil || len(p.SecretsDelivery) == 0 {
+		return EnvSecretPropertiesDelivery
+	}
+	return p.SecretsDelivery
+}
+
+// GetSecretProperties returns the properties whose value comes from a Secret.
+func (p *PropertyFlowSpec) GetSecretProperties() []PropertyVar {
+	if p == nil {
+		return nil
+	}
+	var secretProps []PropertyVar
+	for _, propVar := range p.Flow {
+		if len(propVar.Value) == 0 && propVar.ValueFrom != nil && propVar.ValueFrom.SecretKeyRef != nil {
+			secretProps = append(secretProps, propVar)
+		}
+	}
+	return secretProps
+}
+
+// PropertySource where the effective value of a workflow property comes from.
+// +kubebuilder:validation:Enum=immutable;operator;platform;workflow;persistence;service;eventing;discovery;user
+type PropertySource string
+
+const (
+	// ImmutablePropertySource the properties the operator always sets, like the HTTP port.
+	ImmutablePropertySource PropertySource = "immutable"
+	// OperatorPropertySource the defaults managed by the operator, like the workflow service url or the monitoring.
+	OperatorPropertySource PropertySource = "operator"
+	// PlatformPropertySource the properties shared by the SonataFlowPlatform.
+	PlatformPropertySource PropertySource = "platform"
+	// WorkflowPropertySource the properties of the SonataFlow spec.
+	WorkflowPropertySource PropertySource = "workflow"
+	// PersistencePropertySource the persistence of the workflow or of the platform.
+	PersistencePropertySource PropertySource = "persistence"
+	// ServicePropertySource the Data Index and the Jobs Service of the platform.
+	ServicePropertySource PropertySource = "service"
+	// EventingPropertySource the Knative or the Kafka eventing of the workflow.
+	EventingPropertySource PropertySource = "eventing"
+	// DiscoveryPropertySource the addresses resolved by the service discovery.
+	DiscoveryPropertySource PropertySource = "discovery"
+	// UserPropertySource the user properties ConfigMap.
+	UserPropertySource PropertySource = "user"
+)
+
+// PropertyStatus the source of an effective property of the workflow. Values are never reported, they may come from
+// Secrets.
+type PropertyStatus struct {
+	// Name of the property.
+	Name string `json:"name"`
+	// Source the effective value comes from.
+	Source PropertySource `json:"source"`
+	// UserOverridden is true when the property is also defined in the user properties ConfigMap, but the value of the
+	// source takes precedence.
+	// +optional
+	UserOverridden bool `json:"userOverridden,omitempty"`
+}
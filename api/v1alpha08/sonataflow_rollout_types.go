@@ -0,0 +1,167 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RolloutStrategyType defines how a new workflow version replaces the running one
+// +kubebuilder:validation:Enum=replace;blueGreen;canary
+type RolloutStrategyType string
+
+const (
+	// ReplaceRolloutStrategy restarts the running workflow pods with the new version. Default strategy.
+	ReplaceRolloutStrategy RolloutStrategyType = "replace"
+	// BlueGreenRolloutStrategy deploys the new version side by side with the running one and switches all the traffic
+	// to it once it's ready.
+	BlueGreenRolloutStrategy RolloutStrategyType = "blueGreen"
+	// CanaryRolloutStrategy deploys the new version side by side with the running one and sends a fraction of the
+	// traffic to it while it's verified.
+	CanaryRolloutStrategy RolloutStrategyType = "canary"
+)
+
+const (
+	defaultCanaryWeight            int32 = 10
+	defaultCanaryStepSeconds       int32 = 300
+	defaultProgressDeadlineSeconds int32 = 600
+)
+
+// RolloutStrategy describes how new versions of a workflow built by the operator are rolled out.
+type RolloutStrategy struct {
+	// Type of the rollout strategy. One of "replace", "blueGreen" or "canary". Defaults to "replace".
+	// +optional
+	Type RolloutStrategyType `json:"type,omitempty"`
+	// Canary configures the canary strategy. Only used when type is "canary".
+	// +optional
+	Canary *CanaryRolloutOptions `json:"canary,omitempty"`
+	// ProgressDeadlineSeconds is the maximum time in seconds for the new version to become ready before it's
+	// rolled back. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// CanaryRolloutOptions configures the canary rollout strategy.
+type CanaryRolloutOptions struct {
+	// Weight is the percentage of the traffic sent to the new version while it's verified. Defaults to 10.
+	// For the kubernetes deployment model, the traffic is split by the number of replicas of each version.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// StepSeconds is the time in seconds the new version must stay ready while it serves its weight of the traffic
+	// before it's promoted. The new version is rolled back if it becomes unavailable in the meantime. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StepSeconds *int32 `json:"stepSeconds,omitempty"`
+}
+
+// GetType returns the rollout strategy type, ReplaceRolloutStrategy if not set.
+func (r *RolloutStrategy) GetType() RolloutStrategyType {
+	if r == nil || len(r.Type) == 0 {
+		return ReplaceRolloutStrategy
+	}
+	return r.Type
+}
+
+// GetCanaryWeight returns the canary traffic weight, or the default one if not set.
+func (r *RolloutStrategy) GetCanaryWeight() int32 {
+	if r == nil || r.Canary == nil || r.Canary.Weight == nil {
+		return defaultCanaryWeight
+	}
+	return *r.Canary.Weight
+}
+
+// GetCanaryStepDuration returns the time the canary must stay ready before its promotion, or the default one if not set.
+func (r *RolloutStrategy) GetCanaryStepDuration() time.Duration {
+	if r == nil || r.Canary == nil || r.Canary.StepSeconds == nil {
+		return time.Duration(defaultCanaryStepSeconds) * time.Second
+	}
+	return time.Duration(*r.Canary.StepSeconds) * time.Second
+}
+
+// GetProgressDeadlineSeconds returns the progress deadline, or the default one if not set.
+func (r *RolloutStrategy) GetProgressDeadlineSeconds() int32 {
+	if r == nil || r.ProgressDeadlineSeconds == nil {
+		return defaultProgressDeadlineSeconds
+	}
+	return *r.ProgressDeadlineSeconds
+}
+
+// RolloutPhase is the current phase of a workflow rollout
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing the new version is deployed and being verified, the stable version still serves the traffic
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePromoting the new version passed the verification and is replacing the stable version
+	RolloutPhasePromoting RolloutPhase = "Promoting"
+	// RolloutPhasePromoted the new version is the stable version
+	RolloutPhasePromoted RolloutPhase = "Promoted"
+	// RolloutPhaseRolledBack the new version failed and the stable version kept serving the traffic
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+)
+
+// RolloutStatus describes the state of the last rollout of a workflow.
+type RolloutStatus struct {
+	// Strategy used by the rollout
+	Strategy RolloutStrategyType `json:"strategy"`
+	// Phase of the rollout
+	Phase RolloutPhase `json:"phase"`
+	// StableImage is the image serving the traffic when the rollout started
+	// +optional
+	StableImage string `json:"stableImage,omitempty"`
+	// CandidateImage is the image being rolled out
+	// +optional
+	CandidateImage string `json:"candidateImage,omitempty"`
+	// StableRevision is the Knative revision serving the traffic when the rollout started
+	// +optional
+	StableRevision string `json:"stableRevision,omitempty"`
+	// CandidateRevision is the Knative revision being rolled out
+	// +optional
+	CandidateRevision string `json:"candidateRevision,omitempty"`
+	// CandidateWeight is the percentage of the traffic sent to the new version
+	// +optional
+	CandidateWeight int32 `json:"candidateWeight,omitempty"`
+	// CandidateReadyTime is when the new version became ready, the canary step starts then
+	// +optional
+	CandidateReadyTime *metav1.Time `json:"candidateReadyTime,omitempty"`
+	// StartTime is when the rollout started
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// LastTransitionTime is the last time the rollout changed its phase
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message is a human-readable explanation of the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsInProgress returns true while the rollout is being verified or promoted.
+func (r *RolloutStatus) IsInProgress() bool {
+	return r != nil && (r.Phase == RolloutPhaseProgressing || r.Phase == RolloutPhasePromoting)
+}
+
+// SetPhase sets the rollout phase and message, updating the transition time when the phase changes.
+func (r *RolloutStatus) SetPhase(phase RolloutPhase, message string) {
+	if r.Phase != phase {
+		r.LastTransitionTime = metav1.Now()
+	}
+	r.Phase = phase
+	r.Message = message
+}
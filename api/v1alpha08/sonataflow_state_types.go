@@ -0,0 +1,56 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaxStateTransitions how many transitions between reconciliation states are kept in the workflow status.
+const MaxStateTransitions = 10
+
+// StateTransition records a workflow moving from a reconciliation state to another.
+type StateTransition struct {
+	// From the state that handled the workflow before, empty for the first state of the workflow
+	// +optional
+	From string `json:"from,omitempty"`
+	// To the state handling the workflow since the transition
+	To string `json:"to"`
+	// Reason of the top level condition that led to the transition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Time when the transition happened
+	Time metav1.Time `json:"time"`
+}
+
+// RecordStateTransition moves the workflow to the given reconciliation state, keeping the MaxStateTransitions most
+// recent transitions. Returns the recorded transition, or nil if the workflow is already in that state.
+func (s *SonataFlowStatus) RecordStateTransition(to string) *StateTransition {
+	if s.ReconciliationState == to {
+		return nil
+	}
+	transition := StateTransition{
+		From:   s.ReconciliationState,
+		To:     to,
+		Reason: s.GetTopLevelCondition().GetReason(),
+		Time:   metav1.Now(),
+	}
+	s.ReconciliationState = to
+	s.StateTransitions = append([]StateTransition{transition}, s.StateTransitions...)
+	if len(s.StateTransitions) > MaxStateTransitions {
+		s.StateTransitions = s.StateTransitions[:MaxStateTransitions]
+	}
+	return &transition
+}
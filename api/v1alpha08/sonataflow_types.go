@@ -29,7 +29,11 @@ import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
 )
 
-const DefaultContainerName = "workflow"
+const (
+	DefaultContainerName = "workflow"
+	// SonataFlowKind is the Kind name of the SonataFlow CR
+	SonataFlowKind string = "SonataFlow"
+)
 
 // DeploymentModel defines how a given pod will be deployed
 // +kubebuilder:validation:Enum=kubernetes;knative
@@ -51,11 +55,19 @@ type FlowPodTemplateSpec struct {
 	// +optional
 	PodSpec `json:",inline"`
 	// +optional
-	// Replicas define the number of pods to start by default for this deployment model. Ignored in "knative" deployment model.
+	// Replicas define the number of pods to start by default for this deployment model. Ignored in "knative" deployment model,
+	// and when autoscaling is configured.
 	Replicas *int32 `json:"replicas,omitempty"`
+	// Autoscaling configures the horizontal autoscaling of the workflow. Ignored in dev profile.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 	// Defines the kind of deployment model for this pod spec. In dev profile, only "kubernetes" is valid.
 	// +optional
 	DeploymentModel DeploymentModel `json:"deploymentModel,omitempty"`
+	// IdlePolicy scales the workflow Deployment to zero when the workflow is idle, and back up when it's needed.
+	// Ignored in "knative" deployment model, that already scales to zero, and in dev profile.
+	// +optional
+	IdlePolicy *IdlePolicySpec `json:"idlePolicy,omitempty"`
 }
 
 // Flow describes the contents of the Workflow definition following the CNCF Serverless Workflow Specification.
@@ -161,6 +173,32 @@ type SonataFlowSpec struct {
 	// Sink describes the sinkBinding details of this SonataFlow instance.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="sink"
 	Sink *duckv1.Destination `json:"sink,omitempty"`
+	// Rollout describes how new versions built by the operator replace the running workflow. Only used by the preview profile.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="rollout"
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+	// Versioning keeps the previous versions of the flow running side by side with the latest one until their active
+	// instances complete. Only used by the preview profile.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="versioning"
+	Versioning *VersioningSpec `json:"versioning,omitempty"`
+	// Exposure describes how the workflow is exposed outside the cluster with an Ingress or a Gateway API HTTPRoute.
+	// When set, status.endpoint reports the URL of the exposed workflow.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="exposure"
+	Exposure *ExposureSpec `json:"exposure,omitempty"`
+	// Monitoring configures the Prometheus metrics of the workflow. Defaults to the monitoring of the platform.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="monitoring"
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// Eventing configures the Knative Brokers or Channels the consumed events come from, their filters and their
+	// delivery, or the Kafka topics of the events. Defaults to the eventing of the platform.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="eventing"
+	Eventing *EventingSpec `json:"eventing,omitempty"`
+	// ServiceDiscovery configures whether the workflow is deployed while some of its service discovery properties
+	// can't be resolved.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="serviceDiscovery"
+	ServiceDiscovery *ServiceDiscoverySpec `json:"serviceDiscovery,omitempty"`
+	// Properties of the workflow sourced from literals, ConfigMaps or Secrets. The Secret-backed values are delivered to
+	// the workflow container without being written to the managed properties ConfigMap.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="properties"
+	Properties *PropertyFlowSpec `json:"properties,omitempty"`
 }
 
 // SonataFlowStatus defines the observed state of SonataFlow
@@ -185,6 +223,51 @@ type SonataFlowStatus struct {
 	// Platform displays which platform is being used by this workflow
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="platform"
 	Platform *SonataFlowPlatformRef `json:"platform,omitempty"`
+	// Rollout displays the state of the last progressive rollout of the workflow
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="rollout"
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// LastSuccessfulBuild the last successful build of the workflow, which produced the image being deployed
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="lastSuccessfulBuild"
+	LastSuccessfulBuild *SonataFlowBuildRecord `json:"lastSuccessfulBuild,omitempty"`
+	// PreviousSuccessfulBuild the successful build before LastSuccessfulBuild, the image to roll back to
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="previousSuccessfulBuild"
+	PreviousSuccessfulBuild *SonataFlowBuildRecord `json:"previousSuccessfulBuild,omitempty"`
+	// ReconciliationState the reconciliation state of the profile that last handled the workflow
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="reconciliationState"
+	ReconciliationState string `json:"reconciliationState,omitempty"`
+	// StateTransitions the most recent transitions between reconciliation states, the most recent first
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="stateTransitions"
+	StateTransitions []StateTransition `json:"stateTransitions,omitempty"`
+	// Versions the versions of the flow currently deployed when versioning is enabled: the latest one and the previous
+	// ones draining their active instances
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="versions"
+	Versions []WorkflowVersionStatus `json:"versions,omitempty"`
+	// Idle describes the activity of the workflow when an idle policy is configured
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="idle"
+	Idle *IdleStatus `json:"idle,omitempty"`
+	// ServiceDiscovery the results of the service discovery of the workflow properties and Knative functions
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="serviceDiscovery"
+	ServiceDiscovery []DiscoveredPropertyStatus `json:"serviceDiscovery,omitempty"`
+	// Properties the effective properties of the workflow with the source of their values, flagging the user
+	// properties ignored in favor of the ones managed by the operator
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="properties"
+	Properties []PropertyStatus `json:"properties,omitempty"`
+}
+
+// SetLastSuccessfulBuild references the given successful build, keeping the former one as the previous successful
+// build. Does nothing if the build is already referenced.
+func (s *SonataFlowStatus) SetLastSuccessfulBuild(build SonataFlowBuildRecord) {
+	if s.LastSuccessfulBuild != nil && s.LastSuccessfulBuild.Number == build.Number && s.LastSuccessfulBuild.InputsHash == build.InputsHash {
+		return
+	}
+	s.PreviousSuccessfulBuild = s.LastSuccessfulBuild
+	s.LastSuccessfulBuild = &build
 }
 
 func (s *SonataFlowStatus) GetTopLevelConditionType() api.ConditionType {
@@ -0,0 +1,86 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VersioningSpec configures the side by side deployment of the versions of the flow.
+type VersioningSpec struct {
+	// Enabled keeps running every previous version of the flow, identified by the "sonataflow.org/version" annotation,
+	// with its own Deployment or Knative Service and properties ConfigMaps until the Data Index reports it doesn't have
+	// any active instance anymore. The new instances always go to the latest version.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled whether the previous versions of the flow are kept running.
+func (v *VersioningSpec) IsEnabled() bool {
+	return v != nil && v.Enabled
+}
+
+// WorkflowVersionPhase describes the lifecycle of a version of the flow.
+type WorkflowVersionPhase string
+
+const (
+	// WorkflowVersionPhaseLatest is the version receiving the new instances.
+	WorkflowVersionPhaseLatest WorkflowVersionPhase = "Latest"
+	// WorkflowVersionPhaseDraining is a previous version running until its active instances complete.
+	WorkflowVersionPhaseDraining WorkflowVersionPhase = "Draining"
+)
+
+// WorkflowVersionStatus describes a version of the flow deployed side by side with the other ones.
+type WorkflowVersionStatus struct {
+	// Version of the flow.
+	Version string `json:"version"`
+	// Phase of the version, "Latest" or "Draining".
+	Phase WorkflowVersionPhase `json:"phase"`
+	// Name of the Deployment or Knative Service, and of the Service, running the version. The properties ConfigMaps
+	// of the version are prefixed by this name.
+	Name string `json:"name"`
+	// Image run by the version.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ActiveInstances is the number of instances of a draining version not completed yet, as last reported by the Data Index.
+	// +optional
+	ActiveInstances *int32 `json:"activeInstances,omitempty"`
+	// Message describes why a draining version is still running.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the phase changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GetVersion returns the status of the given version of the flow, or nil if not deployed.
+func (s *SonataFlowStatus) GetVersion(version string) *WorkflowVersionStatus {
+	for i := range s.Versions {
+		if s.Versions[i].Version == version {
+			return &s.Versions[i]
+		}
+	}
+	return nil
+}
+
+// RemoveVersion removes the given version from the deployed versions.
+func (s *SonataFlowStatus) RemoveVersion(version string) {
+	versions := s.Versions[:0]
+	for _, v := range s.Versions {
+		if v.Version != version {
+			versions = append(versions, v)
+		}
+	}
+	s.Versions = versions
+}
@@ -112,6 +112,85 @@ type SonataFlowBuildStatus struct {
 	// +optional
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="InnerBuild"
 	InnerBuild runtime.RawExtension `json:"innerBuild,omitempty" patchStrategy:"replace"`
+	// BuildNumber sequence number of the current build, increased every time the build is scheduled
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="BuildNumber"
+	BuildNumber int32 `json:"buildNumber,omitempty"`
+	// ImageDigest digest of the image produced by the current build
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="ImageDigest"
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// WorkflowGeneration generation of the workflow built by the current build
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="WorkflowGeneration"
+	WorkflowGeneration int64 `json:"workflowGeneration,omitempty"`
+	// InputsHash hash of the inputs of the current build: the flow, the properties and the resources
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="InputsHash"
+	InputsHash string `json:"inputsHash,omitempty"`
+	// StartTime when the current build was scheduled
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="StartTime"
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime when the current build finished
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="CompletionTime"
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// History finished builds of the workflow, the most recent first.
+	// The number of records is limited by the platform .spec.build.config.historyLimit.
+	// +optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="History"
+	History []SonataFlowBuildRecord `json:"history,omitempty"`
+}
+
+// SonataFlowBuildRecord describes a finished build and the inputs that produced its image.
+// +k8s:openapi-gen=true
+type SonataFlowBuildRecord struct {
+	// Number sequence number of the build
+	Number int32 `json:"number"`
+	// BuildPhase final phase of the build
+	BuildPhase BuildPhase `json:"buildPhase,omitempty"`
+	// ImageTag the image tag produced by the build
+	// +optional
+	ImageTag string `json:"imageTag,omitempty"`
+	// ImageDigest digest of the image produced by the build
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// WorkflowGeneration generation of the workflow built
+	// +optional
+	WorkflowGeneration int64 `json:"workflowGeneration,omitempty"`
+	// InputsHash hash of the flow, the properties and the resources used by the build
+	// +optional
+	InputsHash string `json:"inputsHash,omitempty"`
+	// StartTime when the build was scheduled
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime when the build finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Error found during the build, if any
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// IsFinished returns true if the current build has reached a final phase.
+func (k *SonataFlowBuildStatus) IsFinished() bool {
+	return k.BuildPhase == BuildPhaseSucceeded || k.BuildPhase == BuildPhaseFailed || k.BuildPhase == BuildPhaseError
+}
+
+// Record gets the SonataFlowBuildRecord for the current build.
+func (k *SonataFlowBuildStatus) Record() SonataFlowBuildRecord {
+	return SonataFlowBuildRecord{
+		Number:             k.BuildNumber,
+		BuildPhase:         k.BuildPhase,
+		ImageTag:           k.ImageTag,
+		ImageDigest:        k.ImageDigest,
+		WorkflowGeneration: k.WorkflowGeneration,
+		InputsHash:         k.InputsHash,
+		StartTime:          k.StartTime,
+		CompletionTime:     k.CompletionTime,
+		Error:              k.Error,
+	}
 }
 
 // SetInnerBuild use to define a new object pointer to the inner build.
@@ -143,6 +222,8 @@ func (k *SonataFlowBuildStatus) GetInnerBuild(innerBuild interface{}) error {
 // +k8s:openapi-gen=true
 // +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.status.imageTag`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.buildPhase`
+// +kubebuilder:printcolumn:name="Build",type=integer,JSONPath=`.status.buildNumber`,priority=1
+// +kubebuilder:printcolumn:name="Digest",type=string,JSONPath=`.status.imageDigest`,priority=1
 // +kubebuilder:resource:shortName={"sfb", "sfbuild", "sfbuilds"}
 // +operator-sdk:csv:customresourcedefinitions:resources={{BuildConfig,build.openshift.io/v1,"An Openshift Build Config"}}
 // +operator-sdk:csv:customresourcedefinitions:displayName="SonataFlowBuild"
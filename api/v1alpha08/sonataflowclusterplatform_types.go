@@ -31,7 +31,9 @@ type SonataFlowClusterPlatformSpec struct {
 	// PlatformRef defines which existing SonataFlowPlatform's supporting services should be used cluster-wide.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PlatformRef"
 	PlatformRef SonataFlowPlatformRef `json:"platformRef"`
-	// Capabilities defines which platform capabilities should be applied cluster-wide. If nil, defaults to `capabilities.workflows["services"]`
+	// Capabilities defines which platform capabilities should be applied cluster-wide. If nil, defaults to `capabilities.workflows["services"]`.
+	// Besides the supporting services, the referenced SonataFlowPlatform can share its build configuration ("build"),
+	// its workflow managed properties ("properties"), its persistence ("persistence") and its monitoring ("monitoring").
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capabilities"
 	Capabilities *SonataFlowClusterPlatformCapSpec `json:"capabilities,omitempty"`
 }
@@ -42,7 +44,7 @@ type SonataFlowClusterPlatformCapSpec struct {
 	Workflows []WorkFlowCapability `json:"workflows,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=services
+// +kubebuilder:validation:Enum=services;build;properties;persistence;monitoring
 type WorkFlowCapability string
 
 // SonataFlowPlatformRef defines which existing SonataFlowPlatform's supporting services should be used cluster-wide.
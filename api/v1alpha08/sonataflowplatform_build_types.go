@@ -42,13 +42,27 @@ type BuildPlatformConfig struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 	// BuildStrategy to use to build workflows in the platform.
 	// Usually, the operator elect the strategy based on the platform.
-	// Note that this field might be read only in certain scenarios.
+	// Note that this field might be read only in certain scenarios, "tekton" is always kept.
 	BuildStrategy BuildStrategy `json:"strategy,omitempty"`
 	// BuildStrategyOptions additional options to add to the build strategy.
 	// See https://sonataflow.org/serverlessworkflow/main/cloud/operator/build-and-deploy-workflows.html
 	BuildStrategyOptions map[string]string `json:"strategyOptions,omitempty"`
 	// Registry the registry where to publish the built image
 	Registry RegistrySpec `json:"registry,omitempty"`
+	// HistoryLimit how many finished builds are kept in the SonataFlowBuild status history. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+const defaultBuildHistoryLimit = 5
+
+// GetHistoryLimit returns the specified build history limit or the default one
+func (b *BuildPlatformConfig) GetHistoryLimit() int {
+	if b.HistoryLimit == nil {
+		return defaultBuildHistoryLimit
+	}
+	return int(*b.HistoryLimit)
 }
 
 // GetTimeout returns the specified duration or a default one
@@ -101,7 +115,7 @@ const (
 	// PlatformBuildStrategy uses the cluster to perform the build.
 	// E.g. on OpenShift, BuildConfig.
 	PlatformBuildStrategy BuildStrategy = "platform"
-
-	// In the future we can have "custom" which will delegate the build to an external actor provided by the administrator
-	// See https://issues.redhat.com/browse/KOGITO-9084
+	// TektonBuildStrategy delegates the build to a Tekton Pipeline provided by the administrator.
+	// E.g. an existing supply chain pipeline that also scans and signs the image.
+	TektonBuildStrategy BuildStrategy = "tekton"
 )
@@ -0,0 +1,44 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// NetworkIsolationSpec configures the NetworkPolicies generated for the workflows and the platform services.
+//
+// Once enabled, the pods of the workflows, of the Data Index and of the Jobs Service only accept the traffic of the
+// platform services, of Knative Serving and Eventing, and of the given ingress peers. They can only reach the cluster
+// DNS, the platform services, the Knative brokers, the Kafka bootstrap servers, the databases referenced by a
+// serviceRef, the services resolved by the service discovery, and the given egress peers.
+// Hosts outside the cluster can't be resolved into NetworkPolicy peers, they must be added as egress peers.
+type NetworkIsolationSpec struct {
+	// Enabled generates the NetworkPolicies.
+	Enabled bool `json:"enabled"`
+	// IngressPeers are the extra peers allowed to call the workflows and the platform services, like the namespace of
+	// the ingress controller or of the Prometheus instance scraping the metrics.
+	// +optional
+	IngressPeers []networkingv1.NetworkPolicyPeer `json:"ingressPeers,omitempty"`
+	// EgressPeers are the extra peers the workflows and the platform services are allowed to call, like the IP blocks
+	// of the external services invoked by the workflows.
+	// +optional
+	EgressPeers []networkingv1.NetworkPolicyPeer `json:"egressPeers,omitempty"`
+}
+
+// IsEnabled returns true if the network isolation is configured and enabled.
+func (n *NetworkIsolationSpec) IsEnabled() bool {
+	return n != nil && n.Enabled
+}
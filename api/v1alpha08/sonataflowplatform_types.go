@@ -20,6 +20,8 @@
 package v1alpha08
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
@@ -52,15 +54,63 @@ type SonataFlowPlatformSpec struct {
 	// that don't provide one of their own.
 	// +optional
 	Persistence *PlatformPersistenceOptionsSpec `json:"persistence,omitempty"`
+	// Monitoring configures the Prometheus metrics of the platform services, and of the workflows that don't provide
+	// one of their own.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="monitoring"
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// Eventing configures the Knative Broker or Channel, and the delivery, of the events consumed by the workflows that
+	// don't provide one of their own. With the "kafka" mode, the Data Index and the Jobs Service also exchange their
+	// events through the Kafka cluster.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="eventing"
+	Eventing *PlatformEventingSpec `json:"eventing,omitempty"`
+	// DeletionPolicies configures the cleanup of the images built for the workflows and of the build cache when the
+	// workflows or the platform are deleted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="deletionPolicies"
+	DeletionPolicies *DeletionPoliciesSpec `json:"deletionPolicies,omitempty"`
 	// Properties defines the property set for a given actor in the current context.
 	// For example, the workflow managed properties. One can define here a set of properties for SonataFlow deployments
 	// that will be reused across every workflow deployment.
 	//
-	// These properties MAY NOT be propagated to a SonataFlowClusterPlatform since PropertyVarSource can only refer local context sources.
+	// When shared through the "properties" capability of a SonataFlowClusterPlatform, the PropertyVarSource references
+	// are resolved in the namespace of the SonataFlowPlatform referenced by the SonataFlowClusterPlatform.
 	// +optional
 	Properties *PropertyPlatformSpec `json:"properties,omitempty"`
+	// ApplyStrategy defines how the operator writes the objects it manages for the workflows. With "update", the default,
+	// the objects are read and written back merged with the operator desired state. With "serverSideApply", the objects
+	// are written with Kubernetes server-side apply, so the operator only owns the fields it sets and leaves the others
+	// to the users and controllers managing them, like a HorizontalPodAutoscaler or a GitOps tool.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="applyStrategy"
+	ApplyStrategy ApplyStrategy `json:"applyStrategy,omitempty"`
+	// ClusterCapabilities selects which of the capabilities shared by the active SonataFlowClusterPlatform this platform
+	// inherits, including the credential Secrets of the shared persistence. If nil, only the platform services are
+	// inherited; an empty list opts out of all of them.
+	// The fields set in this platform always take precedence over the inherited ones.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="clusterCapabilities"
+	ClusterCapabilities *SonataFlowClusterPlatformCapSpec `json:"clusterCapabilities,omitempty"`
+	// NetworkIsolation generates the NetworkPolicies allowing only the traffic required by the workflows of this
+	// namespace and by the platform services.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="networkIsolation"
+	NetworkIsolation *NetworkIsolationSpec `json:"networkIsolation,omitempty"`
 }
 
+// ApplyStrategy is the way the operator writes the objects it manages for the workflows
+// +kubebuilder:validation:Enum=update;serverSideApply
+type ApplyStrategy string
+
+const (
+	// UpdateApplyStrategy reads the objects and writes them back merged with the operator desired state
+	UpdateApplyStrategy ApplyStrategy = "update"
+	// ServerSideApplyStrategy writes the objects with server-side apply, conflicting fields owned by other field
+	// managers are reported in the workflow conditions
+	ServerSideApplyStrategy ApplyStrategy = "serverSideApply"
+)
+
 // PlatformCluster is the kind of orchestration cluster the platform is installed into
 // +kubebuilder:validation:Enum=kubernetes;openshift
 type PlatformCluster string
@@ -77,6 +127,8 @@ const (
 	PlatformWarmingReason    = "Warming"
 	PlatformFailureReason    = "Failure"
 	PlatformDuplicatedReason = "Duplicated"
+	// PlatformDeletionBlockedReason the platform deletion waits for the workflows depending on it to be deleted
+	PlatformDeletionBlockedReason = "DeletionBlocked"
 )
 
 // SonataFlowPlatformStatus defines the observed state of SonataFlowPlatform
@@ -95,6 +147,49 @@ type SonataFlowPlatformStatus struct {
 	// ClusterPlatformRef information related to the (optional) active SonataFlowClusterPlatform
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="clusterPlatformRef"
 	ClusterPlatformRef *SonataFlowClusterPlatformRefStatus `json:"clusterPlatformRef,omitempty"`
+	// Persistence displays the persistence provisioned by this platform
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="persistence"
+	Persistence *PlatformPersistenceStatus `json:"persistence,omitempty"`
+	// EffectiveConfig displays the configuration used by the workflows once the capabilities shared by the active
+	// SonataFlowClusterPlatform are merged with this platform
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="effectiveConfig"
+	EffectiveConfig *PlatformEffectiveConfigStatus `json:"effectiveConfig,omitempty"`
+}
+
+// PlatformEffectiveConfigStatus displays the platform configuration resulting from the merge with the capabilities
+// shared by the active SonataFlowClusterPlatform
+// +k8s:openapi-gen=true
+type PlatformEffectiveConfigStatus struct {
+	// Build the effective build configuration
+	Build BuildPlatformSpec `json:"build,omitempty"`
+	// Properties the effective workflow managed properties
+	Properties *PropertyPlatformSpec `json:"properties,omitempty"`
+	// Persistence the effective persistence configuration
+	Persistence *PlatformPersistenceOptionsSpec `json:"persistence,omitempty"`
+	// Monitoring the effective monitoring configuration
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// InheritedFields lists the fields taken from the SonataFlowPlatform referenced by the SonataFlowClusterPlatform,
+	// for example "build.config.registry" or "properties.flow[quarkus.log.level]".
+	InheritedFields []string `json:"inheritedFields,omitempty"`
+}
+
+// InheritedPropertyField returns the InheritedFields entry of the given workflow managed property.
+func InheritedPropertyField(name string) string {
+	return fmt.Sprintf("properties.flow[%s]", name)
+}
+
+// IsInherited returns true if the given field is taken from the SonataFlowPlatform referenced by the
+// SonataFlowClusterPlatform.
+func (in *PlatformEffectiveConfigStatus) IsInherited(field string) bool {
+	if in == nil {
+		return false
+	}
+	for _, f := range in.InheritedFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
 }
 
 // SonataFlowClusterPlatformRefStatus information related to the (optional) active SonataFlowClusterPlatform
@@ -32,3 +32,61 @@ func NewSonataFlowPlatformList() SonataFlowPlatformList {
 		},
 	}
 }
+
+const (
+	provisionedPostgreSQLSuffix       = "-postgresql"
+	provisionedPostgreSQLPort         = 5432
+	provisionedPostgreSQLDatabaseName = "sonataflow"
+	// ProvisionedPostgreSQLUserKey key of the user name in the Secret holding the credentials of a provisioned postgresql
+	ProvisionedPostgreSQLUserKey = "POSTGRESQL_USER"
+	// ProvisionedPostgreSQLPasswordKey key of the password in the Secret holding the credentials of a provisioned postgresql
+	ProvisionedPostgreSQLPasswordKey = "POSTGRESQL_PASSWORD"
+)
+
+// GetProvisionedPostgreSQLName returns the name of the StatefulSet, Service, PersistentVolumeClaim and Secret of the
+// postgresql database provisioned by this platform.
+func (p *SonataFlowPlatform) GetProvisionedPostgreSQLName() string {
+	return p.Name + provisionedPostgreSQLSuffix
+}
+
+// GetProvisionedPostgreSQLDatabaseName returns the name of the database created in the postgresql server provisioned
+// by this platform, defaulting to "sonataflow".
+func (p *SonataFlowPlatform) GetProvisionedPostgreSQLDatabaseName() string {
+	if p.Spec.Persistence != nil && p.Spec.Persistence.ProvisionedPostgreSQL != nil &&
+		len(p.Spec.Persistence.ProvisionedPostgreSQL.DatabaseName) > 0 {
+		return p.Spec.Persistence.ProvisionedPostgreSQL.DatabaseName
+	}
+	return provisionedPostgreSQLDatabaseName
+}
+
+// GetPersistence returns the persistence shared by the platform with its services and workflows. A provisioned
+// postgresql database is returned as a postgresql persistence referencing the Service and Secret created for it.
+func (p *SonataFlowPlatform) GetPersistence() *PlatformPersistenceOptionsSpec {
+	if p == nil || p.Spec.Persistence == nil {
+		return nil
+	}
+	if p.Spec.Persistence.ProvisionedPostgreSQL == nil {
+		return p.Spec.Persistence
+	}
+	port := provisionedPostgreSQLPort
+	return &PlatformPersistenceOptionsSpec{
+		PostgreSQL: &PlatformPersistencePostgreSQL{
+			SecretRef: PostgreSQLSecretOptions{
+				Name:        p.GetProvisionedPostgreSQLName(),
+				UserKey:     ProvisionedPostgreSQLUserKey,
+				PasswordKey: ProvisionedPostgreSQLPasswordKey,
+			},
+			ServiceRef: &SQLServiceOptions{
+				Name:         p.GetProvisionedPostgreSQLName(),
+				Namespace:    p.Namespace,
+				Port:         &port,
+				DatabaseName: p.GetProvisionedPostgreSQLDatabaseName(),
+			},
+		},
+	}
+}
+
+// IsServerSideApplyEnabled returns true if the objects of the workflows are written with server-side apply.
+func (p *SonataFlowPlatform) IsServerSideApplyEnabled() bool {
+	return p != nil && p.Spec.ApplyStrategy == ServerSideApplyStrategy
+}
@@ -23,12 +23,66 @@ package v1alpha08
 import (
 	"github.com/serverlessworkflow/sdk-go/v2/model"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingMetric) DeepCopyInto(out *AutoscalingMetric) {
+	*out = *in
+	out.TargetAverageValue = in.TargetAverageValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingMetric.
+func (in *AutoscalingMetric) DeepCopy() *AutoscalingMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]AutoscalingMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildPlatformConfig) DeepCopyInto(out *BuildPlatformConfig) {
 	*out = *in
@@ -45,6 +99,11 @@ func (in *BuildPlatformConfig) DeepCopyInto(out *BuildPlatformConfig) {
 		}
 	}
 	out.Registry = in.Registry
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildPlatformConfig.
@@ -110,6 +169,31 @@ func (in *BuildTemplate) DeepCopy() *BuildTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRolloutOptions) DeepCopyInto(out *CanaryRolloutOptions) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StepSeconds != nil {
+		in, out := &in.StepSeconds, &out.StepSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRolloutOptions.
+func (in *CanaryRolloutOptions) DeepCopy() *CanaryRolloutOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRolloutOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapWorkflowResource) DeepCopyInto(out *ConfigMapWorkflowResource) {
 	*out = *in
@@ -126,6 +210,29 @@ func (in *ConfigMapWorkflowResource) DeepCopy() *ConfigMapWorkflowResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumedEventSpec) DeepCopyInto(out *ConsumedEventSpec) {
+	*out = *in
+	in.EventingOptionsSpec.DeepCopyInto(&out.EventingOptionsSpec)
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumedEventSpec.
+func (in *ConsumedEventSpec) DeepCopy() *ConsumedEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumedEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerSpec) DeepCopyInto(out *ContainerSpec) {
 	*out = *in
@@ -213,6 +320,21 @@ func (in *ContainerSpec) DeepCopy() *ContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionPoliciesSpec) DeepCopyInto(out *DeletionPoliciesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionPoliciesSpec.
+func (in *DeletionPoliciesSpec) DeepCopy() *DeletionPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevModePlatformSpec) DeepCopyInto(out *DevModePlatformSpec) {
 	*out = *in
@@ -228,6 +350,162 @@ func (in *DevModePlatformSpec) DeepCopy() *DevModePlatformSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveredPropertyStatus) DeepCopyInto(out *DiscoveredPropertyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredPropertyStatus.
+func (in *DiscoveredPropertyStatus) DeepCopy() *DiscoveredPropertyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveredPropertyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventDeliverySpec) DeepCopyInto(out *EventDeliverySpec) {
+	*out = *in
+	if in.DeadLetterSink != nil {
+		in, out := &in.DeadLetterSink, &out.DeadLetterSink
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffPolicy != nil {
+		in, out := &in.BackoffPolicy, &out.BackoffPolicy
+		*out = new(EventBackoffPolicy)
+		**out = **in
+	}
+	if in.BackoffDelay != nil {
+		in, out := &in.BackoffDelay, &out.BackoffDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventDeliverySpec.
+func (in *EventDeliverySpec) DeepCopy() *EventDeliverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventDeliverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventingBackendSpec) DeepCopyInto(out *EventingBackendSpec) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaEventingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingBackendSpec.
+func (in *EventingBackendSpec) DeepCopy() *EventingBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventingBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventingOptionsSpec) DeepCopyInto(out *EventingOptionsSpec) {
+	*out = *in
+	if in.Channel != nil {
+		in, out := &in.Channel, &out.Channel
+		*out = new(duckv1.KReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(EventDeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingOptionsSpec.
+func (in *EventingOptionsSpec) DeepCopy() *EventingOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventingOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventingSpec) DeepCopyInto(out *EventingSpec) {
+	*out = *in
+	in.EventingOptionsSpec.DeepCopyInto(&out.EventingOptionsSpec)
+	in.EventingBackendSpec.DeepCopyInto(&out.EventingBackendSpec)
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]ConsumedEventSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingSpec.
+func (in *EventingSpec) DeepCopy() *EventingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposureSpec) DeepCopyInto(out *ExposureSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayReference)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposureSpec.
+func (in *ExposureSpec) DeepCopy() *ExposureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Flow) DeepCopyInto(out *Flow) {
 	*out = *in
@@ -330,6 +608,16 @@ func (in *FlowPodTemplateSpec) DeepCopyInto(out *FlowPodTemplateSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdlePolicy != nil {
+		in, out := &in.IdlePolicy, &out.IdlePolicy
+		*out = new(IdlePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowPodTemplateSpec.
@@ -337,48 +625,366 @@ func (in *FlowPodTemplateSpec) DeepCopy() *FlowPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FlowPodTemplateSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistenceOptionsSpec) DeepCopyInto(out *PersistenceOptionsSpec) {
-	*out = *in
-	if in.PostgreSQL != nil {
-		in, out := &in.PostgreSQL, &out.PostgreSQL
-		*out = new(PersistencePostgreSQL)
+	out := new(FlowPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayReference.
+func (in *GatewayReference) DeepCopy() *GatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdlePolicySpec) DeepCopyInto(out *IdlePolicySpec) {
+	*out = *in
+	if in.QuietPeriod != nil {
+		in, out := &in.QuietPeriod, &out.QuietPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WakeUpLeadTime != nil {
+		in, out := &in.WakeUpLeadTime, &out.WakeUpLeadTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdlePolicySpec.
+func (in *IdlePolicySpec) DeepCopy() *IdlePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdlePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleStatus) DeepCopyInto(out *IdleStatus) {
+	*out = *in
+	in.LastActivityTime.DeepCopyInto(&out.LastActivityTime)
+	if in.PodActivity != nil {
+		in, out := &in.PodActivity, &out.PodActivity
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleStatus.
+func (in *IdleStatus) DeepCopy() *IdleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanSecretOptions) DeepCopyInto(out *InfinispanSecretOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSecretOptions.
+func (in *InfinispanSecretOptions) DeepCopy() *InfinispanSecretOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanSecretOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaEventingSpec) DeepCopyInto(out *KafkaEventingSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = new(KafkaTopicsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaEventingSpec.
+func (in *KafkaEventingSpec) DeepCopy() *KafkaEventingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaEventingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicsSpec) DeepCopyInto(out *KafkaTopicsSpec) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicsSpec.
+func (in *KafkaTopicsSpec) DeepCopy() *KafkaTopicsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSecretOptions) DeepCopyInto(out *MongoDBSecretOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSecretOptions.
+func (in *MongoDBSecretOptions) DeepCopy() *MongoDBSecretOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSecretOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkIsolationSpec) DeepCopyInto(out *NetworkIsolationSpec) {
+	*out = *in
+	if in.IngressPeers != nil {
+		in, out := &in.IngressPeers, &out.IngressPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EgressPeers != nil {
+		in, out := &in.EgressPeers, &out.EgressPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIsolationSpec.
+func (in *NetworkIsolationSpec) DeepCopy() *NetworkIsolationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkIsolationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoSQLServiceOptions) DeepCopyInto(out *NoSQLServiceOptions) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoSQLServiceOptions.
+func (in *NoSQLServiceOptions) DeepCopy() *NoSQLServiceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NoSQLServiceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceInfinispan) DeepCopyInto(out *PersistenceInfinispan) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(NoSQLServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceInfinispan.
+func (in *PersistenceInfinispan) DeepCopy() *PersistenceInfinispan {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceInfinispan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceMongoDB) DeepCopyInto(out *PersistenceMongoDB) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(NoSQLServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceMongoDB.
+func (in *PersistenceMongoDB) DeepCopy() *PersistenceMongoDB {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceMongoDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceOptionsSpec) DeepCopyInto(out *PersistenceOptionsSpec) {
+	*out = *in
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(PersistencePostgreSQL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(PersistenceMongoDB)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Infinispan != nil {
+		in, out := &in.Infinispan, &out.Infinispan
+		*out = new(PersistenceInfinispan)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceOptionsSpec.
+func (in *PersistenceOptionsSpec) DeepCopy() *PersistenceOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistencePostgreSQL) DeepCopyInto(out *PersistencePostgreSQL) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(PostgreSQLServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistencePostgreSQL.
+func (in *PersistencePostgreSQL) DeepCopy() *PersistencePostgreSQL {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistencePostgreSQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformEffectiveConfigStatus) DeepCopyInto(out *PlatformEffectiveConfigStatus) {
+	*out = *in
+	in.Build.DeepCopyInto(&out.Build)
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = new(PropertyPlatformSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PlatformPersistenceOptionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InheritedFields != nil {
+		in, out := &in.InheritedFields, &out.InheritedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceOptionsSpec.
-func (in *PersistenceOptionsSpec) DeepCopy() *PersistenceOptionsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformEffectiveConfigStatus.
+func (in *PlatformEffectiveConfigStatus) DeepCopy() *PlatformEffectiveConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistenceOptionsSpec)
+	out := new(PlatformEffectiveConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistencePostgreSQL) DeepCopyInto(out *PersistencePostgreSQL) {
+func (in *PlatformEventingSpec) DeepCopyInto(out *PlatformEventingSpec) {
 	*out = *in
-	out.SecretRef = in.SecretRef
-	if in.ServiceRef != nil {
-		in, out := &in.ServiceRef, &out.ServiceRef
-		*out = new(PostgreSQLServiceOptions)
-		(*in).DeepCopyInto(*out)
-	}
+	in.EventingOptionsSpec.DeepCopyInto(&out.EventingOptionsSpec)
+	in.EventingBackendSpec.DeepCopyInto(&out.EventingBackendSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistencePostgreSQL.
-func (in *PersistencePostgreSQL) DeepCopy() *PersistencePostgreSQL {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformEventingSpec.
+func (in *PlatformEventingSpec) DeepCopy() *PlatformEventingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistencePostgreSQL)
+	out := new(PlatformEventingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -391,6 +997,21 @@ func (in *PlatformPersistenceOptionsSpec) DeepCopyInto(out *PlatformPersistenceO
 		*out = new(PlatformPersistencePostgreSQL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(PersistenceMongoDB)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Infinispan != nil {
+		in, out := &in.Infinispan, &out.Infinispan
+		*out = new(PersistenceInfinispan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisionedPostgreSQL != nil {
+		in, out := &in.ProvisionedPostgreSQL, &out.ProvisionedPostgreSQL
+		*out = new(ProvisionedPostgreSQLOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformPersistenceOptionsSpec.
@@ -424,6 +1045,26 @@ func (in *PlatformPersistencePostgreSQL) DeepCopy() *PlatformPersistencePostgreS
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformPersistenceStatus) DeepCopyInto(out *PlatformPersistenceStatus) {
+	*out = *in
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(ProvisionedPostgreSQLStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformPersistenceStatus.
+func (in *PlatformPersistenceStatus) DeepCopy() *PlatformPersistenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformPersistenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformServiceRefStatus) DeepCopyInto(out *PlatformServiceRefStatus) {
 	*out = *in
@@ -684,6 +1325,28 @@ func (in *PostgreSQLServiceOptions) DeepCopy() *PostgreSQLServiceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertyFlowSpec) DeepCopyInto(out *PropertyFlowSpec) {
+	*out = *in
+	if in.Flow != nil {
+		in, out := &in.Flow, &out.Flow
+		*out = make([]PropertyVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertyFlowSpec.
+func (in *PropertyFlowSpec) DeepCopy() *PropertyFlowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertyFlowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PropertyPlatformSpec) DeepCopyInto(out *PropertyPlatformSpec) {
 	*out = *in
@@ -706,6 +1369,21 @@ func (in *PropertyPlatformSpec) DeepCopy() *PropertyPlatformSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertyStatus) DeepCopyInto(out *PropertyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertyStatus.
+func (in *PropertyStatus) DeepCopy() *PropertyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PropertyVar) DeepCopyInto(out *PropertyVar) {
 	*out = *in
@@ -751,6 +1429,49 @@ func (in *PropertyVarSource) DeepCopy() *PropertyVarSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedPostgreSQLOptions) DeepCopyInto(out *ProvisionedPostgreSQLOptions) {
+	*out = *in
+	if in.StorageSize != nil {
+		in, out := &in.StorageSize, &out.StorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedPostgreSQLOptions.
+func (in *ProvisionedPostgreSQLOptions) DeepCopy() *ProvisionedPostgreSQLOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedPostgreSQLOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedPostgreSQLStatus) DeepCopyInto(out *ProvisionedPostgreSQLStatus) {
+	*out = *in
+	in.ServiceRef.DeepCopyInto(&out.ServiceRef)
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedPostgreSQLStatus.
+func (in *ProvisionedPostgreSQLStatus) DeepCopy() *ProvisionedPostgreSQLStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedPostgreSQLStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistrySpec) DeepCopyInto(out *RegistrySpec) {
 	*out = *in
@@ -766,6 +1487,52 @@ func (in *RegistrySpec) DeepCopy() *RegistrySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.CandidateReadyTime != nil {
+		in, out := &in.CandidateReadyTime, &out.CandidateReadyTime
+		*out = (*in).DeepCopy()
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryRolloutOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SQLServiceOptions) DeepCopyInto(out *SQLServiceOptions) {
 	*out = *in
@@ -786,6 +1553,21 @@ func (in *SQLServiceOptions) DeepCopy() *SQLServiceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceDiscoverySpec) DeepCopyInto(out *ServiceDiscoverySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDiscoverySpec.
+func (in *ServiceDiscoverySpec) DeepCopy() *ServiceDiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceDiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
@@ -923,6 +1705,29 @@ func (in *SonataFlowBuildList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SonataFlowBuildRecord) DeepCopyInto(out *SonataFlowBuildRecord) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonataFlowBuildRecord.
+func (in *SonataFlowBuildRecord) DeepCopy() *SonataFlowBuildRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SonataFlowBuildRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SonataFlowBuildSpec) DeepCopyInto(out *SonataFlowBuildSpec) {
 	*out = *in
@@ -943,6 +1748,21 @@ func (in *SonataFlowBuildSpec) DeepCopy() *SonataFlowBuildSpec {
 func (in *SonataFlowBuildStatus) DeepCopyInto(out *SonataFlowBuildStatus) {
 	*out = *in
 	in.InnerBuild.DeepCopyInto(&out.InnerBuild)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]SonataFlowBuildRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonataFlowBuildStatus.
@@ -1213,11 +2033,36 @@ func (in *SonataFlowPlatformSpec) DeepCopyInto(out *SonataFlowPlatformSpec) {
 		*out = new(PlatformPersistenceOptionsSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Eventing != nil {
+		in, out := &in.Eventing, &out.Eventing
+		*out = new(PlatformEventingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionPolicies != nil {
+		in, out := &in.DeletionPolicies, &out.DeletionPolicies
+		*out = new(DeletionPoliciesSpec)
+		**out = **in
+	}
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = new(PropertyPlatformSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClusterCapabilities != nil {
+		in, out := &in.ClusterCapabilities, &out.ClusterCapabilities
+		*out = new(SonataFlowClusterPlatformCapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonataFlowPlatformSpec.
@@ -1246,6 +2091,16 @@ func (in *SonataFlowPlatformStatus) DeepCopyInto(out *SonataFlowPlatformStatus)
 		*out = new(SonataFlowClusterPlatformRefStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PlatformPersistenceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(PlatformEffectiveConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonataFlowPlatformStatus.
@@ -1274,6 +2129,41 @@ func (in *SonataFlowSpec) DeepCopyInto(out *SonataFlowSpec) {
 		*out = new(duckv1.Destination)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(VersioningSpec)
+		**out = **in
+	}
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(ExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Eventing != nil {
+		in, out := &in.Eventing, &out.Eventing
+		*out = new(EventingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceDiscovery != nil {
+		in, out := &in.ServiceDiscovery, &out.ServiceDiscovery
+		*out = new(ServiceDiscoverySpec)
+		**out = **in
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = new(PropertyFlowSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonataFlowSpec.
@@ -1307,6 +2197,50 @@ func (in *SonataFlowStatus) DeepCopyInto(out *SonataFlowStatus) {
 		*out = new(SonataFlowPlatformRef)
 		**out = **in
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSuccessfulBuild != nil {
+		in, out := &in.LastSuccessfulBuild, &out.LastSuccessfulBuild
+		*out = new(SonataFlowBuildRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreviousSuccessfulBuild != nil {
+		in, out := &in.PreviousSuccessfulBuild, &out.PreviousSuccessfulBuild
+		*out = new(SonataFlowBuildRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StateTransitions != nil {
+		in, out := &in.StateTransitions, &out.StateTransitions
+		*out = make([]StateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]WorkflowVersionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Idle != nil {
+		in, out := &in.Idle, &out.Idle
+		*out = new(IdleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceDiscovery != nil {
+		in, out := &in.ServiceDiscovery, &out.ServiceDiscovery
+		*out = make([]DiscoveredPropertyStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]PropertyStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonataFlowStatus.
@@ -1319,6 +2253,37 @@ func (in *SonataFlowStatus) DeepCopy() *SonataFlowStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateTransition) DeepCopyInto(out *StateTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateTransition.
+func (in *StateTransition) DeepCopy() *StateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(StateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersioningSpec) DeepCopyInto(out *VersioningSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersioningSpec.
+func (in *VersioningSpec) DeepCopy() *VersioningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VersioningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowResources) DeepCopyInto(out *WorkflowResources) {
 	*out = *in
@@ -1338,3 +2303,24 @@ func (in *WorkflowResources) DeepCopy() *WorkflowResources {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowVersionStatus) DeepCopyInto(out *WorkflowVersionStatus) {
+	*out = *in
+	if in.ActiveInstances != nil {
+		in, out := &in.ActiveInstances, &out.ActiveInstances
+		*out = new(int32)
+		**out = **in
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowVersionStatus.
+func (in *WorkflowVersionStatus) DeepCopy() *WorkflowVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -80,6 +80,23 @@ type ContainerRegistrySpec struct {
 type ContainerBuildTask struct {
 	// a KanikoTask, for Kaniko strategy
 	Kaniko *KanikoTask `json:"kaniko,omitempty"`
+	// a BuildahTask, for Buildah strategy
+	Buildah *BuildahTask `json:"buildah,omitempty"`
+	// a BuildKitTask, for BuildKit strategy
+	BuildKit *BuildKitTask `json:"buildKit,omitempty"`
+}
+
+// GetPublishTask returns the PublishTask of the configured task, nil if none is configured.
+func (t *ContainerBuildTask) GetPublishTask() *PublishTask {
+	switch {
+	case t.Kaniko != nil:
+		return &t.Kaniko.PublishTask
+	case t.Buildah != nil:
+		return &t.Buildah.PublishTask
+	case t.BuildKit != nil:
+		return &t.BuildKit.PublishTask
+	}
+	return nil
 }
 
 // ContainerBuildBaseTask is a base for the struct hierarchy
@@ -137,6 +154,49 @@ type KanikoTaskCache struct {
 	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
 }
 
+// BuildahTask is used to configure Buildah
+type BuildahTask struct {
+	ContainerBuildBaseTask `json:",inline"`
+	PublishTask            `json:",inline"`
+	// log more information
+	Verbose *bool `json:"verbose,omitempty"`
+	// use a cache
+	Cache BuildahTaskCache `json:"cache,omitempty"`
+	// AdditionalFlags -- List of additional flags for the `buildah bud` process (see https://github.com/containers/buildah/blob/main/docs/buildah-build.1.md)
+	AdditionalFlags []string `json:"additionalFlags,omitempty"`
+	// Image used by the created Buildah pod
+	BuildahImage string `json:"buildahImage,omitempty"`
+}
+
+// BuildahTaskCache is used to configure Buildah cache
+type BuildahTaskCache struct {
+	// true if a cache is enabled, the image layers are kept and reused by the next builds
+	Enabled *bool `json:"enabled,omitempty"`
+	// the PVC used to store the Buildah containers storage. An ephemeral volume is used when empty.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
+// BuildKitTask is used to configure BuildKit
+type BuildKitTask struct {
+	ContainerBuildBaseTask `json:",inline"`
+	PublishTask            `json:",inline"`
+	// use a cache
+	Cache BuildKitTaskCache `json:"cache,omitempty"`
+	// AdditionalFlags -- List of additional flags for the `buildctl build` process (see https://github.com/moby/buildkit/blob/master/README.md)
+	AdditionalFlags []string `json:"additionalFlags,omitempty"`
+	// Image used by the created BuildKit pod, it must be a rootless BuildKit image (e.g. moby/buildkit:rootless)
+	BuildKitImage string `json:"buildKitImage,omitempty"`
+}
+
+// BuildKitTaskCache is used to configure BuildKit cache
+type BuildKitTaskCache struct {
+	// true if a cache is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+	// the PVC used to export and import the cache. When empty, the cache is exported inline with the published image
+	// and imported from it in the next builds.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
 // ContainerBuildPhase --
 type ContainerBuildPhase string
 
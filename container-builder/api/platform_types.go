@@ -53,6 +53,12 @@ const (
 	// PlatformBuildPublishStrategyKaniko uses Kaniko project (https://github.com/GoogleContainerTools/kaniko)
 	// in order to push the incremental images to the image repository. It can be used with `pod` ContainerBuildStrategy.
 	PlatformBuildPublishStrategyKaniko PlatformContainerBuildPublishStrategy = "Kaniko"
+	// PlatformBuildPublishStrategyBuildah uses Buildah project (https://buildah.io/) in rootless mode
+	// in order to push the incremental images to the image repository. It can be used with `pod` ContainerBuildStrategy.
+	PlatformBuildPublishStrategyBuildah PlatformContainerBuildPublishStrategy = "Buildah"
+	// PlatformBuildPublishStrategyBuildKit uses BuildKit project (https://github.com/moby/buildkit) in rootless mode
+	// in order to push the incremental images to the image repository. It can be used with `pod` ContainerBuildStrategy.
+	PlatformBuildPublishStrategyBuildKit PlatformContainerBuildPublishStrategy = "BuildKit"
 )
 
 // IsOptionEnabled return whether if the BuildStrategyOptions is enabled or not
@@ -30,6 +30,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util/minikube"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util/registry"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -70,6 +72,16 @@ func newBuildPod(ctx context.Context, c client.Client, build *api.ContainerBuild
 			if err != nil {
 				return nil, err
 			}
+		case task.Buildah != nil:
+			err := addBuildahTaskToPod(ctx, c, build, task.Buildah, pod)
+			if err != nil {
+				return nil, err
+			}
+		case task.BuildKit != nil:
+			err := addBuildKitTaskToPod(ctx, c, build, task.BuildKit, pod)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -113,6 +125,27 @@ func deleteBuilderPod(ctx context.Context, c client.Client, build *api.Container
 	return err
 }
 
+// setDefaultRegistryAddress looks up the cluster registry when the given one has no address.
+func setDefaultRegistryAddress(ctx context.Context, c client.Client, registrySpec *api.ContainerRegistrySpec) error {
+	// TODO: perform an actual registry lookup based on the environment
+	if registrySpec.Address != "" {
+		return nil
+	}
+	address, err := registry.GetRegistryAddress(ctx, c)
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		if address, err = minikube.FindRegistry(ctx, c); err != nil {
+			return err
+		}
+	}
+	if address != nil {
+		registrySpec.Address = *address
+	}
+	return nil
+}
+
 func getRegistrySecret(ctx context.Context, c client.Client, ns, name string, registrySecrets []registrySecret) (registrySecret, error) {
 	secret := corev1.Secret{}
 	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, &secret)
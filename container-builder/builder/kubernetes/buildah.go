@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util"
+)
+
+var (
+	plainDockerBuildahRegistrySecret = registrySecret{
+		fileName:    "config.json",
+		mountPath:   "/buildah/.docker",
+		destination: "config.json",
+		refEnv:      "REGISTRY_AUTH_FILE",
+	}
+	standardDockerBuildahRegistrySecret = registrySecret{
+		fileName:    corev1.DockerConfigJsonKey,
+		mountPath:   "/buildah/.docker",
+		destination: "config.json",
+		refEnv:      "REGISTRY_AUTH_FILE",
+	}
+
+	buildahRegistrySecrets = []registrySecret{
+		plainDockerBuildahRegistrySecret,
+		standardDockerBuildahRegistrySecret,
+	}
+)
+
+const (
+	// see: https://github.com/containers/buildah/blob/main/docs/buildah-build.1.md
+	buildahBuildArgs = "--build-arg"
+	// buildahStorageDir is where the containers storage is kept, shared by the build and the push containers.
+	buildahStorageDir    = "/buildah/storage"
+	buildahStorageVolume = "buildah-storage"
+	// buildahUser is the unprivileged user of the upstream Buildah images (quay.io/buildah/stable)
+	buildahUser int64 = 1000
+)
+
+// addBuildahTaskToPod adds the Buildah containers to the build pod. The image is built by an init container with
+// `buildah bud` and then pushed by the main container with `buildah push`, both sharing the same containers storage.
+// Buildah runs rootless with the `chroot` isolation and the `vfs` storage driver, so no privileged container is needed.
+func addBuildahTaskToPod(ctx context.Context, c client.Client, build *api.ContainerBuild, task *api.BuildahTask, pod *corev1.Pod) error {
+	if err := setDefaultRegistryAddress(ctx, c, &task.Registry); err != nil {
+		return err
+	}
+
+	storageArgs := []string{"--storage-driver=vfs", "--root=" + buildahStorageDir}
+	budArgs := append(append([]string{}, storageArgs...),
+		"bud",
+		"--isolation=chroot",
+		"--file="+path.Join(task.ContextDir, "Dockerfile"),
+		"--tag="+task.GetRepositoryImageTag(),
+	)
+	pushArgs := append(append([]string{}, storageArgs...),
+		"push",
+		// the digest is reported back in the container termination message
+		"--digestfile="+corev1.TerminationMessagePathDefault,
+	)
+
+	if task.Cache.Enabled != nil && *task.Cache.Enabled {
+		budArgs = append(budArgs, "--layers")
+	}
+
+	if task.Verbose != nil && *task.Verbose {
+		budArgs = append(budArgs, "--log-level=debug")
+		pushArgs = append(pushArgs, "--log-level=debug")
+	}
+
+	env := make([]corev1.EnvVar, 0)
+	env = append(env, task.Envs...)
+	env = append(env, corev1.EnvVar{Name: "BUILDAH_ISOLATION", Value: "chroot"})
+	volumes := make([]corev1.Volume, 0)
+	volumeMounts := make([]corev1.VolumeMount, 0)
+
+	storage := corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	if task.Cache.Enabled != nil && *task.Cache.Enabled && task.Cache.PersistentVolumeClaim != "" {
+		storage = corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: task.Cache.PersistentVolumeClaim}}
+	}
+	volumes = append(volumes, corev1.Volume{Name: buildahStorageVolume, VolumeSource: storage})
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: buildahStorageVolume, MountPath: buildahStorageDir})
+
+	if task.Registry.Secret != "" {
+		secret, err := getRegistrySecret(ctx, c, pod.Namespace, task.Registry.Secret, buildahRegistrySecrets)
+		if err != nil {
+			return err
+		}
+		addRegistrySecret(task.Registry.Secret, secret, &volumes, &volumeMounts, &env)
+	}
+
+	if task.Registry.Insecure {
+		budArgs = append(budArgs, "--tls-verify=false")
+		pushArgs = append(pushArgs, "--tls-verify=false")
+	}
+
+	if err := addResourcesToBuilderContextVolume(ctx, c, task.PublishTask, build, &volumes, &volumeMounts); err != nil {
+		return err
+	}
+
+	env = append(env, proxyFromEnvironment()...)
+
+	buildArgs, err := FromEnvToArgs(c, pod.Namespace, task.BuildArgs...)
+	if err != nil {
+		return err
+	}
+	for _, buildArg := range buildArgs {
+		budArgs = append(budArgs, fmt.Sprintf("%s=%s", buildahBuildArgs, buildArg))
+	}
+
+	if len(task.AdditionalFlags) > 0 {
+		budArgs = append(budArgs, task.AdditionalFlags...)
+	}
+	budArgs = append(budArgs, task.ContextDir)
+	pushArgs = append(pushArgs, task.GetRepositoryImageTag(), "docker://"+task.GetRepositoryImageTag())
+
+	name := strings.ToLower(task.Name)
+	budContainer := corev1.Container{
+		Name:            name + "-bud",
+		Image:           task.BuildahImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"buildah"},
+		Args:            budArgs,
+		Env:             env,
+		WorkingDir:      task.ContextDir,
+		VolumeMounts:    volumeMounts,
+		Resources:       task.Resources,
+		SecurityContext: BuildahSecurityDefaults(),
+	}
+	pushContainer := corev1.Container{
+		Name:            name + "-push",
+		Image:           task.BuildahImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"buildah"},
+		Args:            pushArgs,
+		Env:             env,
+		VolumeMounts:    volumeMounts,
+		SecurityContext: BuildahSecurityDefaults(),
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, budContainer)
+	pod.Spec.Containers = append(pod.Spec.Containers, pushContainer)
+	if pod.Spec.SecurityContext == nil {
+		// the storage volume must be writable by the unprivileged user
+		pod.Spec.SecurityContext = &corev1.PodSecurityContext{FSGroup: util.Pint64(buildahUser)}
+	}
+
+	return nil
+}
+
+// BuildahSecurityDefaults runs Buildah as the unprivileged user of the image. SETUID and SETGID are the only
+// capabilities kept, they're needed by newuidmap/newgidmap to set up the rootless user namespace.
+func BuildahSecurityDefaults() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		Privileged:   util.Pbool(false),
+		RunAsUser:    util.Pint64(buildahUser),
+		RunAsNonRoot: util.Pbool(true),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add:  []corev1.Capability{"SETUID", "SETGID"},
+		},
+	}
+}
@@ -34,13 +34,17 @@ import (
 
 type BuilderProperty string
 
-const KanikoCache BuilderProperty = "kaniko-cache"
+const (
+	KanikoCache   BuilderProperty = "kaniko-cache"
+	BuildahCache  BuilderProperty = "buildah-cache"
+	BuildKitCache BuilderProperty = "buildkit-cache"
+)
 
 type ContainerBuilderInfo struct {
 	FinalImageName  string
 	BuildUniqueName string
 	Platform        api.PlatformContainerBuild
-	// ContainerBuilderImageTag the image tag used internally to create the pod builder (e.g. Kaniko Executor Builder image)
+	// ContainerBuilderImageTag the image tag used internally to create the pod builder (e.g. Kaniko Executor Builder image, Buildah or BuildKit image)
 	ContainerBuilderImageTag string
 }
 
@@ -81,7 +85,9 @@ var _ MountHandler = &mountHandler{}
 
 // available schedulers, add them in priority order
 var schedulers = map[string]schedulerManager{
-	"kaniko": &kanikoSchedulerManager{},
+	"kaniko":   &kanikoSchedulerManager{},
+	"buildah":  &buildahSchedulerManager{},
+	"buildkit": &buildKitSchedulerManager{},
 }
 
 // Scheduler provides an interface to add resources and schedule a new build
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kubernetes
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+)
+
+var _ Scheduler = &buildahScheduler{}
+
+type buildahScheduler struct {
+	schedulerHook schedulerHook
+	buildahTask   *api.BuildahTask
+}
+
+type buildahSchedulerManager struct {
+}
+
+var _ schedulerManager = &buildahSchedulerManager{}
+
+func (b buildahSchedulerManager) CreateScheduler(info ContainerBuilderInfo, ctx *containerBuildContext, hook schedulerHook) Scheduler {
+	buildahTask := api.BuildahTask{
+		ContainerBuildBaseTask: api.ContainerBuildBaseTask{Name: "BuildahTask"},
+		PublishTask: api.PublishTask{
+			ContextDir: path.Join("/builder", info.BuildUniqueName, "context"),
+			BaseImage:  info.Platform.Spec.BaseImage,
+			Image:      info.FinalImageName,
+			Registry:   info.Platform.Spec.Registry,
+		},
+		Cache:        api.BuildahTaskCache{},
+		BuildahImage: info.ContainerBuilderImageTag,
+	}
+
+	ctx.containerBuild = &api.ContainerBuild{
+		Spec: api.ContainerBuildSpec{
+			Tasks:    []api.ContainerBuildTask{{Buildah: &buildahTask}},
+			Strategy: api.ContainerBuildStrategyPod,
+			Timeout:  *info.Platform.Spec.Timeout,
+		},
+		Status: api.ContainerBuildStatus{},
+	}
+	ctx.containerBuild.Name = info.BuildUniqueName
+	ctx.containerBuild.Namespace = info.Platform.Namespace
+
+	return &buildahScheduler{
+		schedulerHook: hook,
+		buildahTask:   &buildahTask,
+	}
+}
+
+func (b buildahSchedulerManager) CanHandle(info ContainerBuilderInfo) bool {
+	return info.Platform.Spec.BuildStrategy == api.ContainerBuildStrategyPod && info.Platform.Spec.PublishStrategy == api.PlatformBuildPublishStrategyBuildah
+}
+
+func (sb *buildahScheduler) WithProperty(property BuilderProperty, object interface{}) Scheduler {
+	if property == BuildahCache {
+		sb.buildahTask.Cache = object.(api.BuildahTaskCache)
+	}
+	return sb
+}
+
+func (sb *buildahScheduler) WithResourceRequirements(res corev1.ResourceRequirements) Scheduler {
+	sb.buildahTask.Resources = res
+	return sb
+}
+
+func (sb *buildahScheduler) WithAdditionalArgs(flags []string) Scheduler {
+	sb.buildahTask.AdditionalFlags = flags
+	return sb
+}
+
+func (sb *buildahScheduler) WithBuildArgs(args []corev1.EnvVar) Scheduler {
+	sb.buildahTask.BuildArgs = args
+	return sb
+}
+
+func (sb *buildahScheduler) WithEnvs(envs []corev1.EnvVar) Scheduler {
+	sb.buildahTask.Envs = envs
+	return sb
+}
+
+func (sb *buildahScheduler) Schedule() (*api.ContainerBuild, error) {
+	return sb.schedulerHook()
+}
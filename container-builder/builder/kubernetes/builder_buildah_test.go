@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util/test"
+)
+
+func TestNewBuildWithBuildah(t *testing.T) {
+	ns := "test"
+	registrySecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: ns},
+		Type:       v1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{v1.DockerConfigJsonKey: []byte("{}")},
+	}
+	c := test.NewFakeClient(registrySecret)
+
+	dockerFile, err := os.ReadFile("testdata/Dockerfile")
+	assert.NoError(t, err)
+
+	workflowDefinition, err := os.ReadFile("testdata/greetings.sw.json")
+	assert.NoError(t, err)
+
+	platform := api.PlatformContainerBuild{
+		ObjectReference: api.ObjectReference{
+			Namespace: ns,
+			Name:      "testPlatform",
+		},
+		Spec: api.PlatformContainerBuildSpec{
+			BuildStrategy:   api.ContainerBuildStrategyPod,
+			PublishStrategy: api.PlatformBuildPublishStrategyBuildah,
+			Registry:        api.ContainerRegistrySpec{Address: "registry.local:5000", Secret: registrySecret.Name, Insecure: true},
+			Timeout:         &metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+
+	build, err := NewBuild(ContainerBuilderInfo{FinalImageName: "apache/incubator-kie-buildexample:latest", BuildUniqueName: "build1", Platform: platform, ContainerBuilderImageTag: "quay.io/buildah/stable:latest"}).
+		AddResource("Dockerfile", dockerFile).
+		AddResource("greetings.sw.json", workflowDefinition).
+		WithClient(c).
+		Scheduler().
+		WithProperty(BuildahCache, api.BuildahTaskCache{Enabled: util.Pbool(true), PersistentVolumeClaim: "buildah-cache-pv"}).
+		WithBuildArgs([]v1.EnvVar{{Name: "QUARKUS_EXTENSIONS", Value: "extension1,extension2"}}).
+		WithEnvs([]v1.EnvVar{{Name: "MYENV", Value: "value"}}).
+		Schedule()
+	assert.NoError(t, err)
+	assert.NotNil(t, build.Spec.Tasks[0].Buildah)
+
+	// reconcile twice to push forward to the pod creation
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhasePending, build.Status.Phase)
+
+	pod := &v1.Pod{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: buildPodName(build), Namespace: ns}, pod)
+	assert.NoError(t, err)
+
+	// the image is built by the init container and pushed by the main one
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	assert.Len(t, pod.Spec.Containers, 1)
+	bud := pod.Spec.InitContainers[0]
+	push := pod.Spec.Containers[0]
+	assert.Equal(t, "quay.io/buildah/stable:latest", bud.Image)
+	assert.Subset(t, bud.Args, []string{
+		"--storage-driver=vfs", "bud", "--layers", "--tls-verify=false",
+		"--tag=registry.local:5000/apache/incubator-kie-buildexample:latest",
+		"--build-arg=QUARKUS_EXTENSIONS=extension1,extension2",
+	})
+	assert.Subset(t, push.Args, []string{"push", "--tls-verify=false", "docker://registry.local:5000/apache/incubator-kie-buildexample:latest"})
+	assert.Subset(t, bud.Env, []v1.EnvVar{{Name: "MYENV", Value: "value"}, {Name: "REGISTRY_AUTH_FILE", Value: "/buildah/.docker/config.json"}})
+	assert.Equal(t, bud.VolumeMounts[:2], push.VolumeMounts[:2])
+	assert.True(t, *bud.SecurityContext.RunAsNonRoot)
+	assert.False(t, *bud.SecurityContext.Privileged)
+
+	// the containers storage is kept in the cache PVC
+	assert.Equal(t, buildahStorageVolume, pod.Spec.Volumes[0].Name)
+	assert.Equal(t, "buildah-cache-pv", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+}
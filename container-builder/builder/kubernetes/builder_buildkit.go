@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kubernetes
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+)
+
+var _ Scheduler = &buildKitScheduler{}
+
+type buildKitScheduler struct {
+	schedulerHook schedulerHook
+	buildKitTask  *api.BuildKitTask
+}
+
+type buildKitSchedulerManager struct {
+}
+
+var _ schedulerManager = &buildKitSchedulerManager{}
+
+func (b buildKitSchedulerManager) CreateScheduler(info ContainerBuilderInfo, ctx *containerBuildContext, hook schedulerHook) Scheduler {
+	buildKitTask := api.BuildKitTask{
+		ContainerBuildBaseTask: api.ContainerBuildBaseTask{Name: "BuildKitTask"},
+		PublishTask: api.PublishTask{
+			ContextDir: path.Join("/builder", info.BuildUniqueName, "context"),
+			BaseImage:  info.Platform.Spec.BaseImage,
+			Image:      info.FinalImageName,
+			Registry:   info.Platform.Spec.Registry,
+		},
+		Cache:         api.BuildKitTaskCache{},
+		BuildKitImage: info.ContainerBuilderImageTag,
+	}
+
+	ctx.containerBuild = &api.ContainerBuild{
+		Spec: api.ContainerBuildSpec{
+			Tasks:    []api.ContainerBuildTask{{BuildKit: &buildKitTask}},
+			Strategy: api.ContainerBuildStrategyPod,
+			Timeout:  *info.Platform.Spec.Timeout,
+		},
+		Status: api.ContainerBuildStatus{},
+	}
+	ctx.containerBuild.Name = info.BuildUniqueName
+	ctx.containerBuild.Namespace = info.Platform.Namespace
+
+	return &buildKitScheduler{
+		schedulerHook: hook,
+		buildKitTask:  &buildKitTask,
+	}
+}
+
+func (b buildKitSchedulerManager) CanHandle(info ContainerBuilderInfo) bool {
+	return info.Platform.Spec.BuildStrategy == api.ContainerBuildStrategyPod && info.Platform.Spec.PublishStrategy == api.PlatformBuildPublishStrategyBuildKit
+}
+
+func (sb *buildKitScheduler) WithProperty(property BuilderProperty, object interface{}) Scheduler {
+	if property == BuildKitCache {
+		sb.buildKitTask.Cache = object.(api.BuildKitTaskCache)
+	}
+	return sb
+}
+
+func (sb *buildKitScheduler) WithResourceRequirements(res corev1.ResourceRequirements) Scheduler {
+	sb.buildKitTask.Resources = res
+	return sb
+}
+
+func (sb *buildKitScheduler) WithAdditionalArgs(flags []string) Scheduler {
+	sb.buildKitTask.AdditionalFlags = flags
+	return sb
+}
+
+func (sb *buildKitScheduler) WithBuildArgs(args []corev1.EnvVar) Scheduler {
+	sb.buildKitTask.BuildArgs = args
+	return sb
+}
+
+func (sb *buildKitScheduler) WithEnvs(envs []corev1.EnvVar) Scheduler {
+	sb.buildKitTask.Envs = envs
+	return sb
+}
+
+func (sb *buildKitScheduler) Schedule() (*api.ContainerBuild, error) {
+	return sb.schedulerHook()
+}
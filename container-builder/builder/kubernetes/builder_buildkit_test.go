@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util/test"
+)
+
+func TestNewBuildWithBuildKit(t *testing.T) {
+	ns := "test"
+	c := test.NewFakeClient()
+
+	dockerFile, err := os.ReadFile("testdata/Dockerfile")
+	assert.NoError(t, err)
+
+	workflowDefinition, err := os.ReadFile("testdata/greetings.sw.json")
+	assert.NoError(t, err)
+
+	platform := api.PlatformContainerBuild{
+		ObjectReference: api.ObjectReference{
+			Namespace: ns,
+			Name:      "testPlatform",
+		},
+		Spec: api.PlatformContainerBuildSpec{
+			BuildStrategy:   api.ContainerBuildStrategyPod,
+			PublishStrategy: api.PlatformBuildPublishStrategyBuildKit,
+			Registry:        api.ContainerRegistrySpec{Address: "registry.local:5000"},
+			Timeout:         &metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+
+	build, err := NewBuild(ContainerBuilderInfo{FinalImageName: "apache/incubator-kie-buildexample:latest", BuildUniqueName: "build1", Platform: platform, ContainerBuilderImageTag: "moby/buildkit:rootless"}).
+		AddResource("Dockerfile", dockerFile).
+		AddResource("greetings.sw.json", workflowDefinition).
+		WithClient(c).
+		Scheduler().
+		WithProperty(BuildKitCache, api.BuildKitTaskCache{Enabled: util.Pbool(true)}).
+		WithBuildArgs([]v1.EnvVar{{Name: "QUARKUS_EXTENSIONS", Value: "extension1,extension2"}}).
+		WithAdditionalArgs([]string{"--progress=plain"}).
+		Schedule()
+	assert.NoError(t, err)
+	assert.NotNil(t, build.Spec.Tasks[0].BuildKit)
+
+	// reconcile twice to push forward to the pod creation
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhasePending, build.Status.Phase)
+
+	pod := &v1.Pod{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: buildPodName(build), Namespace: ns}, pod)
+	assert.NoError(t, err)
+
+	assert.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, []string{buildKitDaemonlessCmd}, container.Command)
+	assert.Subset(t, container.Args, []string{
+		"build",
+		"--output=type=image,name=registry.local:5000/apache/incubator-kie-buildexample:latest,push=true",
+		"--opt=build-arg:QUARKUS_EXTENSIONS=extension1,extension2",
+		// without a PVC the cache is kept in the published image
+		"--export-cache=type=inline",
+		"--import-cache=type=registry,ref=registry.local:5000/apache/incubator-kie-buildexample:latest",
+		"--progress=plain",
+	})
+	assert.Equal(t, v1.SeccompProfileTypeUnconfined, container.SecurityContext.SeccompProfile.Type)
+	assert.Equal(t, "unconfined", pod.Annotations[buildKitAppArmorKey+container.Name])
+}
+
+func TestMonitorPodImageDigest(t *testing.T) {
+	action := &monitorPodAction{}
+	terminated := func(message string) v1.ContainerStatus {
+		return v1.ContainerStatus{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Message: message}}}
+	}
+	pod := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("sha256:1234\n")}}}
+	assert.Equal(t, "sha256:1234", action.getImageDigest(pod))
+
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{terminated(`{"containerimage.digest":"sha256:5678","image.name":"greeting"}`)}
+	assert.Equal(t, "sha256:5678", action.getImageDigest(pod))
+
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{terminated("")}
+	assert.Empty(t, action.getImageDigest(pod))
+}
@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util"
+)
+
+var (
+	plainDockerBuildKitRegistrySecret = registrySecret{
+		fileName:    "config.json",
+		mountPath:   buildKitHomeDir + "/.docker",
+		destination: "config.json",
+	}
+	standardDockerBuildKitRegistrySecret = registrySecret{
+		fileName:    corev1.DockerConfigJsonKey,
+		mountPath:   buildKitHomeDir + "/.docker",
+		destination: "config.json",
+	}
+
+	buildKitRegistrySecrets = []registrySecret{
+		plainDockerBuildKitRegistrySecret,
+		standardDockerBuildKitRegistrySecret,
+	}
+)
+
+const (
+	// see: https://github.com/moby/buildkit/blob/master/frontend/dockerfile/docs/reference.md#arg
+	buildKitBuildArgs = "--opt=build-arg:"
+	// buildKitHomeDir is the home of the unprivileged user of the upstream rootless BuildKit images (moby/buildkit:rootless)
+	buildKitHomeDir             = "/home/user"
+	buildKitStateDir            = buildKitHomeDir + "/.local/share/buildkit"
+	buildKitStateVolume         = "buildkit-state"
+	buildKitCacheDir            = "/buildkit/cache"
+	buildKitCacheVolume         = "buildkit-cache"
+	buildKitUser          int64 = 1000
+	buildKitAppArmorKey         = "container.apparmor.security.beta.kubernetes.io/"
+	buildKitDaemonlessCmd       = "buildctl-daemonless.sh"
+)
+
+// addBuildKitTaskToPod adds the BuildKit container to the build pod. The BuildKit daemon runs rootless inside the
+// container for the duration of the build, see https://github.com/moby/buildkit/blob/master/docs/rootless.md
+func addBuildKitTaskToPod(ctx context.Context, c client.Client, build *api.ContainerBuild, task *api.BuildKitTask, pod *corev1.Pod) error {
+	if err := setDefaultRegistryAddress(ctx, c, &task.Registry); err != nil {
+		return err
+	}
+
+	output := "--output=type=image,name=" + task.GetRepositoryImageTag() + ",push=true"
+	if task.Registry.Insecure {
+		output += ",registry.insecure=true"
+	}
+	args := []string{
+		"build",
+		"--frontend=dockerfile.v0",
+		"--local=context=" + task.ContextDir,
+		"--local=dockerfile=" + task.ContextDir,
+		"--opt=filename=Dockerfile",
+		output,
+		// the image digest is reported back in the container termination message
+		"--metadata-file=" + corev1.TerminationMessagePathDefault,
+	}
+
+	env := make([]corev1.EnvVar, 0)
+	env = append(env, task.Envs...)
+	env = append(env, corev1.EnvVar{Name: "BUILDKITD_FLAGS", Value: "--oci-worker-no-process-sandbox"})
+	volumes := []corev1.Volume{{Name: buildKitStateVolume, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	volumeMounts := []corev1.VolumeMount{{Name: buildKitStateVolume, MountPath: buildKitStateDir}}
+
+	if task.Cache.Enabled != nil && *task.Cache.Enabled {
+		if task.Cache.PersistentVolumeClaim != "" {
+			args = append(args,
+				"--export-cache=type=local,mode=max,dest="+buildKitCacheDir,
+				"--import-cache=type=local,src="+buildKitCacheDir)
+			volumes = append(volumes, corev1.Volume{
+				Name: buildKitCacheVolume,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: task.Cache.PersistentVolumeClaim},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: buildKitCacheVolume, MountPath: buildKitCacheDir})
+		} else {
+			args = append(args,
+				"--export-cache=type=inline",
+				"--import-cache=type=registry,ref="+task.GetRepositoryImageTag())
+		}
+	}
+
+	if task.Registry.Secret != "" {
+		secret, err := getRegistrySecret(ctx, c, pod.Namespace, task.Registry.Secret, buildKitRegistrySecrets)
+		if err != nil {
+			return err
+		}
+		addRegistrySecret(task.Registry.Secret, secret, &volumes, &volumeMounts, &env)
+	}
+
+	if err := addResourcesToBuilderContextVolume(ctx, c, task.PublishTask, build, &volumes, &volumeMounts); err != nil {
+		return err
+	}
+
+	env = append(env, proxyFromEnvironment()...)
+
+	buildArgs, err := FromEnvToArgs(c, pod.Namespace, task.BuildArgs...)
+	if err != nil {
+		return err
+	}
+	for _, buildArg := range buildArgs {
+		args = append(args, fmt.Sprintf("%s%s", buildKitBuildArgs, buildArg))
+	}
+
+	if len(task.AdditionalFlags) > 0 {
+		args = append(args, task.AdditionalFlags...)
+	}
+
+	container := corev1.Container{
+		Name:            strings.ToLower(task.Name),
+		Image:           task.BuildKitImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{buildKitDaemonlessCmd},
+		Args:            args,
+		Env:             env,
+		WorkingDir:      task.ContextDir,
+		VolumeMounts:    volumeMounts,
+		Resources:       task.Resources,
+		SecurityContext: BuildKitSecurityDefaults(),
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+	pod.Spec.Containers = append(pod.Spec.Containers, container)
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	// rootlesskit needs to mount the user namespace filesystems, which the default AppArmor profile denies
+	pod.Annotations[buildKitAppArmorKey+container.Name] = "unconfined"
+	if pod.Spec.SecurityContext == nil {
+		pod.Spec.SecurityContext = &corev1.PodSecurityContext{FSGroup: util.Pint64(buildKitUser)}
+	}
+
+	return nil
+}
+
+// BuildKitSecurityDefaults runs BuildKit as the unprivileged user of the rootless image. The seccomp profile is
+// unconfined since rootlesskit creates the user and mount namespaces the daemon runs into.
+func BuildKitSecurityDefaults() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		Privileged:   util.Pbool(false),
+		RunAsUser:    util.Pint64(buildKitUser),
+		RunAsGroup:   util.Pint64(buildKitUser),
+		RunAsNonRoot: util.Pbool(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeUnconfined,
+		},
+	}
+}
@@ -28,8 +28,6 @@ import (
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
-	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util/minikube"
-	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/util/registry"
 )
 
 var (
@@ -61,23 +59,8 @@ var (
 const kanikoBuildArgs = "--build-arg"
 
 func addKanikoTaskToPod(ctx context.Context, c client.Client, build *api.ContainerBuild, task *api.KanikoTask, pod *corev1.Pod) error {
-	// TODO: perform an actual registry lookup based on the environment
-	if task.Registry.Address == "" {
-		address, err := registry.GetRegistryAddress(ctx, c)
-		if err != nil {
-			return err
-		}
-		if address != nil {
-			task.Registry.Address = *address
-		} else {
-			address, err = minikube.FindRegistry(ctx, c)
-			if err != nil {
-				return err
-			}
-			if address != nil {
-				task.Registry.Address = *address
-			}
-		}
+	if err := setDefaultRegistryAddress(ctx, c, &task.Registry); err != nil {
+		return err
 	}
 
 	// TODO: verify how cache is possible
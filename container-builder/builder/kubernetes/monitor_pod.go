@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -35,7 +36,11 @@ import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
 )
 
-const timeoutAnnotation = "sonataflow.org/timeout"
+const (
+	timeoutAnnotation = "sonataflow.org/timeout"
+	imageDigestPrefix = "sha256:"
+	buildKitDigestKey = "containerimage.digest"
+)
 
 func newMonitorPodAction() Action {
 	return &monitorPodAction{}
@@ -109,11 +114,12 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *api.Container
 		build.Status.Duration = duration.String()
 
 		for _, task := range build.Spec.Tasks {
-			if t := task.Kaniko; t != nil {
+			if t := task.GetPublishTask(); t != nil {
 				build.Status.RepositoryImageTag = t.GetRepositoryImageTag()
 				break
 			}
 		}
+		build.Status.Digest = action.getImageDigest(pod)
 
 	case corev1.PodFailed:
 		phase := api.ContainerBuildPhaseFailed
@@ -277,6 +283,28 @@ func (action *monitorPodAction) getTerminationMessage(pod *corev1.Pod) string {
 	}
 }
 
+// getImageDigest reads the pushed image digest from the builder containers termination messages. Buildah reports the
+// plain digest, while BuildKit reports its build metadata.
+func (action *monitorPodAction) getImageDigest(pod *corev1.Pod) string {
+	for _, container := range pod.Status.ContainerStatuses {
+		t := container.State.Terminated
+		if t == nil || t.ExitCode != 0 || t.Message == "" {
+			continue
+		}
+		if message := strings.TrimSpace(t.Message); strings.HasPrefix(message, imageDigestPrefix) {
+			return message
+		}
+		metadata := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(t.Message), &metadata); err != nil {
+			continue
+		}
+		if digest, ok := metadata[buildKitDigestKey].(string); ok {
+			return digest
+		}
+	}
+	return ""
+}
+
 type terminationMessage struct {
 	Container string `json:"container,omitempty"`
 	Message   string `json:"message,omitempty"`
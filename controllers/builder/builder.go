@@ -47,7 +47,7 @@ type BuildManager interface {
 }
 
 func NewBuildManager(ctx context.Context, client client.Client, cliConfig *rest.Config, targetName, targetNamespace string) (BuildManager, error) {
-	p, err := platform.GetActivePlatform(ctx, client, targetNamespace)
+	p, err := platform.GetEffectivePlatform(ctx, client, targetNamespace)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil, err
@@ -66,6 +66,9 @@ func NewBuildManager(ctx context.Context, client client.Client, cliConfig *rest.
 		platform:         p,
 		builderConfigMap: builderConfig,
 	}
+	if p.Spec.Build.Config.BuildStrategy == operatorapi.TektonBuildStrategy {
+		return newTektonBuilderManager(managerContext), nil
+	}
 	switch p.Status.Cluster {
 	case operatorapi.PlatformClusterOpenShift:
 		return newOpenShiftBuilderManager(managerContext, cliConfig)
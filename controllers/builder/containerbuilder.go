@@ -49,11 +49,37 @@ const (
 	resourceDockerfile = "Dockerfile"
 )
 
+// Options read from the platform `spec.build.config.strategyOptions` when the build strategy is `operator`.
+const (
+	// PublishStrategyOption selects the tool that builds and publishes the workflow image: Kaniko (default), Buildah or BuildKit.
+	PublishStrategyOption = "PublishStrategy"
+	// BuildahCacheEnabledOption keeps the Buildah image layers to reuse them in the next builds.
+	BuildahCacheEnabledOption = "BuildahBuildCacheEnabled"
+	// BuildahCachePVCOption is the PVC holding the Buildah containers storage when the cache is enabled.
+	BuildahCachePVCOption = "BuildahPersistentVolumeClaim"
+	// BuildKitCacheEnabledOption exports the BuildKit cache and imports it in the next builds.
+	BuildKitCacheEnabledOption = "BuildKitBuildCacheEnabled"
+	// BuildKitCachePVCOption is the PVC holding the BuildKit cache. When not set, the cache is kept inline in the published image.
+	BuildKitCachePVCOption = "BuildKitPersistentVolumeClaim"
+)
+
+// SupportedPublishStrategies lists the values accepted by the PublishStrategyOption.
+var SupportedPublishStrategies = []api.PlatformContainerBuildPublishStrategy{
+	api.PlatformBuildPublishStrategyKaniko,
+	api.PlatformBuildPublishStrategyBuildah,
+	api.PlatformBuildPublishStrategyBuildKit,
+}
+
 var _ BuildManager = &containerBuilderManager{}
 
-type kanikoBuildInput struct {
+type containerBuildInput struct {
 	name               string
-	task               *api.KanikoTask
+	task               api.ContainerBuildBaseTask
+	additionalFlags    []string
+	publishStrategy    api.PlatformContainerBuildPublishStrategy
+	builderImage       string
+	cacheProperty      builder.BuilderProperty
+	cache              interface{}
 	workflowDefinition []byte
 	workflow           *operatorapi.SonataFlow
 	workflowProperties []operatorapi.ConfigMapWorkflowResource
@@ -68,25 +94,14 @@ type containerBuilderManager struct {
 }
 
 func (c *containerBuilderManager) Schedule(build *operatorapi.SonataFlowBuild) error {
-	kanikoTaskCache := api.KanikoTaskCache{}
-	if platform.IsKanikoCacheEnabled(c.platform) {
-		kanikoTaskCache.Enabled = utils.Pbool(true)
-	}
-	kanikoTask := &api.KanikoTask{
-		ContainerBuildBaseTask: api.ContainerBuildBaseTask{
-			Name:      "kaniko",
-			BuildArgs: build.Spec.BuildArgs,
-			Envs:      build.Spec.Envs,
-			Resources: build.Spec.Resources,
-		},
-		PublishTask:         api.PublishTask{},
-		Cache:               kanikoTaskCache,
-		AdditionalFlags:     build.Spec.Arguments,
-		KanikoExecutorImage: cfg.GetCfg().KanikoExecutorImageTag,
+	task := api.ContainerBuildBaseTask{
+		BuildArgs: build.Spec.BuildArgs,
+		Envs:      build.Spec.Envs,
+		Resources: build.Spec.Resources,
 	}
 	var containerBuilder *api.ContainerBuild
 	var err error
-	if containerBuilder, err = c.scheduleNewKanikoBuildWithContainerFile(build, kanikoTask); err != nil {
+	if containerBuilder, err = c.scheduleNewBuildWithContainerFile(build, task); err != nil {
 		return err
 	}
 	if containerBuilder == nil {
@@ -116,6 +131,7 @@ func (c *containerBuilderManager) Reconcile(build *operatorapi.SonataFlowBuild)
 	build.Status.BuildPhase = operatorapi.BuildPhase(containerBuild.Status.Phase)
 	build.Status.Error = containerBuild.Status.Error
 	build.Status.ImageTag = containerBuild.Status.RepositoryImageTag
+	build.Status.ImageDigest = containerBuild.Status.Digest
 	if err = build.Status.SetInnerBuild(containerBuild); err != nil {
 		return err
 	}
@@ -129,7 +145,7 @@ func newContainerBuilderManager(managerContext buildManagerContext, config *rest
 	}
 }
 
-func (c *containerBuilderManager) scheduleNewKanikoBuildWithContainerFile(build *operatorapi.SonataFlowBuild, task *api.KanikoTask) (*api.ContainerBuild, error) {
+func (c *containerBuilderManager) scheduleNewBuildWithContainerFile(build *operatorapi.SonataFlowBuild, task api.ContainerBuildBaseTask) (*api.ContainerBuild, error) {
 	workflow, err := c.fetchWorkflowForBuild(build)
 	if err != nil {
 		return nil, err
@@ -139,15 +155,17 @@ func (c *containerBuilderManager) scheduleNewKanikoBuildWithContainerFile(build
 		return nil, err
 	}
 
-	buildInput := kanikoBuildInput{
+	buildInput := containerBuildInput{
 		name:               workflow.Name,
 		task:               task,
+		additionalFlags:    build.Spec.Arguments,
 		workflowDefinition: workflowDef,
 		workflow:           workflow,
 		workflowProperties: buildWorkflowPropertyResources(workflow),
 		dockerfile:         platform.GetCustomizedBuilderDockerfile(c.builderConfigMap.Data[defaultBuilderResourceName], *c.platform),
 		imageTag:           buildNamespacedImageTag(workflow),
 	}
+	c.setPublishStrategy(&buildInput)
 
 	if c.platform.Spec.Build.Config.Timeout == nil {
 		c.platform.Spec.Build.Config.Timeout = &metav1.Duration{Duration: 5 * time.Minute}
@@ -155,12 +173,48 @@ func (c *containerBuilderManager) scheduleNewKanikoBuildWithContainerFile(build
 	return c.buildImage(buildInput)
 }
 
+// setPublishStrategy sets the publish strategy selected in the platform, with its builder image and cache, to the build input.
+func (c *containerBuilderManager) setPublishStrategy(buildInput *containerBuildInput) {
+	config := &c.platform.Spec.Build.Config
+	switch api.PlatformContainerBuildPublishStrategy(config.BuildStrategyOptions[PublishStrategyOption]) {
+	case api.PlatformBuildPublishStrategyBuildah:
+		cache := api.BuildahTaskCache{}
+		if config.IsStrategyOptionEnabled(BuildahCacheEnabledOption) {
+			cache.Enabled = utils.Pbool(true)
+			cache.PersistentVolumeClaim = config.BuildStrategyOptions[BuildahCachePVCOption]
+		}
+		buildInput.publishStrategy = api.PlatformBuildPublishStrategyBuildah
+		buildInput.builderImage = cfg.GetCfg().BuildahImageTag
+		buildInput.cacheProperty = builder.BuildahCache
+		buildInput.cache = cache
+	case api.PlatformBuildPublishStrategyBuildKit:
+		cache := api.BuildKitTaskCache{}
+		if config.IsStrategyOptionEnabled(BuildKitCacheEnabledOption) {
+			cache.Enabled = utils.Pbool(true)
+			cache.PersistentVolumeClaim = config.BuildStrategyOptions[BuildKitCachePVCOption]
+		}
+		buildInput.publishStrategy = api.PlatformBuildPublishStrategyBuildKit
+		buildInput.builderImage = cfg.GetCfg().BuildKitImageTag
+		buildInput.cacheProperty = builder.BuildKitCache
+		buildInput.cache = cache
+	default:
+		cache := api.KanikoTaskCache{}
+		if platform.IsKanikoCacheEnabled(c.platform) {
+			cache.Enabled = utils.Pbool(true)
+		}
+		buildInput.publishStrategy = api.PlatformBuildPublishStrategyKaniko
+		buildInput.builderImage = cfg.GetCfg().KanikoExecutorImageTag
+		buildInput.cacheProperty = builder.KanikoCache
+		buildInput.cache = cache
+	}
+}
+
 func (c *containerBuilderManager) reconcileBuild(build *api.ContainerBuild, cli client.Client) (*api.ContainerBuild, error) {
 	result, err := builder.FromBuild(build).WithClient(cli).Reconcile()
 	return result, err
 }
 
-func (c *containerBuilderManager) buildImage(buildInput kanikoBuildInput) (*api.ContainerBuild, error) {
+func (c *containerBuilderManager) buildImage(buildInput containerBuildInput) (*api.ContainerBuild, error) {
 	cli, err := client.FromCtrlClientSchemeAndConfig(c.client, c.client.Scheme(), c.restConfig)
 	plat := api.PlatformContainerBuild{
 		ObjectReference: api.ObjectReference{
@@ -169,7 +223,7 @@ func (c *containerBuilderManager) buildImage(buildInput kanikoBuildInput) (*api.
 		},
 		Spec: api.PlatformContainerBuildSpec{
 			BuildStrategy:   api.ContainerBuildStrategyPod,
-			PublishStrategy: api.PlatformBuildPublishStrategyKaniko,
+			PublishStrategy: buildInput.publishStrategy,
 			Registry: api.ContainerRegistrySpec{
 				Insecure: c.platform.Spec.Build.Config.Registry.Insecure,
 				Address:  c.platform.Spec.Build.Config.Registry.Address,
@@ -190,12 +244,12 @@ func (c *containerBuilderManager) buildImage(buildInput kanikoBuildInput) (*api.
 }
 
 // Helper function to create a new container-builder build and schedule it
-func newBuild(buildInput kanikoBuildInput, platform api.PlatformContainerBuild, defaultExtension string, cli client.Client) (*api.ContainerBuild, error) {
+func newBuild(buildInput containerBuildInput, platform api.PlatformContainerBuild, defaultExtension string, cli client.Client) (*api.ContainerBuild, error) {
 	buildInfo := builder.ContainerBuilderInfo{
 		FinalImageName:           buildInput.imageTag,
 		BuildUniqueName:          buildInput.name,
 		Platform:                 platform,
-		ContainerBuilderImageTag: buildInput.task.KanikoExecutorImage,
+		ContainerBuilderImageTag: buildInput.builderImage,
 	}
 
 	newBuilder := builder.NewBuild(buildInfo).
@@ -206,19 +260,20 @@ func newBuild(buildInput kanikoBuildInput, platform api.PlatformContainerBuild,
 		newBuilder.AddConfigMapResource(res.ConfigMap, res.WorkflowPath)
 	}
 
-	//make the workflow properties available to the kaniko build.
+	//make the workflow properties available to the image build.
 	for _, props := range buildInput.workflowProperties {
 		newBuilder.AddConfigMapResource(props.ConfigMap, props.WorkflowPath)
 	}
 
 	return newBuilder.Scheduler().
-		WithAdditionalArgs(buildInput.task.AdditionalFlags).
+		WithProperty(buildInput.cacheProperty, buildInput.cache).
+		WithAdditionalArgs(buildInput.additionalFlags).
 		WithResourceRequirements(buildInput.task.Resources).
 		WithBuildArgs(buildInput.task.BuildArgs).
 		WithEnvs(buildInput.task.Envs).Schedule()
 }
 
-// buildNamespacedImageTag For the kaniko build we prepend the namespace to the calculated image name/tag to avoid potential
+// buildNamespacedImageTag For the container-builder builds we prepend the namespace to the calculated image name/tag to avoid potential
 // collisions if the same workflows is deployed in a different namespace. In OpenShift this last is not needed since the
 // ImageStreams are already namespaced.
 func buildNamespacedImageTag(workflow *operatorapi.SonataFlow) string {
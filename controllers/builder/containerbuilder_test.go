@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/api"
+	containerbuilder "github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/builder/kubernetes"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/cfg"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func Test_containerBuilderManager_setPublishStrategy(t *testing.T) {
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	manager := &containerBuilderManager{buildManagerContext: buildManagerContext{platform: platform}}
+
+	buildInput := containerBuildInput{}
+	manager.setPublishStrategy(&buildInput)
+	assert.Equal(t, api.PlatformBuildPublishStrategyKaniko, buildInput.publishStrategy)
+	assert.Equal(t, cfg.GetCfg().KanikoExecutorImageTag, buildInput.builderImage)
+
+	platform.Spec.Build.Config.BuildStrategyOptions = map[string]string{
+		PublishStrategyOption:     string(api.PlatformBuildPublishStrategyBuildah),
+		BuildahCacheEnabledOption: "true",
+		BuildahCachePVCOption:     "buildah-cache",
+	}
+	buildInput = containerBuildInput{}
+	manager.setPublishStrategy(&buildInput)
+	assert.Equal(t, api.PlatformBuildPublishStrategyBuildah, buildInput.publishStrategy)
+	assert.Equal(t, cfg.GetCfg().BuildahImageTag, buildInput.builderImage)
+	assert.Equal(t, containerbuilder.BuildahCache, buildInput.cacheProperty)
+	assert.Equal(t, "buildah-cache", buildInput.cache.(api.BuildahTaskCache).PersistentVolumeClaim)
+
+	platform.Spec.Build.Config.BuildStrategyOptions = map[string]string{PublishStrategyOption: string(api.PlatformBuildPublishStrategyBuildKit)}
+	buildInput = containerBuildInput{}
+	manager.setPublishStrategy(&buildInput)
+	assert.Equal(t, api.PlatformBuildPublishStrategyBuildKit, buildInput.publishStrategy)
+	assert.Equal(t, cfg.GetCfg().BuildKitImageTag, buildInput.builderImage)
+	assert.Nil(t, buildInput.cache.(api.BuildKitTaskCache).Enabled)
+}
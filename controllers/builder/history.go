@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package builder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/workflowdef"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+// StartBuildRecord sets the provenance of the build that has just been scheduled: a new build number, the workflow
+// generation and the hash of the build inputs.
+func StartBuildRecord(ctx context.Context, c client.Client, build *operatorapi.SonataFlowBuild) error {
+	workflow := &operatorapi.SonataFlow{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(build), workflow); err != nil {
+		return err
+	}
+	inputsHash, err := GetBuildInputsHash(ctx, c, workflow)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	build.Status.BuildNumber++
+	build.Status.StartTime = &now
+	build.Status.CompletionTime = nil
+	build.Status.ImageDigest = ""
+	build.Status.WorkflowGeneration = workflow.Generation
+	build.Status.InputsHash = inputsHash
+	return nil
+}
+
+// CompleteBuildRecord adds the finished build to the head of the build history, keeping at most historyLimit records.
+func CompleteBuildRecord(build *operatorapi.SonataFlowBuild, historyLimit int) {
+	if !build.Status.IsFinished() || build.Status.CompletionTime != nil {
+		return
+	}
+	now := metav1.Now()
+	build.Status.CompletionTime = &now
+	history := append([]operatorapi.SonataFlowBuildRecord{build.Status.Record()}, build.Status.History...)
+	if len(history) > historyLimit {
+		history = history[:historyLimit]
+	}
+	build.Status.History = history
+}
+
+// GetBuildInputsHash calculates the hash of everything a workflow build takes as input: the flow definition,
+// the application properties and the ConfigMaps referenced in .spec.resources.
+func GetBuildInputsHash(ctx context.Context, c client.Client, workflow *operatorapi.SonataFlow) (string, error) {
+	hash := sha256.New()
+	flow, err := workflowdef.GetJSONWorkflow(workflow, ctx)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(flow)
+	for _, name := range []string{workflowproj.GetWorkflowUserPropertiesConfigMapName(workflow), workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow)} {
+		if err = writeConfigMapHash(ctx, c, workflow.Namespace, name, hash.Write); err != nil {
+			return "", err
+		}
+	}
+	for _, res := range workflow.Spec.Resources.ConfigMaps {
+		hash.Write([]byte(res.WorkflowPath))
+		if err = writeConfigMapHash(ctx, c, workflow.Namespace, res.ConfigMap.Name, hash.Write); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeConfigMapHash writes the name and the data of the given ConfigMap in a stable order. A missing ConfigMap only
+// contributes with its name.
+func writeConfigMapHash(ctx context.Context, c client.Client, namespace, name string, write func([]byte) (int, error)) error {
+	_, _ = write([]byte(name))
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	for k := range cm.BinaryData {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		_, _ = write([]byte(k))
+		if v, ok := cm.Data[k]; ok {
+			_, _ = write([]byte(v))
+		} else {
+			_, _ = write(cm.BinaryData[k])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+func TestStartBuildRecord(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Generation = 3
+	build := test.GetNewEmptySonataFlowBuild(workflow.Name, workflow.Namespace)
+	build.Status.BuildNumber = 1
+	build.Status.ImageDigest = "sha256:old"
+	userProps := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: workflowproj.GetWorkflowUserPropertiesConfigMapName(workflow), Namespace: workflow.Namespace},
+		Data:       map[string]string{workflowproj.ApplicationPropertiesFileName: "my.prop=1"},
+	}
+	client := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, userProps).Build()
+
+	assert.NoError(t, StartBuildRecord(context.TODO(), client, build))
+	assert.Equal(t, int32(2), build.Status.BuildNumber)
+	assert.Equal(t, int64(3), build.Status.WorkflowGeneration)
+	assert.Empty(t, build.Status.ImageDigest)
+	assert.NotNil(t, build.Status.StartTime)
+	assert.NotEmpty(t, build.Status.InputsHash)
+
+	// the hash changes with the properties
+	hash := build.Status.InputsHash
+	userProps.Data[workflowproj.ApplicationPropertiesFileName] = "my.prop=2"
+	assert.NoError(t, client.Update(context.TODO(), userProps))
+	assert.NoError(t, StartBuildRecord(context.TODO(), client, build))
+	assert.NotEqual(t, hash, build.Status.InputsHash)
+}
+
+func TestCompleteBuildRecord(t *testing.T) {
+	build := test.GetNewEmptySonataFlowBuild("greeting", t.Name())
+	for i := int32(1); i <= 3; i++ {
+		build.Status.BuildNumber = i
+		build.Status.CompletionTime = nil
+		build.Status.BuildPhase = operatorapi.BuildPhaseRunning
+		CompleteBuildRecord(build, 2)
+		assert.Nil(t, build.Status.CompletionTime)
+
+		build.Status.BuildPhase = operatorapi.BuildPhaseSucceeded
+		build.Status.ImageDigest = "sha256:digest"
+		CompleteBuildRecord(build, 2)
+		// completing twice doesn't add the build again
+		CompleteBuildRecord(build, 2)
+	}
+	assert.Len(t, build.Status.History, 2)
+	assert.Equal(t, int32(3), build.Status.History[0].Number)
+	assert.Equal(t, int32(2), build.Status.History[1].Number)
+	assert.Equal(t, "sha256:digest", build.Status.History[0].ImageDigest)
+	assert.NotNil(t, build.Status.History[0].CompletionTime)
+}
@@ -58,12 +58,15 @@ func (k *sonataFlowBuildManager) GetOrCreateBuild(workflow *operatorapi.SonataFl
 	if err := k.client.Get(k.ctx, client.ObjectKeyFromObject(workflow), buildInstance); err != nil {
 		if errors.IsNotFound(err) {
 			plat := &operatorapi.SonataFlowPlatform{}
-			if plat, err = platform.GetActivePlatform(k.ctx, k.client, workflow.Namespace); err != nil {
+			if plat, err = platform.GetEffectivePlatform(k.ctx, k.client, workflow.Namespace); err != nil {
 				return nil, err
 			}
 			workflowBuildTemplate := plat.Spec.Build.Template.DeepCopy()
-			if persistence.UsesPostgreSQLPersistence(workflow, plat) {
-				addPersistenceExtensions(workflowBuildTemplate)
+			if extensions := persistence.GetPersistenceExtensions(persistence.GetWorkflowPersistenceType(workflow, plat)); len(extensions) > 0 {
+				addExtensions(workflowBuildTemplate, extensions)
+			}
+			if extensions := cfg.GetCfg().MonitoringExtensions; operatorapi.GetWorkflowMonitoring(workflow, plat).IsEnabled() && len(extensions) > 0 {
+				addExtensions(workflowBuildTemplate, extensions)
 			}
 			buildInstance.Spec.BuildTemplate = *workflowBuildTemplate
 			if err = controllerutil.SetControllerReference(workflow, buildInstance, k.client.Scheme()); err != nil {
@@ -98,17 +101,17 @@ func NewSonataFlowBuildManager(ctx context.Context, client client.Client) Sonata
 	}
 }
 
-// addPersistenceExtensions Adds the persistence related extensions to the current BuildTemplate if none of them is
+// addExtensions Adds the given persistence or monitoring related extensions to the current BuildTemplate if none of them is
 // already provided. If any of them is detected, its assumed that users might already have provided them in the
 // SonataFlowPlatform, so we just let the provided configuration.
-func addPersistenceExtensions(template *operatorapi.BuildTemplate) {
+func addExtensions(template *operatorapi.BuildTemplate, extensions []cfg.GAV) {
 	quarkusExtensions := getBuildArg(template.BuildArgs, QuarkusExtensionsBuildArg)
 	if quarkusExtensions == nil {
 		template.BuildArgs = append(template.BuildArgs, v1.EnvVar{Name: QuarkusExtensionsBuildArg})
 		quarkusExtensions = &template.BuildArgs[len(template.BuildArgs)-1]
 	}
-	if !hasAnyExtensionPresent(quarkusExtensions, persistence.GetPostgreSQLExtensions()) {
-		for _, extension := range persistence.GetPostgreSQLExtensions() {
+	if !hasAnyExtensionPresent(quarkusExtensions, extensions) {
+		for _, extension := range extensions {
 			if len(quarkusExtensions.Value) > 0 {
 				quarkusExtensions.Value = quarkusExtensions.Value + ","
 			}
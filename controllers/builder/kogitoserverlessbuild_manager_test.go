@@ -69,6 +69,36 @@ func TestSonataFlowBuildManager_GetOrCreateBuildWithPlatformPersistence(t *testi
 	testGetOrCreateBuildWithPersistence(t, &currentPlatform, &workflow)
 }
 
+func TestSonataFlowBuildManager_GetOrCreateBuildWithMongoDBPersistence(t *testing.T) {
+	currentPlatform := operatorapi.SonataFlowPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "current-platform"},
+		Spec: operatorapi.SonataFlowPlatformSpec{
+			Persistence: &operatorapi.PlatformPersistenceOptionsSpec{
+				PostgreSQL: &operatorapi.PlatformPersistencePostgreSQL{},
+			},
+		},
+	}
+	// MongoDB is configured in the workflow, the platform's postgresql must be ignored
+	workflow := operatorapi.SonataFlow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-workflow",
+		},
+		Spec: operatorapi.SonataFlowSpec{
+			Persistence: &operatorapi.PersistenceOptionsSpec{
+				MongoDB: &operatorapi.PersistenceMongoDB{},
+			},
+		},
+	}
+	buildManager := prepareGetOrCreateBuildTest(t, &currentPlatform)
+	build, _ := buildManager.GetOrCreateBuild(&workflow)
+	assert.Equal(t, 1, len(build.Spec.BuildArgs))
+	for _, extension := range persistence.GetMongoDBExtensions() {
+		assert.Contains(t, build.Spec.BuildArgs[0].Value, extension.String())
+	}
+	assert.NotContains(t, build.Spec.BuildArgs[0].Value, "quarkus-jdbc-postgresql")
+	test.RestoreControllersConfig(t)
+}
+
 func TestSonataFlowBuildManager_GetOrCreateBuildWithNoPersistence(t *testing.T) {
 	// Platform has no persistence
 	currentPlatform := operatorapi.SonataFlowPlatform{
@@ -112,7 +142,7 @@ func prepareGetOrCreateBuildTest(t *testing.T, currentPlatform *operatorapi.Sona
 func Test_addPersistenceExtensionsWithEmptyArgs(t *testing.T) {
 	initializeControllersConfig(t)
 	buildTemplate := &operatorapi.BuildTemplate{}
-	addPersistenceExtensions(buildTemplate)
+	addExtensions(buildTemplate, persistence.GetPostgreSQLExtensions())
 	assert.Equal(t, 1, len(buildTemplate.BuildArgs))
 	assertContainsPersistence(t, buildTemplate.BuildArgs, 0)
 	test.RestoreControllersConfig(t)
@@ -125,7 +155,7 @@ func Test_addPersistenceExtensionsWithNoQuarkusExtensionsArg(t *testing.T) {
 			{Name: "VAR1"},
 		},
 	}
-	addPersistenceExtensions(buildTemplate)
+	addExtensions(buildTemplate, persistence.GetPostgreSQLExtensions())
 	assert.Equal(t, 2, len(buildTemplate.BuildArgs))
 	assertContainsPersistence(t, buildTemplate.BuildArgs, 1)
 	test.RestoreControllersConfig(t)
@@ -139,7 +169,7 @@ func Test_addPersistenceExtensionsWithQuarkusExtensionsArgAndNoPersistenceExtens
 			{Name: "QUARKUS_EXTENSIONS", Value: "org.acme:org.acme.library:1.0.0"},
 		},
 	}
-	addPersistenceExtensions(buildTemplate)
+	addExtensions(buildTemplate, persistence.GetPostgreSQLExtensions())
 	assert.Equal(t, 2, len(buildTemplate.BuildArgs))
 	assertContainsPersistence(t, buildTemplate.BuildArgs, 1)
 	test.RestoreControllersConfig(t)
@@ -153,7 +183,7 @@ func Test_addPersistenceExtensionsWithQuarkusExtensionsArgAndPersistenceExtensio
 			{Name: "QUARKUS_EXTENSIONS", Value: "org.acme:org.acme.library:1.0.0,io.quarkus:quarkus-jdbc-postgresql:8.8.0.Final"},
 		},
 	}
-	addPersistenceExtensions(buildTemplate)
+	addExtensions(buildTemplate, persistence.GetPostgreSQLExtensions())
 	assert.Equal(t, 2, len(buildTemplate.BuildArgs))
 	assert.Equal(t, v1.EnvVar{Name: "VAR1", Value: "VALUE1"}, buildTemplate.BuildArgs[0])
 	assert.Equal(t, v1.EnvVar{Name: "QUARKUS_EXTENSIONS", Value: "org.acme:org.acme.library:1.0.0,io.quarkus:quarkus-jdbc-postgresql:8.8.0.Final"}, buildTemplate.BuildArgs[1])
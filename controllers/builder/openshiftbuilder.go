@@ -251,6 +251,9 @@ func (o *openshiftBuilderManager) Reconcile(build *operatorapi.SonataFlowBuild)
 		build.Status.Error = openshiftBuild.Status.Message
 	}
 	build.Status.ImageTag = openshiftBuild.Status.OutputDockerImageReference
+	if openshiftBuild.Status.Output.To != nil {
+		build.Status.ImageDigest = openshiftBuild.Status.Output.To.ImageDigest
+	}
 
 	return build.Status.SetInnerBuild(kubeutil.ToTypedLocalReference(openshiftBuild))
 }
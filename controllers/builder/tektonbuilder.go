@@ -0,0 +1,320 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package builder
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/workflowdef"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+const (
+	// TektonPipelineOption the build strategy option with the name of the Pipeline, in the platform namespace, that builds the workflows
+	TektonPipelineOption = "TektonPipeline"
+	// TektonServiceAccountOption the build strategy option with the ServiceAccount running the PipelineRuns
+	TektonServiceAccountOption = "TektonServiceAccount"
+	// TektonSourceWorkspaceOption the build strategy option with the name of the Pipeline workspace that receives the workflow sources
+	TektonSourceWorkspaceOption = "TektonSourceWorkspace"
+
+	defaultTektonSourceWorkspace = "source"
+	tektonSourcesConfigMapSuffix = "-build-sources"
+
+	tektonParamImage      = "IMAGE"
+	tektonParamDockerfile = "DOCKERFILE"
+	tektonParamContext    = "CONTEXT"
+	tektonParamBuildArgs  = "BUILD_ARGS"
+	tektonParamEnvVars    = "ENV_VARS"
+	tektonResultImageURL  = "IMAGE_URL"
+	tektonResultDigest    = "IMAGE_DIGEST"
+
+	tektonSucceededCondition = "Succeeded"
+	tektonReasonPending      = "PipelineRunPending"
+	tektonReasonCancelled    = "Cancelled"
+	tektonReasonRunCancelled = "PipelineRunCancelled"
+	tektonReasonStopped      = "StoppedRunFinally"
+)
+
+var pipelineRunGVK = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "PipelineRun"}
+
+var _ BuildManager = &tektonBuilderManager{}
+
+// tektonBuilderManager builds the workflows by running a Tekton Pipeline provided by the administrator.
+//
+// The Pipeline receives the workflow sources, the flow definition, the properties, the resources and the Dockerfile from
+// the builder ConfigMap, in the workspace named by TektonSourceWorkspaceOption, "source" by default.
+// The PipelineRun sets the params IMAGE, the image to build and push, DOCKERFILE, CONTEXT, BUILD_ARGS and ENV_VARS,
+// the last two as arrays of "NAME=value" items. Params not declared by the Pipeline are ignored.
+// The results IMAGE_URL and IMAGE_DIGEST of the Pipeline, if any, are the image produced by the build, the same
+// results Tekton Chains uses to sign it.
+type tektonBuilderManager struct {
+	buildManagerContext
+}
+
+func newTektonBuilderManager(managerContext buildManagerContext) BuildManager {
+	return &tektonBuilderManager{buildManagerContext: managerContext}
+}
+
+func (t *tektonBuilderManager) Schedule(build *operatorapi.SonataFlowBuild) error {
+	pipeline := t.platform.Spec.Build.Config.BuildStrategyOptions[TektonPipelineOption]
+	if len(pipeline) == 0 {
+		build.Status.BuildPhase = operatorapi.BuildPhaseError
+		build.Status.Error = fmt.Sprintf("Build strategy option %s is required by the %s build strategy", TektonPipelineOption, operatorapi.TektonBuildStrategy)
+		return nil
+	}
+	workflow, err := t.fetchWorkflowForBuild(build)
+	if err != nil {
+		return err
+	}
+	sources, err := t.ensureSourcesConfigMap(build, workflow)
+	if err != nil {
+		return err
+	}
+	projectedSources, err := t.getProjectedSources(workflow, sources)
+	if err != nil {
+		return err
+	}
+	image := t.getImage(workflow)
+	pipelineRun := newPipelineRun(build, t.platform, pipeline, image, projectedSources)
+	workflowproj.SetMergedLabels(workflow, pipelineRun)
+	if err = controllerutil.SetControllerReference(build, pipelineRun, t.client.Scheme()); err != nil {
+		return err
+	}
+	if err = t.client.Create(t.ctx, pipelineRun); err != nil {
+		return err
+	}
+	ref := &corev1.TypedLocalObjectReference{APIGroup: &pipelineRunGVK.Group, Kind: pipelineRunGVK.Kind, Name: pipelineRun.GetName()}
+	if err = build.Status.SetInnerBuild(ref); err != nil {
+		return err
+	}
+	build.Status.BuildPhase = operatorapi.BuildPhaseScheduling
+	build.Status.ImageTag = image
+	build.Status.Error = ""
+	return nil
+}
+
+func (t *tektonBuilderManager) Reconcile(build *operatorapi.SonataFlowBuild) error {
+	ref := &corev1.TypedLocalObjectReference{}
+	if err := build.Status.GetInnerBuild(ref); err != nil {
+		return err
+	}
+	if len(ref.Name) == 0 {
+		build.Status.BuildPhase = operatorapi.BuildPhaseNone
+		return nil
+	}
+	pipelineRun := &unstructured.Unstructured{}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	if err := t.client.Get(t.ctx, types.NamespacedName{Namespace: build.Namespace, Name: ref.Name}, pipelineRun); err != nil {
+		if errors.IsNotFound(err) {
+			// schedules a new PipelineRun in the next reconciliation
+			build.Status.BuildPhase = operatorapi.BuildPhaseNone
+			return nil
+		}
+		return err
+	}
+	phase, message := getPipelineRunPhase(pipelineRun)
+	build.Status.BuildPhase = phase
+	build.Status.Error = message
+	results := getPipelineRunResults(pipelineRun)
+	if url := results[tektonResultImageURL]; len(url) > 0 {
+		build.Status.ImageTag = url
+	}
+	build.Status.ImageDigest = results[tektonResultDigest]
+	return nil
+}
+
+// ensureSourcesConfigMap creates the ConfigMap with the flow definition and the Dockerfile.
+func (t *tektonBuilderManager) ensureSourcesConfigMap(build *operatorapi.SonataFlowBuild, workflow *operatorapi.SonataFlow) (*corev1.ConfigMap, error) {
+	flow, err := workflowdef.GetJSONWorkflow(workflow, t.ctx)
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: build.Namespace, Name: build.Name + tektonSourcesConfigMapSuffix}}
+	if _, err = controllerutil.CreateOrPatch(t.ctx, t.client, cm, func() error {
+		workflowproj.SetMergedLabels(workflow, cm)
+		cm.Data = map[string]string{
+			resourceDockerfile: platform.GetCustomizedBuilderDockerfile(t.builderConfigMap.Data[defaultBuilderResourceName], *t.platform),
+			workflow.Name + t.builderConfigMap.Data[configKeyDefaultExtension]: string(flow),
+		}
+		return controllerutil.SetControllerReference(build, cm, t.client.Scheme())
+	}); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// getProjectedSources lays out the workflow sources as the other builders do: the resources in their workflow path and
+// the properties next to the flow definition.
+func (t *tektonBuilderManager) getProjectedSources(workflow *operatorapi.SonataFlow, sources *corev1.ConfigMap) ([]corev1.VolumeProjection, error) {
+	projections := []corev1.VolumeProjection{
+		{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: sources.Name}}},
+	}
+	for _, props := range buildWorkflowPropertyResources(workflow) {
+		projections = append(projections, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: props.ConfigMap, Optional: utils.Pbool(true)},
+		})
+	}
+	for _, res := range workflow.Spec.Resources.ConfigMaps {
+		projection := &corev1.ConfigMapProjection{LocalObjectReference: res.ConfigMap}
+		if len(res.WorkflowPath) > 0 {
+			cm := &corev1.ConfigMap{}
+			if err := t.client.Get(t.ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: res.ConfigMap.Name}, cm); err != nil {
+				return nil, err
+			}
+			keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+			for k := range cm.Data {
+				keys = append(keys, k)
+			}
+			for k := range cm.BinaryData {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				projection.Items = append(projection.Items, corev1.KeyToPath{Key: k, Path: path.Join(res.WorkflowPath, k)})
+			}
+		}
+		projections = append(projections, corev1.VolumeProjection{ConfigMap: projection})
+	}
+	return projections, nil
+}
+
+func (t *tektonBuilderManager) getImage(workflow *operatorapi.SonataFlow) string {
+	if address := t.platform.Spec.Build.Config.Registry.Address; len(address) > 0 {
+		return address + "/" + buildNamespacedImageTag(workflow)
+	}
+	return buildNamespacedImageTag(workflow)
+}
+
+func newPipelineRun(build *operatorapi.SonataFlowBuild, plf *operatorapi.SonataFlowPlatform, pipeline, image string, sources []corev1.VolumeProjection) *unstructured.Unstructured {
+	options := plf.Spec.Build.Config.BuildStrategyOptions
+	workspace := options[TektonSourceWorkspaceOption]
+	if len(workspace) == 0 {
+		workspace = defaultTektonSourceWorkspace
+	}
+	projected := make([]interface{}, 0, len(sources))
+	for _, source := range sources {
+		projected = append(projected, toUnstructuredProjection(source))
+	}
+	spec := map[string]interface{}{
+		"pipelineRef": map[string]interface{}{"name": pipeline},
+		"params": []interface{}{
+			map[string]interface{}{"name": tektonParamImage, "value": image},
+			map[string]interface{}{"name": tektonParamDockerfile, "value": "./" + resourceDockerfile},
+			map[string]interface{}{"name": tektonParamContext, "value": "."},
+			map[string]interface{}{"name": tektonParamBuildArgs, "value": toTektonArrayParam(build.Spec.BuildArgs)},
+			map[string]interface{}{"name": tektonParamEnvVars, "value": toTektonArrayParam(build.Spec.Envs)},
+		},
+		"workspaces": []interface{}{
+			map[string]interface{}{"name": workspace, "projected": map[string]interface{}{"sources": projected}},
+		},
+	}
+	if sa := options[TektonServiceAccountOption]; len(sa) > 0 {
+		spec["taskRunTemplate"] = map[string]interface{}{"serviceAccountName": sa}
+	}
+	if timeout := plf.Spec.Build.Config.GetTimeout(); timeout.Duration > 0 {
+		spec["timeouts"] = map[string]interface{}{"pipeline": timeout.Duration.String()}
+	}
+	pipelineRun := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	pipelineRun.SetNamespace(build.Namespace)
+	pipelineRun.SetGenerateName(build.Name + "-")
+	return pipelineRun
+}
+
+func toUnstructuredProjection(source corev1.VolumeProjection) map[string]interface{} {
+	cm := map[string]interface{}{"name": source.ConfigMap.Name}
+	if source.ConfigMap.Optional != nil {
+		cm["optional"] = *source.ConfigMap.Optional
+	}
+	if len(source.ConfigMap.Items) > 0 {
+		items := make([]interface{}, 0, len(source.ConfigMap.Items))
+		for _, item := range source.ConfigMap.Items {
+			items = append(items, map[string]interface{}{"key": item.Key, "path": item.Path})
+		}
+		cm["items"] = items
+	}
+	return map[string]interface{}{"configMap": cm}
+}
+
+func toTektonArrayParam(vars []corev1.EnvVar) []interface{} {
+	values := make([]interface{}, 0, len(vars))
+	for _, v := range vars {
+		values = append(values, v.Name+"="+v.Value)
+	}
+	return values
+}
+
+// getPipelineRunPhase maps the Succeeded condition of the PipelineRun to a BuildPhase, along with the failure message.
+func getPipelineRunPhase(pipelineRun *unstructured.Unstructured) (operatorapi.BuildPhase, string) {
+	conditions, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != tektonSucceededCondition {
+			continue
+		}
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		switch condition["status"] {
+		case string(corev1.ConditionTrue):
+			return operatorapi.BuildPhaseSucceeded, ""
+		case string(corev1.ConditionFalse):
+			switch reason {
+			case tektonReasonCancelled, tektonReasonRunCancelled, tektonReasonStopped:
+				return operatorapi.BuildPhaseInterrupted, message
+			}
+			return operatorapi.BuildPhaseFailed, message
+		default:
+			if reason == tektonReasonPending {
+				return operatorapi.BuildPhasePending, ""
+			}
+			return operatorapi.BuildPhaseRunning, ""
+		}
+	}
+	return operatorapi.BuildPhaseScheduling, ""
+}
+
+func getPipelineRunResults(pipelineRun *unstructured.Unstructured) map[string]string {
+	results := map[string]string{}
+	values, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "results")
+	for _, r := range values {
+		result, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := result["name"].(string)
+		if value, ok := result["value"].(string); ok {
+			results[name] = value
+		}
+	}
+	return results
+}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func Test_tektonBuilderManager_ScheduleAndReconcile(t *testing.T) {
+	ns := t.Name()
+	workflow := test.GetBaseSonataFlow(ns)
+	workflow.Spec.Resources.ConfigMaps = []operatorapi.ConfigMapWorkflowResource{
+		{ConfigMap: v1.LocalObjectReference{Name: "myopenapis"}, WorkflowPath: "specs"},
+	}
+	externalCm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "myopenapis", Namespace: ns},
+		Data:       map[string]string{"openapi.yaml": "openapi: 3.0.0"},
+	}
+	platform := test.GetBasePlatformInReadyPhase(ns)
+	platform.Spec.Build.Config.BuildStrategy = operatorapi.TektonBuildStrategy
+	platform.Spec.Build.Config.BuildStrategyOptions = map[string]string{
+		TektonPipelineOption:       "supply-chain",
+		TektonServiceAccountOption: "pipeline",
+	}
+	platform.Spec.Build.Config.Registry.Address = "quay.io/myorg"
+	config := test.GetSonataFlowBuilderConfig(ns)
+	client := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, platform, config, externalCm).Build()
+
+	buildManager := newTektonBuilderManager(buildManagerContext{
+		ctx:              context.TODO(),
+		client:           client,
+		platform:         platform,
+		builderConfigMap: config,
+	})
+	build, err := NewSonataFlowBuildManager(context.TODO(), client).GetOrCreateBuild(workflow)
+	assert.NoError(t, err)
+	build.Spec.BuildArgs = []v1.EnvVar{{Name: QuarkusExtensionsBuildArg, Value: "io.quarkus:quarkus-jdbc-postgresql:3.8.4"}}
+	assert.NoError(t, buildManager.Schedule(build))
+	assert.Equal(t, operatorapi.BuildPhaseScheduling, build.Status.BuildPhase)
+	assert.Equal(t, "quay.io/myorg/"+ns+"/"+workflow.Name+":latest", build.Status.ImageTag)
+
+	sources := &v1.ConfigMap{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: workflow.Name + tektonSourcesConfigMapSuffix}, sources))
+	assert.Contains(t, sources.Data, resourceDockerfile)
+	assert.Contains(t, sources.Data, workflow.Name+".sw.json")
+
+	ref := &v1.TypedLocalObjectReference{}
+	assert.NoError(t, build.Status.GetInnerBuild(ref))
+	pipelineRun := &unstructured.Unstructured{}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: ref.Name}, pipelineRun))
+	pipeline, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "pipelineRef", "name")
+	assert.Equal(t, "supply-chain", pipeline)
+	sa, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "taskRunTemplate", "serviceAccountName")
+	assert.Equal(t, "pipeline", sa)
+	params, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "params")
+	assert.Contains(t, params, map[string]interface{}{"name": tektonParamImage, "value": build.Status.ImageTag})
+	assert.Contains(t, params, map[string]interface{}{"name": tektonParamBuildArgs, "value": []interface{}{"QUARKUS_EXTENSIONS=io.quarkus:quarkus-jdbc-postgresql:3.8.4"}})
+	workspaces, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "workspaces")
+	assert.Len(t, workspaces, 1)
+	projected, _, _ := unstructured.NestedSlice(workspaces[0].(map[string]interface{}), "projected", "sources")
+	// sources, user and managed properties, and the external resources in their workflow path
+	assert.Len(t, projected, 4)
+	items, _, _ := unstructured.NestedSlice(projected[3].(map[string]interface{}), "configMap", "items")
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": "openapi.yaml", "path": "specs/openapi.yaml"}}, items)
+
+	// the pipeline succeeded, signing the image
+	assert.NoError(t, unstructured.SetNestedSlice(pipelineRun.Object, []interface{}{
+		map[string]interface{}{"type": tektonSucceededCondition, "status": "True", "reason": "Succeeded"},
+	}, "status", "conditions"))
+	assert.NoError(t, unstructured.SetNestedSlice(pipelineRun.Object, []interface{}{
+		map[string]interface{}{"name": tektonResultImageURL, "value": "quay.io/myorg/greeting:latest"},
+		map[string]interface{}{"name": tektonResultDigest, "value": "sha256:1234"},
+	}, "status", "results"))
+	assert.NoError(t, client.Update(context.TODO(), pipelineRun))
+	assert.NoError(t, buildManager.Reconcile(build))
+	assert.Equal(t, operatorapi.BuildPhaseSucceeded, build.Status.BuildPhase)
+	assert.Equal(t, "quay.io/myorg/greeting:latest", build.Status.ImageTag)
+	assert.Equal(t, "sha256:1234", build.Status.ImageDigest)
+}
+
+func Test_tektonBuilderManager_PipelineRequired(t *testing.T) {
+	ns := t.Name()
+	workflow := test.GetBaseSonataFlow(ns)
+	platform := test.GetBasePlatformInReadyPhase(ns)
+	platform.Spec.Build.Config.BuildStrategy = operatorapi.TektonBuildStrategy
+	config := test.GetSonataFlowBuilderConfig(ns)
+	client := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, platform, config).Build()
+
+	buildManager := newTektonBuilderManager(buildManagerContext{ctx: context.TODO(), client: client, platform: platform, builderConfigMap: config})
+	build := test.GetNewEmptySonataFlowBuild(workflow.Name, ns)
+	assert.NoError(t, buildManager.Schedule(build))
+	assert.Equal(t, operatorapi.BuildPhaseError, build.Status.BuildPhase)
+	assert.Contains(t, build.Status.Error, TektonPipelineOption)
+}
+
+func Test_getPipelineRunPhase(t *testing.T) {
+	newPipelineRun := func(status, reason string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": tektonSucceededCondition, "status": status, "reason": reason, "message": "a message"}},
+		}}}
+	}
+	phase, _ := getPipelineRunPhase(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	assert.Equal(t, operatorapi.BuildPhaseScheduling, phase)
+	phase, _ = getPipelineRunPhase(newPipelineRun("Unknown", tektonReasonPending))
+	assert.Equal(t, operatorapi.BuildPhasePending, phase)
+	phase, _ = getPipelineRunPhase(newPipelineRun("Unknown", "Running"))
+	assert.Equal(t, operatorapi.BuildPhaseRunning, phase)
+	phase, message := getPipelineRunPhase(newPipelineRun("False", "Failed"))
+	assert.Equal(t, operatorapi.BuildPhaseFailed, phase)
+	assert.Equal(t, "a message", message)
+	phase, _ = getPipelineRunPhase(newPipelineRun("False", tektonReasonCancelled))
+	assert.Equal(t, operatorapi.BuildPhaseInterrupted, phase)
+}
@@ -38,6 +38,9 @@ var defaultControllersCfg = &ControllersCfg{
 	DefaultPvcKanikoSize:          "1Gi",
 	KanikoDefaultWarmerImageTag:   "gcr.io/kaniko-project/warmer:v1.9.0",
 	KanikoExecutorImageTag:        "gcr.io/kaniko-project/executor:v1.9.0",
+	BuildahImageTag:               "quay.io/buildah/stable:v1.33",
+	BuildKitImageTag:              "moby/buildkit:v0.12.5-rootless",
+	PostgreSQLImageTag:            "docker.io/library/postgres:15.5",
 	BuilderConfigMapName:          "sonataflow-operator-builder-config",
 }
 
@@ -60,14 +63,24 @@ type ControllersCfg struct {
 	HealthFailureThresholdDevMode   int32  `yaml:"healthFailureThresholdDevMode,omitempty"`
 	KanikoDefaultWarmerImageTag     string `yaml:"kanikoDefaultWarmerImageTag,omitempty"`
 	KanikoExecutorImageTag          string `yaml:"kanikoExecutorImageTag,omitempty"`
+	BuildahImageTag                 string `yaml:"buildahImageTag,omitempty"`
+	BuildKitImageTag                string `yaml:"buildKitImageTag,omitempty"`
+	PostgreSQLImageTag              string `yaml:"postgreSQLImageTag,omitempty"`
 	JobsServicePostgreSQLImageTag   string `yaml:"jobsServicePostgreSQLImageTag,omitempty"`
+	JobsServiceMongoDBImageTag      string `yaml:"jobsServiceMongoDBImageTag,omitempty"`
+	JobsServiceInfinispanImageTag   string `yaml:"jobsServiceInfinispanImageTag,omitempty"`
 	JobsServiceEphemeralImageTag    string `yaml:"jobsServiceEphemeralImageTag,omitempty"`
 	DataIndexPostgreSQLImageTag     string `yaml:"dataIndexPostgreSQLImageTag,omitempty"`
+	DataIndexMongoDBImageTag        string `yaml:"dataIndexMongoDBImageTag,omitempty"`
+	DataIndexInfinispanImageTag     string `yaml:"dataIndexInfinispanImageTag,omitempty"`
 	DataIndexEphemeralImageTag      string `yaml:"dataIndexEphemeralImageTag,omitempty"`
 	SonataFlowBaseBuilderImageTag   string `yaml:"sonataFlowBaseBuilderImageTag,omitempty"`
 	SonataFlowDevModeImageTag       string `yaml:"sonataFlowDevModeImageTag,omitempty"`
 	BuilderConfigMapName            string `yaml:"builderConfigMapName,omitempty"`
 	PostgreSQLPersistenceExtensions []GAV  `yaml:"postgreSQLPersistenceExtensions,omitempty"`
+	MongoDBPersistenceExtensions    []GAV  `yaml:"mongoDBPersistenceExtensions,omitempty"`
+	InfinispanPersistenceExtensions []GAV  `yaml:"infinispanPersistenceExtensions,omitempty"`
+	MonitoringExtensions            []GAV  `yaml:"monitoringExtensions,omitempty"`
 }
 
 // InitializeControllersCfg initializes the platform configuration for this instance.
@@ -51,6 +51,13 @@ func TestInitializeControllersCfgAt_ValidFile(t *testing.T) {
 		ArtifactId: "kie-addons-quarkus-persistence-jdbc",
 		Version:    "999-SNAPSHOT",
 	}, postgresExtensions[2])
+
+	assert.Equal(t, 2, len(cfg.MongoDBPersistenceExtensions))
+	assert.Equal(t, GAV{
+		GroupId:    "org.kie",
+		ArtifactId: "kie-addons-quarkus-persistence-mongodb",
+		Version:    "999-SNAPSHOT",
+	}, cfg.MongoDBPersistenceExtensions[1])
 }
 
 func TestInitializeControllersCfgAt_FileNotFound(t *testing.T) {
@@ -32,6 +32,14 @@ import (
 
 const (
 	PlatformServices operatorapi.WorkFlowCapability = "services"
+	// PlatformBuild shares the registry, base image and build template of the referenced platform
+	PlatformBuild operatorapi.WorkFlowCapability = "build"
+	// PlatformProperties shares the workflow managed properties of the referenced platform
+	PlatformProperties operatorapi.WorkFlowCapability = "properties"
+	// PlatformPersistence shares the persistence of the referenced platform
+	PlatformPersistence operatorapi.WorkFlowCapability = "persistence"
+	// PlatformMonitoring shares the monitoring configuration of the referenced platform
+	PlatformMonitoring operatorapi.WorkFlowCapability = "monitoring"
 )
 
 // GetActiveClusterPlatform returns the currently installed active cluster platform.
@@ -107,9 +115,9 @@ func listAllClusterPlatforms(ctx context.Context, c ctrl.Reader) (*operatorapi.S
 	return &lst, nil
 }
 
-// IsActive determines if the given cluster platform is being used.
+// IsActive determines if the given cluster platform is being used. A cluster platform being deleted is no longer used.
 func IsActive(p *operatorapi.SonataFlowClusterPlatform) bool {
-	return p.Status.IsReady() && !p.Status.IsDuplicated()
+	return p.Status.IsReady() && !p.Status.IsDuplicated() && p.DeletionTimestamp.IsZero()
 }
 
 // IsSecondary determines if the given cluster platform is marked as secondary.
@@ -119,3 +127,22 @@ func IsSecondary(p *operatorapi.SonataFlowClusterPlatform) bool {
 	}
 	return false
 }
+
+// ClearPlatformReferences removes the reference to the given cluster platform from the status of every platform.
+func ClearPlatformReferences(ctx context.Context, c ctrl.Client, cPlatform *operatorapi.SonataFlowClusterPlatform) error {
+	platforms := &operatorapi.SonataFlowPlatformList{}
+	if err := c.List(ctx, platforms); err != nil {
+		return err
+	}
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		if platform.Status.ClusterPlatformRef == nil || platform.Status.ClusterPlatformRef.Name != cPlatform.Name {
+			continue
+		}
+		platform.Status.ClusterPlatformRef = nil
+		if err := c.Status().Update(ctx, platform); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -33,6 +33,7 @@ const (
 	KnativeScheme    = "knative"
 	KubernetesScheme = "kubernetes"
 	OpenshiftScheme  = "openshift"
+	IstioScheme      = "istio"
 
 	// PortQueryParam well known query param to select a particular target port, for example when a service is being
 	// discovered and there are many ports to select.
@@ -51,14 +52,22 @@ const (
 	kubernetesDeployments  = "kubernetes:deployments.v1.apps"
 	kubernetesStatefulSets = "kubernetes:statefulsets.v1.apps"
 	kubernetesIngresses    = "kubernetes:ingresses.v1.networking.k8s.io"
+	kubernetesGateways     = "kubernetes:gateways.v1beta1.gateway.networking.k8s.io"
+	kubernetesHTTPRoutes   = "kubernetes:httproutes.v1beta1.gateway.networking.k8s.io"
 
 	// knative groups
-	knativeServices = "knative:services.v1.serving.knative.dev"
-	knativeBrokers  = "knative:brokers.v1.eventing.knative.dev"
+	knativeServices         = "knative:services.v1.serving.knative.dev"
+	knativeBrokers          = "knative:brokers.v1.eventing.knative.dev"
+	knativeChannels         = "knative:channels.v1.messaging.knative.dev"
+	knativeInMemoryChannels = "knative:inmemorychannels.v1.messaging.knative.dev"
+	knativeDomainMappings   = "knative:domainmappings.v1beta1.serving.knative.dev"
 
 	// openshift groups
 	openshiftRoutes            = "openshift:routes.v1.route.openshift.io"
 	openshiftDeploymentConfigs = "openshift:deploymentconfigs.v1.apps.openshift.io"
+
+	// istio groups
+	istioVirtualServices = "istio:virtualservices.v1beta1.networking.istio.io"
 )
 
 type ResourceUri struct {
@@ -82,35 +91,47 @@ type ServiceCatalog interface {
 
 type sonataFlowServiceCatalog struct {
 	kubernetesCatalog ServiceCatalog
+	gatewayCatalog    ServiceCatalog
 	knativeCatalog    ServiceCatalog
 	openshiftCatalog  ServiceCatalog
+	istioCatalog      ServiceCatalog
 }
 
-// NewServiceCatalog returns a new ServiceCatalog configured to resolve kubernetes, knative, and openshift resource addresses.
+// NewServiceCatalog returns a new ServiceCatalog configured to resolve kubernetes, Gateway API, knative, openshift,
+// and istio resource addresses.
 func NewServiceCatalog(cli client.Client, knDiscoveryClient *KnDiscoveryClient, openShiftDiscoveryClient *OpenShiftDiscoveryClient) ServiceCatalog {
 	return &sonataFlowServiceCatalog{
 		kubernetesCatalog: newK8SServiceCatalog(cli),
+		gatewayCatalog:    newGatewayServiceCatalog(cli),
 		knativeCatalog:    newKnServiceCatalog(knDiscoveryClient),
 		openshiftCatalog:  newOpenShiftServiceCatalog(openShiftDiscoveryClient),
+		istioCatalog:      newIstioServiceCatalog(cli),
 	}
 }
 
 func NewServiceCatalogForConfig(cli client.Client, cfg *rest.Config) ServiceCatalog {
 	return &sonataFlowServiceCatalog{
 		kubernetesCatalog: newK8SServiceCatalog(cli),
+		gatewayCatalog:    newGatewayServiceCatalog(cli),
 		knativeCatalog:    newKnServiceCatalogForConfig(cfg),
 		openshiftCatalog:  newOpenShiftServiceCatalogForClientAndConfig(cli, cfg),
+		istioCatalog:      newIstioServiceCatalog(cli),
 	}
 }
 
 func (c *sonataFlowServiceCatalog) Query(ctx context.Context, uri ResourceUri, outputFormat string) (string, error) {
 	switch uri.Scheme {
 	case KubernetesScheme:
+		if uri.GVK.Group == gatewayAPIGroup {
+			return c.gatewayCatalog.Query(ctx, uri, outputFormat)
+		}
 		return c.kubernetesCatalog.Query(ctx, uri, outputFormat)
 	case KnativeScheme:
 		return c.knativeCatalog.Query(ctx, uri, outputFormat)
 	case OpenshiftScheme:
 		return c.openshiftCatalog.Query(ctx, uri, outputFormat)
+	case IstioScheme:
+		return c.istioCatalog.Query(ctx, uri, outputFormat)
 	default:
 		return "", fmt.Errorf("unknown scheme was provided for service discovery: %s", uri.Scheme)
 	}
@@ -0,0 +1,177 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func Test_QueryGatewayPrefersSecureListener(t *testing.T) {
+	gateway := mockGateway(namespace1, gateway1Name,
+		mockGatewayListener("web", gatewayv1beta1.HTTPProtocolType, defaultHttpPort),
+		mockGatewayListener("secure", gatewayv1beta1.HTTPSProtocolType, defaultHttpsPort))
+	doTestQuery(t, newGatewayTestCatalog(gateway), *NewResourceUriBuilder(KubernetesScheme).
+		Kind("gateways").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(gateway1Name).Build(), "", "https://10.1.5.20:443")
+}
+
+func Test_QueryGatewayCustomListener(t *testing.T) {
+	gateway := mockGateway(namespace1, gateway1Name,
+		mockGatewayListener("secure", gatewayv1beta1.HTTPSProtocolType, defaultHttpsPort),
+		mockGatewayListener(customPortName, gatewayv1beta1.HTTPProtocolType, 8080))
+	doTestQuery(t, newGatewayTestCatalog(gateway), *NewResourceUriBuilder(KubernetesScheme).
+		Kind("gateways").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(gateway1Name).
+		WithPort(customPortName).Build(), "", "http://10.1.5.20:8080")
+}
+
+func Test_QueryHTTPRouteHostname(t *testing.T) {
+	gateway := mockGateway(namespace1, gateway1Name,
+		mockGatewayListener("web", gatewayv1beta1.HTTPProtocolType, defaultHttpPort),
+		mockGatewayListener("secure", gatewayv1beta1.HTTPSProtocolType, defaultHttpsPort))
+	route := mockHTTPRoute(namespace1, httpRoute1Name, gateway1Name, "", "*.example.com", "orders.example.com")
+	doTestQuery(t, newGatewayTestCatalog(gateway, route), *NewResourceUriBuilder(KubernetesScheme).
+		Kind("httproutes").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(httpRoute1Name).Build(), "", "https://orders.example.com:443")
+}
+
+func Test_QueryHTTPRouteSectionName(t *testing.T) {
+	gateway := mockGateway(namespace1, gateway1Name,
+		mockGatewayListener("web", gatewayv1beta1.HTTPProtocolType, defaultHttpPort),
+		mockGatewayListener("secure", gatewayv1beta1.HTTPSProtocolType, defaultHttpsPort))
+	route := mockHTTPRoute(namespace1, httpRoute1Name, gateway1Name, "web")
+	doTestQuery(t, newGatewayTestCatalog(gateway, route), *NewResourceUriBuilder(KubernetesScheme).
+		Kind("httproutes").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(httpRoute1Name).Build(), "", "http://10.1.5.20:80")
+}
+
+func Test_QueryHTTPRouteWithoutGateway(t *testing.T) {
+	route := mockHTTPRoute(namespace1, httpRoute1Name, gateway1Name, "", "orders.example.com")
+	doTestQueryWithError(t, newGatewayTestCatalog(route), *NewResourceUriBuilder(KubernetesScheme).
+		Kind("httproutes").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(httpRoute1Name).Build(), "", "not found")
+}
+
+func Test_GetHTTPRouteParentGateways(t *testing.T) {
+	const gatewaysNamespace = "gateways"
+	route := mockHTTPRoute(namespace1, httpRoute1Name, gateway1Name, "")
+	gatewayNamespace := gatewayv1beta1.Namespace(gatewaysNamespace)
+	route.Spec.ParentRefs[0].Namespace = &gatewayNamespace
+	routeUri := *NewResourceUriBuilder(KubernetesScheme).
+		Kind("httproutes").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(httpRoute1Name).Build()
+	missingRouteUri := *NewResourceUriBuilder(KubernetesScheme).
+		Kind("httproutes").
+		Group(gatewayAPIGroup).
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name("missing-route").Build()
+
+	gateways, err := GetHTTPRouteParentGateways(context.TODO(), newGatewayTestClient(route), []ResourceUri{routeUri, missingRouteUri})
+	assert.NoError(t, err)
+	assert.Len(t, gateways, 1)
+	assert.Equal(t, gatewayKind, gateways[0].GVK.Kind)
+	assert.Equal(t, gatewaysNamespace, gateways[0].Namespace)
+	assert.Equal(t, gateway1Name, gateways[0].Name)
+}
+
+func newGatewayTestClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func newGatewayTestCatalog(objects ...client.Object) ServiceCatalog {
+	return NewServiceCatalog(newGatewayTestClient(objects...), nil, nil)
+}
+
+func mockGateway(namespace string, name string, listeners ...gatewayv1beta1.Listener) *gatewayv1beta1.Gateway {
+	return &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "gatewayClass1",
+			Listeners:        listeners,
+		},
+		Status: gatewayv1beta1.GatewayStatus{
+			Addresses: []gatewayv1beta1.GatewayAddress{{Value: "10.1.5.20"}},
+		},
+	}
+}
+
+func mockGatewayListener(name string, protocol gatewayv1beta1.ProtocolType, port int32) gatewayv1beta1.Listener {
+	return gatewayv1beta1.Listener{
+		Name:     gatewayv1beta1.SectionName(name),
+		Protocol: protocol,
+		Port:     gatewayv1beta1.PortNumber(port),
+	}
+}
+
+func mockHTTPRoute(namespace string, name string, gatewayName string, sectionName string, hostnames ...string) *gatewayv1beta1.HTTPRoute {
+	parentRef := gatewayv1beta1.ParentReference{Name: gatewayv1beta1.ObjectName(gatewayName)}
+	if len(sectionName) > 0 {
+		section := gatewayv1beta1.SectionName(sectionName)
+		parentRef.SectionName = &section
+	}
+	route := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{ParentRefs: []gatewayv1beta1.ParentReference{parentRef}},
+		},
+	}
+	for _, hostname := range hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1beta1.Hostname(hostname))
+	}
+	return route
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package discovery
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_QueryIstioVirtualServiceMeshHost(t *testing.T) {
+	service := mockServiceWithPorts(namespace1, service1Name,
+		mockServicePort(httpProtocol, tcp, defaultHttpPort),
+		mockServicePort(customPortName, tcp, 8080))
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	virtualService := mockVirtualService(namespace1, virtualService1Name, nil, false, service1Name+"."+namespace1+".svc.cluster.local")
+	doTestQuery(t, newIstioTestCatalog(service, virtualService), *NewResourceUriBuilder(IstioScheme).
+		Kind("virtualservices").
+		Group("networking.istio.io").
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(virtualService1Name).
+		WithPort(customPortName).Build(), KubernetesDNSAddress, "http://service1Name.namespace1.svc:8080")
+}
+
+func Test_QueryIstioVirtualServiceGatewayHost(t *testing.T) {
+	virtualService := mockVirtualService(namespace1, virtualService1Name, []string{"istio-system/ingressgateway"}, false, "*.example.com", "orders.example.com")
+	doTestQuery(t, newIstioTestCatalog(virtualService), *NewResourceUriBuilder(IstioScheme).
+		Kind("virtualservices").
+		Group("networking.istio.io").
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(virtualService1Name).Build(), "", "http://orders.example.com:80")
+}
+
+func Test_QueryIstioVirtualServiceGatewayHostWithTLS(t *testing.T) {
+	virtualService := mockVirtualService(namespace1, virtualService1Name, []string{"istio-system/ingressgateway"}, true, "orders.example.com")
+	doTestQuery(t, newIstioTestCatalog(virtualService), *NewResourceUriBuilder(IstioScheme).
+		Kind("virtualservices").
+		Group("networking.istio.io").
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(virtualService1Name).Build(), "", "https://orders.example.com:443")
+}
+
+func Test_QueryIstioVirtualServiceWithoutHosts(t *testing.T) {
+	virtualService := mockVirtualService(namespace1, virtualService1Name, nil, false, "*")
+	doTestQueryWithError(t, newIstioTestCatalog(virtualService), *NewResourceUriBuilder(IstioScheme).
+		Kind("virtualservices").
+		Group("networking.istio.io").
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(virtualService1Name).Build(), "", "has no resolvable host")
+}
+
+func Test_splitMeshHost(t *testing.T) {
+	for host, expected := range map[string][]string{
+		"orders":                             {"orders", namespace1},
+		"orders.shop":                        {"orders", "shop"},
+		"orders.shop.svc":                    {"orders", "shop"},
+		"orders.shop.svc.cluster.local":      {"orders", "shop"},
+		"orders.example.com":                 nil,
+		"orders.shop.svc.other.cluster.name": nil,
+	} {
+		name, namespace, ok := splitMeshHost(host, namespace1)
+		if expected == nil {
+			if ok {
+				t.Errorf("host: %s should not be a mesh host, but returned: %s/%s", host, namespace, name)
+			}
+		} else if !ok || name != expected[0] || namespace != expected[1] {
+			t.Errorf("host: %s expected: %v, but returned: %s/%s", host, expected, namespace, name)
+		}
+	}
+}
+
+func newIstioTestCatalog(objects ...client.Object) ServiceCatalog {
+	cli := fake.NewClientBuilder().WithObjects(objects...).Build()
+	return NewServiceCatalog(cli, nil, nil)
+}
+
+func mockVirtualService(namespace string, name string, gateways []string, tls bool, hosts ...string) *unstructured.Unstructured {
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(istioVirtualServiceGVK)
+	virtualService.SetNamespace(namespace)
+	virtualService.SetName(name)
+	_ = unstructured.SetNestedStringSlice(virtualService.Object, hosts, "spec", "hosts")
+	if len(gateways) > 0 {
+		_ = unstructured.SetNestedStringSlice(virtualService.Object, gateways, "spec", "gateways")
+	}
+	if tls {
+		_ = unstructured.SetNestedSlice(virtualService.Object, []interface{}{map[string]interface{}{}}, "spec", "tls")
+	}
+	return virtualService
+}
@@ -30,10 +30,12 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 
 	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
 
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	fakeservingclient "knative.dev/serving/pkg/client/injection/client/fake"
 )
 
@@ -123,3 +125,87 @@ func doTestQueryKnativeBroker(t *testing.T, expectedUri string) {
 		Namespace(namespace1).
 		Name(knBrokerName1).Build(), "", expectedUri)
 }
+
+func Test_QueryKnativeInMemoryChannel(t *testing.T) {
+	channel := &messagingv1.InMemoryChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace1,
+			Name:      knChannelName1,
+		},
+	}
+	channel.Status.Address = &duckv1.Addressable{
+		URL: &apis.URL{
+			Scheme: "http",
+			Host:   knChannelName1 + "-kn-channel." + namespace1 + ".svc.cluster.local",
+		},
+	}
+	_, client := fakeeventingclient.With(context.TODO(), channel)
+	discoveryClient := newKnDiscoveryClient(nil, client.EventingV1())
+	discoveryClient.MessagingClient = client.MessagingV1()
+	ctg := NewServiceCatalog(nil, discoveryClient, nil)
+	doTestQuery(t, ctg, *NewResourceUriBuilder(KnativeScheme).
+		Kind("inmemorychannels").
+		Group("messaging.knative.dev").
+		Version("v1").
+		Namespace(namespace1).
+		Name(knChannelName1).Build(), "", "http://knChannelName1-kn-channel.namespace1.svc.cluster.local")
+}
+
+func Test_QueryKnativeChannelNotReady(t *testing.T) {
+	channel := &messagingv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace1,
+			Name:      knChannelName1,
+		},
+	}
+	_, client := fakeeventingclient.With(context.TODO(), channel)
+	discoveryClient := newKnDiscoveryClient(nil, client.EventingV1())
+	discoveryClient.MessagingClient = client.MessagingV1()
+	ctg := NewServiceCatalog(nil, discoveryClient, nil)
+	doTestQueryWithError(t, ctg, *NewResourceUriBuilder(KnativeScheme).
+		Kind("channels").
+		Group("messaging.knative.dev").
+		Version("v1").
+		Namespace(namespace1).
+		Name(knChannelName1).Build(), "", "has no address yet")
+}
+
+func Test_QueryKnativeChannelWithoutMessagingClient(t *testing.T) {
+	_, client := fakeeventingclient.With(context.TODO())
+	ctg := NewServiceCatalog(nil, newKnDiscoveryClient(nil, client.EventingV1()), nil)
+	doTestQueryWithError(t, ctg, *NewResourceUriBuilder(KnativeScheme).
+		Kind("channels").
+		Group("messaging.knative.dev").
+		Version("v1").
+		Namespace(namespace1).
+		Name(knChannelName1).Build(), "", "MessagingClient was not provided")
+}
+
+func Test_QueryKnativeDomainMapping(t *testing.T) {
+	domainMapping := &servingv1beta1.DomainMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace1,
+			Name:      knDomainMappingName1,
+		},
+	}
+	domainMapping.Status.URL = &apis.URL{
+		Scheme: "https",
+		Host:   "orders.example.com",
+	}
+	domainMapping.Status.Address = &duckv1.Addressable{
+		URL: &apis.URL{
+			Scheme: "http",
+			Host:   "orders.example.com." + namespace1 + ".svc.cluster.local",
+		},
+	}
+	_, client := fakeservingclient.With(context.TODO(), domainMapping)
+	discoveryClient := newKnDiscoveryClient(client.ServingV1(), nil)
+	discoveryClient.DomainMappingClient = client.ServingV1beta1()
+	ctg := NewServiceCatalog(nil, discoveryClient, nil)
+	doTestQuery(t, ctg, *NewResourceUriBuilder(KnativeScheme).
+		Kind("domainmappings").
+		Group("serving.knative.dev").
+		Version("v1beta1").
+		Namespace(namespace1).
+		Name(knDomainMappingName1).Build(), "", "https://orders.example.com")
+}
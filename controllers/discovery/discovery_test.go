@@ -26,7 +26,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -73,6 +75,56 @@ func doTestQueryKubernetesService(t *testing.T, outputFormat string, expectedUri
 		Name(service1Name).Build(), outputFormat, expectedUri)
 }
 
+func Test_QueryKubernetesHeadlessServiceDNSMode(t *testing.T) {
+	doTestQueryKubernetesHeadlessService(t, KubernetesDNSAddress, "http://service1Name.namespace1.svc:80")
+}
+
+func Test_QueryKubernetesHeadlessServiceIPAddressMode(t *testing.T) {
+	doTestQueryKubernetesHeadlessService(t, KubernetesIPAddress, "http://10.1.5.21:8080")
+}
+
+func doTestQueryKubernetesHeadlessService(t *testing.T, outputFormat string, expectedUri string) {
+	service := mockServiceWithPorts(namespace1, service1Name, mockServicePort(httpProtocol, tcp, defaultHttpPort))
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	portName := httpProtocol
+	port := int32(8080)
+	notReady := false
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace1,
+			Name:      service1Name + "-abc12",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service1Name},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.1.5.20"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+			{Addresses: []string{"10.1.5.21"}},
+		},
+		Ports: []discoveryv1.EndpointPort{{Name: &portName, Port: &port}},
+	}
+	cli := fake.NewClientBuilder().WithRuntimeObjects(service, endpointSlice).Build()
+	ctg := NewServiceCatalog(cli, nil, nil)
+	doTestQuery(t, ctg, *NewResourceUriBuilder(KubernetesScheme).
+		Kind("services").
+		Version("v1").
+		Namespace(namespace1).
+		Name(service1Name).Build(), outputFormat, expectedUri)
+}
+
+func Test_QueryKubernetesHeadlessServiceWithoutEndpoints(t *testing.T) {
+	service := mockServiceWithPorts(namespace1, service1Name, mockServicePort(httpProtocol, tcp, defaultHttpPort))
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	cli := fake.NewClientBuilder().WithRuntimeObjects(service).Build()
+	ctg := NewServiceCatalog(cli, nil, nil)
+	doTestQueryWithError(t, ctg, *NewResourceUriBuilder(KubernetesScheme).
+		Kind("services").
+		Version("v1").
+		Namespace(namespace1).
+		Name(service1Name).Build(), KubernetesIPAddress, "has no ready endpoints")
+}
+
 func Test_QueryKubernetesPodDNSMode(t *testing.T) {
 	doTestQueryKubernetesPod(t, KubernetesDNSAddress, "http://10-1-12-13.namespace1.pod:80")
 }
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	gatewayAPIGroup = "gateway.networking.k8s.io"
+	gatewayKind     = "gateways"
+	httpRouteKind   = "httproutes"
+)
+
+type gatewayServiceCatalog struct {
+	Client client.Client
+}
+
+func newGatewayServiceCatalog(cli client.Client) gatewayServiceCatalog {
+	return gatewayServiceCatalog{
+		Client: cli,
+	}
+}
+
+func (c gatewayServiceCatalog) Query(ctx context.Context, uri ResourceUri, outputFormat string) (string, error) {
+	switch uri.GVK.Kind {
+	case gatewayKind:
+		return c.resolveGatewayQuery(ctx, uri)
+	case httpRouteKind:
+		return c.resolveHTTPRouteQuery(ctx, uri)
+	default:
+		return "", fmt.Errorf("resolution of gateway api kind: %s is not implemented", uri.GVK.Kind)
+	}
+}
+
+func (c gatewayServiceCatalog) resolveGatewayQuery(ctx context.Context, uri ResourceUri) (string, error) {
+	if gateway, err := findGateway(ctx, c.Client, uri.Namespace, uri.Name); err != nil {
+		return "", err
+	} else if listener := findBestSuitedGatewayListener(gateway, uri.GetPort()); listener == nil {
+		return "", fmt.Errorf("gateway: %s in namespace: %s, has no listeners", uri.Name, uri.Namespace)
+	} else {
+		return resolveGatewayListenerUri(gateway, listener, "")
+	}
+}
+
+// resolveHTTPRouteQuery resolves the route hostname, exposed by the listener of its first parent Gateway. The route
+// sectionName selects the listener, unless the uri selects one with the port query param.
+func (c gatewayServiceCatalog) resolveHTTPRouteQuery(ctx context.Context, uri ResourceUri) (string, error) {
+	route, err := findHTTPRoute(ctx, c.Client, uri.Namespace, uri.Name)
+	if err != nil {
+		return "", err
+	}
+	if len(route.Spec.ParentRefs) == 0 {
+		return "", fmt.Errorf("httproute: %s in namespace: %s, has no parent gateway", uri.Name, uri.Namespace)
+	}
+	parent := route.Spec.ParentRefs[0]
+	gatewayNamespace := uri.Namespace
+	if parent.Namespace != nil {
+		gatewayNamespace = string(*parent.Namespace)
+	}
+	gateway, err := findGateway(ctx, c.Client, gatewayNamespace, string(parent.Name))
+	if err != nil {
+		return "", err
+	}
+	customListener := uri.GetPort()
+	if len(customListener) == 0 && parent.SectionName != nil {
+		customListener = string(*parent.SectionName)
+	}
+	listener := findBestSuitedGatewayListener(gateway, customListener)
+	if listener == nil {
+		return "", fmt.Errorf("gateway: %s in namespace: %s, has no listeners", gateway.Name, gateway.Namespace)
+	}
+	var host string
+	for _, hostname := range route.Spec.Hostnames {
+		if !strings.HasPrefix(string(hostname), "*") {
+			host = string(hostname)
+			break
+		}
+	}
+	return resolveGatewayListenerUri(gateway, listener, host)
+}
+
+// GetHTTPRouteParentGateways returns the uris of the Gateways the addresses of the given http route uris are resolved
+// from, so that they can be tracked with the routes. The routes not found yet are ignored, the workflow is reconciled
+// again when they are created.
+func GetHTTPRouteParentGateways(ctx context.Context, cli client.Client, uris []ResourceUri) ([]ResourceUri, error) {
+	var gateways []ResourceUri
+	for _, uri := range uris {
+		if uri.GVK.Group != gatewayAPIGroup || uri.GVK.Kind != httpRouteKind {
+			continue
+		}
+		route, err := findHTTPRoute(ctx, cli, uri.Namespace, uri.Name)
+		if err != nil {
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if len(route.Spec.ParentRefs) == 0 {
+			continue
+		}
+		parent := route.Spec.ParentRefs[0]
+		gatewayNamespace := uri.Namespace
+		if parent.Namespace != nil {
+			gatewayNamespace = string(*parent.Namespace)
+		}
+		gateways = append(gateways, *NewResourceUriBuilder(KubernetesScheme).
+			Group(gatewayAPIGroup).
+			Version(gatewaysResource.Version).
+			Kind(gatewayKind).
+			Namespace(gatewayNamespace).
+			Name(string(parent.Name)).Build())
+	}
+	return gateways, nil
+}
+
+// resolveGatewayListenerUri returns the uri of the given host exposed by the listener, the listener hostname or the
+// gateway address are used when not set.
+func resolveGatewayListenerUri(gateway *gatewayv1beta1.Gateway, listener *gatewayv1beta1.Listener, host string) (string, error) {
+	if len(host) == 0 && listener.Hostname != nil && !strings.HasPrefix(string(*listener.Hostname), "*") {
+		host = string(*listener.Hostname)
+	}
+	if len(host) == 0 && len(gateway.Status.Addresses) > 0 {
+		host = gateway.Status.Addresses[0].Value
+	}
+	if len(host) == 0 {
+		return "", fmt.Errorf("gateway: %s in namespace: %s, has no allocated address", gateway.Name, gateway.Namespace)
+	}
+	scheme := httpProtocol
+	if isSecureGatewayListener(listener) {
+		scheme = httpsProtocol
+	}
+	return buildURI(scheme, host, int(listener.Port)), nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	istioVirtualServiceKind = "virtualservices"
+	// istioMeshGateway the reserved gateway name of the sidecars of the mesh
+	istioMeshGateway = "mesh"
+)
+
+var istioVirtualServiceGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"}
+
+type istioServiceCatalog struct {
+	Client client.Client
+}
+
+func newIstioServiceCatalog(cli client.Client) istioServiceCatalog {
+	return istioServiceCatalog{
+		Client: cli,
+	}
+}
+
+func (c istioServiceCatalog) Query(ctx context.Context, uri ResourceUri, outputFormat string) (string, error) {
+	switch uri.GVK.Kind {
+	case istioVirtualServiceKind:
+		return c.resolveVirtualServiceQuery(ctx, uri, outputFormat)
+	default:
+		return "", fmt.Errorf("resolution of istio kind: %s is not implemented", uri.GVK.Kind)
+	}
+}
+
+// resolveVirtualServiceQuery resolves the first host of the VirtualService. The hosts of the mesh are the cluster
+// Services they name, resolved like any other Service, while the hosts exposed by the ingress gateways are reached on
+// the https port when the VirtualService routes TLS traffic, and on the http port otherwise.
+func (c istioServiceCatalog) resolveVirtualServiceQuery(ctx context.Context, uri ResourceUri, outputFormat string) (string, error) {
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(istioVirtualServiceGVK)
+	if err := c.Client.Get(ctx, types.NamespacedName{Namespace: uri.Namespace, Name: uri.Name}, virtualService); err != nil {
+		return "", err
+	}
+	hosts, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+	host := ""
+	for _, h := range hosts {
+		if !strings.HasPrefix(h, "*") {
+			host = h
+			break
+		}
+	}
+	if len(host) == 0 {
+		return "", fmt.Errorf("virtualservice: %s in namespace: %s, has no resolvable host", uri.Name, uri.Namespace)
+	}
+
+	gateways, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "gateways")
+	if len(gateways) == 0 || slices.Contains(gateways, istioMeshGateway) {
+		if name, namespace, ok := splitMeshHost(host, uri.Namespace); ok {
+			if service, err := findService(ctx, c.Client, namespace, name); err == nil {
+				return resolveServiceUri(service, uri.GetPort(), outputFormat)
+			}
+		}
+		return buildURI(httpProtocol, host, defaultHttpPort), nil
+	}
+	if tls, _, _ := unstructured.NestedSlice(virtualService.Object, "spec", "tls"); len(tls) > 0 {
+		return buildURI(httpsProtocol, host, defaultHttpsPort), nil
+	}
+	return buildURI(httpProtocol, host, defaultHttpPort), nil
+}
+
+// splitMeshHost returns the name and the namespace of the Service named by a mesh host, like "name", "name.namespace"
+// or "name.namespace.svc.cluster.local".
+func splitMeshHost(host string, defaultNamespace string) (string, string, bool) {
+	host = strings.TrimSuffix(host, ".svc.cluster.local")
+	host = strings.TrimSuffix(host, ".svc")
+	parts := strings.Split(host, ".")
+	switch len(parts) {
+	case 1:
+		return parts[0], defaultNamespace, true
+	case 2:
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
@@ -29,14 +29,20 @@ import (
 	"k8s.io/klog/v2"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	clienteventingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1"
+	clientmessagingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/messaging/v1"
 	clientservingv1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1"
+	clientservingv1beta1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1beta1"
 )
 
 const (
-	knServiceKind = "services"
-	knBrokerKind  = "brokers"
+	knServiceKind         = "services"
+	knBrokerKind          = "brokers"
+	knChannelKind         = "channels"
+	knInMemoryChannelKind = "inmemorychannels"
+	knDomainMappingKind   = "domainmappings"
 )
 
 type knServiceCatalog struct {
@@ -46,6 +52,10 @@ type knServiceCatalog struct {
 type KnDiscoveryClient struct {
 	ServingClient  clientservingv1.ServingV1Interface
 	EventingClient clienteventingv1.EventingV1Interface
+	// MessagingClient resolves the channels, set only when the messaging.knative.dev api is installed.
+	MessagingClient clientmessagingv1.MessagingV1Interface
+	// DomainMappingClient resolves the domain mappings, set only when the serving.knative.dev/v1beta1 api is installed.
+	DomainMappingClient clientservingv1beta1.ServingV1beta1Interface
 }
 
 func newKnServiceCatalog(discoveryClient *KnDiscoveryClient) knServiceCatalog {
@@ -83,7 +93,19 @@ func newKnDiscoveryClientForConfig(cfg *rest.Config) *KnDiscoveryClient {
 			}
 		}
 		if servingClient != nil || eventingClient != nil {
-			return newKnDiscoveryClient(servingClient, eventingClient)
+			discoveryClient := newKnDiscoveryClient(servingClient, eventingClient)
+			// channels and domain mappings are optional, their absence must not prevent the resolution of the other kinds
+			if avail.Eventing {
+				if discoveryClient.MessagingClient, err = knative.GetKnativeMessagingClient(cfg); err != nil {
+					klog.V(log.E).ErrorS(err, "Unable to get the knative messaging client")
+				}
+			}
+			if avail.Serving {
+				if discoveryClient.DomainMappingClient, err = knative.GetKnativeDomainMappingClient(cfg); err != nil {
+					klog.V(log.E).ErrorS(err, "Unable to get the knative domain mapping client")
+				}
+			}
+			return discoveryClient
 		}
 	}
 	return nil
@@ -105,6 +127,10 @@ func (c knServiceCatalog) Query(ctx context.Context, uri ResourceUri, outputForm
 		return c.resolveKnServiceQuery(ctx, uri)
 	case knBrokerKind:
 		return c.resolveKnBrokerQuery(ctx, uri)
+	case knChannelKind, knInMemoryChannelKind:
+		return c.resolveKnChannelQuery(ctx, uri)
+	case knDomainMappingKind:
+		return c.resolveKnDomainMappingQuery(ctx, uri)
 	default:
 		return "", fmt.Errorf("resolution of knative kind: %s is not implemented", uri.GVK.Kind)
 	}
@@ -133,3 +159,44 @@ func (c knServiceCatalog) resolveKnBrokerQuery(ctx context.Context, uri Resource
 		return broker.Status.Address.URL.String(), nil
 	}
 }
+
+func (c knServiceCatalog) resolveKnChannelQuery(ctx context.Context, uri ResourceUri) (string, error) {
+	if c.dc.MessagingClient == nil {
+		return "", fmt.Errorf("knative MessagingClient was not provided, maybe the messaging.knative.dev api is not installed in current cluster")
+	}
+	if uri.GVK.Kind == knInMemoryChannelKind {
+		if channel, err := c.dc.MessagingClient.InMemoryChannels(uri.Namespace).Get(ctx, uri.Name, metav1.GetOptions{}); err != nil {
+			return "", err
+		} else {
+			return addressableURL(channel.Status.Address, uri)
+		}
+	}
+	if channel, err := c.dc.MessagingClient.Channels(uri.Namespace).Get(ctx, uri.Name, metav1.GetOptions{}); err != nil {
+		return "", err
+	} else {
+		return addressableURL(channel.Status.Address, uri)
+	}
+}
+
+func (c knServiceCatalog) resolveKnDomainMappingQuery(ctx context.Context, uri ResourceUri) (string, error) {
+	if c.dc.DomainMappingClient == nil {
+		return "", fmt.Errorf("knative DomainMappingClient was not provided, maybe the serving.knative.dev/v1beta1 api is not installed in current cluster")
+	}
+	if domainMapping, err := c.dc.DomainMappingClient.DomainMappings(uri.Namespace).Get(ctx, uri.Name, metav1.GetOptions{}); err != nil {
+		return "", err
+	} else {
+		// unlike the address, the url of a domain mapping is the custom domain it exposes
+		if domainMapping.Status.URL != nil {
+			return domainMapping.Status.URL.String(), nil
+		}
+		return addressableURL(domainMapping.Status.Address, uri)
+	}
+}
+
+// addressableURL returns the url of an addressable resource, or an error if the resource is not ready yet.
+func addressableURL(address *duckv1.Addressable, uri ResourceUri) (string, error) {
+	if address == nil || address.URL == nil {
+		return "", fmt.Errorf("knative %s: %s in namespace: %s, has no address yet", uri.GVK.Kind, uri.Name, uri.Namespace)
+	}
+	return address.URL.String(), nil
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -64,6 +65,12 @@ func (c k8sServiceCatalog) Query(ctx context.Context, uri ResourceUri, outputFor
 func (c k8sServiceCatalog) resolveServiceQuery(ctx context.Context, uri ResourceUri, outputFormat string) (string, error) {
 	if service, err := findService(ctx, c.Client, uri.Namespace, uri.Name); err != nil {
 		return "", err
+	} else if service.Spec.ClusterIP == corev1.ClusterIPNone && outputFormat == KubernetesIPAddress {
+		if endpointSlices, err := findEndpointSlices(ctx, c.Client, uri.Namespace, uri.Name); err != nil {
+			return "", err
+		} else {
+			return resolveHeadlessServiceUri(service, endpointSlices, uri.GetPort())
+		}
 	} else if serviceUri, err := resolveServiceUri(service, uri.GetPort(), outputFormat); err != nil {
 		return "", err
 	} else {
@@ -22,6 +22,8 @@ package discovery
 import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 func isSecurePort(port int) bool {
@@ -54,6 +56,18 @@ func findBestSuitedServicePort(service *corev1.Service, customPort string) *core
 	return &service.Spec.Ports[0]
 }
 
+// findEndpointSlicePort returns the EndpointSlice port that corresponds to the service port with the given name, or
+// nil if the EndpointSlice does not publish it. EndpointSlice ports are named after the service ports they target.
+func findEndpointSlicePort(endpointSlice *discoveryv1.EndpointSlice, servicePortName string) *discoveryv1.EndpointPort {
+	for i := range endpointSlice.Ports {
+		port := &endpointSlice.Ports[i]
+		if (port.Name == nil && len(servicePortName) == 0) || (port.Name != nil && *port.Name == servicePortName) {
+			return port
+		}
+	}
+	return nil
+}
+
 func isSecureServicePort(servicePort *corev1.ServicePort) bool {
 	return servicePort.Name == httpsProtocol || isSecurePort(int(servicePort.Port))
 }
@@ -91,3 +105,41 @@ func findBestSuitedContainerPort(container *corev1.Container, customPort string)
 func isSecureContainerPort(containerPort *corev1.ContainerPort) bool {
 	return containerPort.Name == httpsProtocol || isSecurePort(int(containerPort.ContainerPort))
 }
+
+// findBestSuitedGatewayListener returns the best suited Listener to connect to a gateway, or nil if the gateway has no listeners.
+// The optional customListener can be used to determine which listener should be used for the communication, when not
+// set, the best suited listener is returned. For this last, a secure listener has precedence over a non-secure listener.
+func findBestSuitedGatewayListener(gateway *gatewayv1beta1.Gateway, customListener string) *gatewayv1beta1.Listener {
+	listeners := gateway.Spec.Listeners
+	if len(listeners) == 0 {
+		return nil
+	}
+	// customListener is provided and configured?
+	if len(customListener) > 0 {
+		if result := findGatewayListener(listeners, func(l *gatewayv1beta1.Listener) bool { return string(l.Name) == customListener }); result != nil {
+			return result
+		}
+	}
+	// has ssl listener?
+	if result := findGatewayListener(listeners, isSecureGatewayListener); result != nil {
+		return result
+	}
+	// has http listener?
+	if result := findGatewayListener(listeners, func(l *gatewayv1beta1.Listener) bool { return l.Protocol == gatewayv1beta1.HTTPProtocolType }); result != nil {
+		return result
+	}
+	return &listeners[0]
+}
+
+func findGatewayListener(listeners []gatewayv1beta1.Listener, matches func(listener *gatewayv1beta1.Listener) bool) *gatewayv1beta1.Listener {
+	for i := range listeners {
+		if matches(&listeners[i]) {
+			return &listeners[i]
+		}
+	}
+	return nil
+}
+
+func isSecureGatewayListener(listener *gatewayv1beta1.Listener) bool {
+	return listener.Protocol == gatewayv1beta1.HTTPSProtocolType || listener.Protocol == gatewayv1beta1.TLSProtocolType || isSecurePort(int(listener.Port))
+}
@@ -24,9 +24,11 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingV1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // findService finds a service by name in the given namespace.
@@ -38,6 +40,15 @@ func findService(ctx context.Context, cli client.Client, namespace string, name
 	return service, nil
 }
 
+// findEndpointSlices finds the EndpointSlices that publish the endpoints of a service.
+func findEndpointSlices(ctx context.Context, cli client.Client, namespace string, serviceName string) (*discoveryv1.EndpointSliceList, error) {
+	endpointSliceList := &discoveryv1.EndpointSliceList{}
+	if err := cli.List(ctx, endpointSliceList, client.InNamespace(namespace), client.MatchingLabels{discoveryv1.LabelServiceName: serviceName}); err != nil {
+		return nil, err
+	}
+	return endpointSliceList, nil
+}
+
 // findServicesBySelectorTarget finds the services for which all the configured selector labels are present in the
 // selection target map.
 func findServicesBySelectorTarget(ctx context.Context, cli client.Client, namespace string, selectorTarget map[string]string) (*corev1.ServiceList, error) {
@@ -152,3 +163,21 @@ func findIngress(ctx context.Context, cli client.Client, namespace string, name
 	}
 	return ingress, nil
 }
+
+// findGateway finds a Gateway API gateway by name in the given namespace.
+func findGateway(ctx context.Context, cli client.Client, namespace string, name string) (*gatewayv1beta1.Gateway, error) {
+	gateway := &gatewayv1beta1.Gateway{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, gateway); err != nil {
+		return nil, err
+	}
+	return gateway, nil
+}
+
+// findHTTPRoute finds a Gateway API http route by name in the given namespace.
+func findHTTPRoute(ctx context.Context, cli client.Client, namespace string, name string) (*gatewayv1beta1.HTTPRoute, error) {
+	route := &gatewayv1beta1.HTTPRoute{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
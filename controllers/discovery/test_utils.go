@@ -56,8 +56,15 @@ const (
 	replicaSet2Name  = "replicaSet2Name"
 	replicaSet3Name  = "replicaSet3Name"
 
-	knServiceName1 = "knServiceName1"
-	knBrokerName1  = "knBrokerName1"
+	knServiceName1       = "knServiceName1"
+	knBrokerName1        = "knBrokerName1"
+	knChannelName1       = "knChannelName1"
+	knDomainMappingName1 = "knDomainMappingName1"
+
+	gateway1Name   = "gateway1Name"
+	httpRoute1Name = "httpRoute1Name"
+
+	virtualService1Name = "virtualService1Name"
 
 	openShiftRouteName1 = "openShiftRouteName1"
 	openShiftRouteHost1 = "openshiftroutehost1"
@@ -30,10 +30,12 @@ import (
 const (
 	// valid namespace, name, or label name.
 	dns1123LabelFmt string = "[a-z0-9]([-a-z0-9]*[a-z0-9])?"
-	queryParamName         = "[a-zA-Z0-9][-a-zAz0-9]*"
-	queryParamValue        = "[/a-zA-Z0-9][/-a-zAz0-9]*"
+	// valid resource name, a sequence of labels separated by dots, like the knative domain mappings named after their domain.
+	dns1123SubdomainFmt string = dns1123LabelFmt + "(\\." + dns1123LabelFmt + ")*"
+	queryParamName             = "[a-zA-Z0-9][-a-zAz0-9]*"
+	queryParamValue            = "[/a-zA-Z0-9][/-a-zAz0-9]*"
 
-	namespaceAndNamePattern = "^/((" + dns1123LabelFmt + ")+)(/(" + dns1123LabelFmt + ")+)?"
+	namespaceAndNamePattern = "^/((" + dns1123SubdomainFmt + ")+)(/(" + dns1123SubdomainFmt + ")+)?"
 	queryStringPattern      = "^(\\?((" + queryParamName + ")+\\=(" + queryParamValue + ")+)" +
 		"(&(" + queryParamName + ")+\\=(" + queryParamValue + ")+)*)?$"
 
@@ -41,20 +43,29 @@ const (
 		"|" + kubernetesPods +
 		"|" + kubernetesDeployments +
 		"|" + kubernetesStatefulSets +
-		"|" + kubernetesIngresses + ")"
+		"|" + kubernetesIngresses +
+		"|" + kubernetesGateways +
+		"|" + kubernetesHTTPRoutes + ")"
 
-	knativeGroupsPattern = "^(" + knativeServices + "|" + knativeBrokers + ")"
+	knativeGroupsPattern = "^(" + knativeServices +
+		"|" + knativeBrokers +
+		"|" + knativeChannels +
+		"|" + knativeInMemoryChannels +
+		"|" + knativeDomainMappings + ")"
 
 	knativeSimplifiedServicePatten = "knative:" + "(" + dns1123LabelFmt + ")" + "(/(" + dns1123LabelFmt + ")+)?"
 
 	openshiftGroupsPattern = "^(" + openshiftDeploymentConfigs +
 		"|" + openshiftRoutes + ")"
+
+	istioGroupsPattern = "^(" + istioVirtualServices + ")"
 )
 
 var kubernetesGroupsExpr = regexp.MustCompile(kubernetesGroupsPattern)
 var knativeGroupsExpr = regexp.MustCompile(knativeGroupsPattern)
 var knativeSimplifiedServiceExpr = regexp.MustCompile(knativeSimplifiedServicePatten)
 var openshiftGroupsExpr = regexp.MustCompile(openshiftGroupsPattern)
+var istioGroupsExpr = regexp.MustCompile(istioGroupsPattern)
 var namespaceAndNameExpr = regexp.MustCompile(namespaceAndNamePattern)
 var queryStringExpr = regexp.MustCompile(queryStringPattern)
 
@@ -67,8 +78,10 @@ func ParseUri(uri string) (*ResourceUri, error) {
 		return parseKnativeSimplifiedServiceUri(uri)
 	} else if split := openshiftGroupsExpr.Split(uri, -1); len(split) == 2 {
 		return parseOpenshiftUri(uri, openshiftGroupsExpr.FindString(uri), split[1])
+	} else if split := istioGroupsExpr.Split(uri, -1); len(split) == 2 {
+		return parseIstioUri(uri, istioGroupsExpr.FindString(uri), split[1])
 	}
-	return nil, fmt.Errorf("invalid uri: %s, not correspond to any of the available schemes format: %s, %s, %s", uri, KubernetesScheme, KnativeScheme, OpenshiftScheme)
+	return nil, fmt.Errorf("invalid uri: %s, not correspond to any of the available schemes format: %s, %s, %s, %s", uri, KubernetesScheme, KnativeScheme, OpenshiftScheme, IstioScheme)
 }
 
 func parseKubernetesUri(uri string, schemaAndGroup string, after string) (*ResourceUri, error) {
@@ -156,6 +169,18 @@ func parseGVK(schemaGvk string) (*v1.GroupVersionKind, error) {
 			Version: "v1",
 			Kind:    "ingresses",
 		}, nil
+	case kubernetesGateways:
+		return &v1.GroupVersionKind{
+			Group:   gatewayAPIGroup,
+			Version: "v1beta1",
+			Kind:    "gateways",
+		}, nil
+	case kubernetesHTTPRoutes:
+		return &v1.GroupVersionKind{
+			Group:   gatewayAPIGroup,
+			Version: "v1beta1",
+			Kind:    "httproutes",
+		}, nil
 	case knativeServices:
 		return &v1.GroupVersionKind{
 			Group:   "serving.knative.dev",
@@ -168,6 +193,24 @@ func parseGVK(schemaGvk string) (*v1.GroupVersionKind, error) {
 			Version: "v1",
 			Kind:    "brokers",
 		}, nil
+	case knativeChannels:
+		return &v1.GroupVersionKind{
+			Group:   "messaging.knative.dev",
+			Version: "v1",
+			Kind:    "channels",
+		}, nil
+	case knativeInMemoryChannels:
+		return &v1.GroupVersionKind{
+			Group:   "messaging.knative.dev",
+			Version: "v1",
+			Kind:    "inmemorychannels",
+		}, nil
+	case knativeDomainMappings:
+		return &v1.GroupVersionKind{
+			Group:   "serving.knative.dev",
+			Version: "v1beta1",
+			Kind:    "domainmappings",
+		}, nil
 	case openshiftRoutes:
 		return &v1.GroupVersionKind{
 			Group:   "route.openshift.io",
@@ -180,6 +223,12 @@ func parseGVK(schemaGvk string) (*v1.GroupVersionKind, error) {
 			Version: "v1",
 			Kind:    "deploymentconfigs",
 		}, nil
+	case istioVirtualServices:
+		return &v1.GroupVersionKind{
+			Group:   "networking.istio.io",
+			Version: "v1beta1",
+			Kind:    "virtualservices",
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown schema and gvk: %s", schemaGvk)
 	}
@@ -240,3 +289,17 @@ func parseOpenshiftUri(uri string, schemaAndGroup string, after string) (*Resour
 		}, nil
 	}
 }
+
+func parseIstioUri(uri string, schemaAndGroup string, after string) (*ResourceUri, error) {
+	if namespace, name, gvk, queryParams, err := parseNamespaceNameGVKAndQueryParams(uri, schemaAndGroup, after); err != nil {
+		return nil, err
+	} else {
+		return &ResourceUri{
+			Scheme:      IstioScheme,
+			GVK:         *gvk,
+			Namespace:   namespace,
+			Name:        name,
+			QueryParams: queryParams,
+		}, nil
+	}
+}
@@ -270,6 +270,73 @@ var OpenshiftDeploymentConfigsTestValues = map[string]*ResourceUri{
 		Build(),
 }
 
+var KubernetesGatewayAPITestValues = map[string]*ResourceUri{
+	"kubernetes:gateways.v1beta1.gateway.networking.k8s.io": nil,
+
+	"kubernetes:gateways.v1beta1.gateway.networking.k8s.io/my-namespace/my-gateway?port=https": NewResourceUriBuilder(KubernetesScheme).
+		Kind("gateways").
+		Group("gateway.networking.k8s.io").
+		Version("v1beta1").
+		Namespace("my-namespace").
+		Name("my-gateway").
+		WithPort("https").
+		Build(),
+
+	"kubernetes:httproutes.v1beta1.gateway.networking.k8s.io/my-route": NewResourceUriBuilder(KubernetesScheme).
+		Kind("httproutes").
+		Group("gateway.networking.k8s.io").
+		Version("v1beta1").
+		Name("my-route").
+		Build(),
+}
+
+var KnativeMessagingAndDomainMappingTestValues = map[string]*ResourceUri{
+	"knative:channels.v1.messaging.knative.dev/my-namespace/my-channel": NewResourceUriBuilder(KnativeScheme).
+		Kind("channels").
+		Group("messaging.knative.dev").
+		Version("v1").
+		Namespace("my-namespace").
+		Name("my-channel").
+		Build(),
+
+	"knative:inmemorychannels.v1.messaging.knative.dev/my-channel": NewResourceUriBuilder(KnativeScheme).
+		Kind("inmemorychannels").
+		Group("messaging.knative.dev").
+		Version("v1").
+		Name("my-channel").
+		Build(),
+
+	"knative:domainmappings.v1beta1.serving.knative.dev/my-namespace/orders.example.com": NewResourceUriBuilder(KnativeScheme).
+		Kind("domainmappings").
+		Group("serving.knative.dev").
+		Version("v1beta1").
+		Namespace("my-namespace").
+		Name("orders.example.com").
+		Build(),
+}
+
+var IstioVirtualServicesTestValues = map[string]*ResourceUri{
+	"istio:virtualservices.v1beta1.networking.istio.io": nil,
+
+	"istio:virtualservices.v1beta1.networking.istio.io/": nil,
+
+	"istio:virtualservices.v1beta1.networking.istio.io/my-virtual-service": NewResourceUriBuilder(IstioScheme).
+		Kind("virtualservices").
+		Group("networking.istio.io").
+		Version("v1beta1").
+		Name("my-virtual-service").
+		Build(),
+
+	"istio:virtualservices.v1beta1.networking.istio.io/my-namespace/my-virtual-service?port=http": NewResourceUriBuilder(IstioScheme).
+		Kind("virtualservices").
+		Group("networking.istio.io").
+		Version("v1beta1").
+		Namespace("my-namespace").
+		Name("my-virtual-service").
+		WithPort("http").
+		Build(),
+}
+
 func TestParseKubernetesServicesURI(t *testing.T) {
 	for k, v := range KubernetesServicesTestValues {
 		doTestParseURI(t, k, v)
@@ -300,6 +367,24 @@ func TestParseOpenshiftDeploymentConfigsURI(t *testing.T) {
 	}
 }
 
+func TestParseKubernetesGatewayAPIURI(t *testing.T) {
+	for k, v := range KubernetesGatewayAPITestValues {
+		doTestParseURI(t, k, v)
+	}
+}
+
+func TestParseKnativeMessagingAndDomainMappingURI(t *testing.T) {
+	for k, v := range KnativeMessagingAndDomainMappingTestValues {
+		doTestParseURI(t, k, v)
+	}
+}
+
+func TestParseIstioVirtualServicesURI(t *testing.T) {
+	for k, v := range IstioVirtualServicesTestValues {
+		doTestParseURI(t, k, v)
+	}
+}
+
 func doTestParseURI(t *testing.T, url string, expectedUri *ResourceUri) {
 	result, err := ParseUri(url)
 	if expectedUri == nil {
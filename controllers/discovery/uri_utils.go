@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 )
 
 func resolveServiceUri(service *corev1.Service, customPort string, outputFormat string) (string, error) {
@@ -37,9 +38,7 @@ func resolveServiceUri(service *corev1.Service, customPort string, outputFormat
 	case corev1.ServiceTypeExternalName:
 		// ExternalName may not work properly with SSL:
 		// https://kubernetes.io/docs/concepts/services-networking/service/#externalname
-		protocol = httpProtocol
-		host = service.Spec.ExternalName
-		port = 80
+		protocol, host, port = resolveExternalNameServiceUriParams(service, customPort)
 	case corev1.ServiceTypeClusterIP:
 		protocol, host, port = resolveClusterIPOrTypeNodeServiceUriParams(service, customPort)
 	case corev1.ServiceTypeNodePort:
@@ -74,6 +73,59 @@ func resolveClusterIPOrTypeNodeServiceUriParams(service *corev1.Service, customP
 	return protocol, host, port
 }
 
+// resolveExternalNameServiceUriParams returns the uri parameters for a service of type ExternalName. The ports of the
+// service, when declared, are selected like for any other service, otherwise, the plain http port is assumed.
+func resolveExternalNameServiceUriParams(service *corev1.Service, customPort string) (protocol string, host string, port int) {
+	host = service.Spec.ExternalName
+	if len(service.Spec.Ports) == 0 {
+		return httpProtocol, host, defaultHttpPort
+	}
+	servicePort := findBestSuitedServicePort(service, customPort)
+	if isSecureServicePort(servicePort) {
+		protocol = httpsProtocol
+	} else {
+		protocol = httpProtocol
+	}
+	return protocol, host, int(servicePort.Port)
+}
+
+// resolveHeadlessServiceUri returns the uri of the first ready endpoint of a headless service. Headless services have
+// no cluster ip, the addresses of the pods behind them are instead published by the EndpointSlices.
+func resolveHeadlessServiceUri(service *corev1.Service, endpointSlices *discoveryv1.EndpointSliceList, customPort string) (string, error) {
+	if len(service.Spec.Ports) == 0 {
+		return "", fmt.Errorf("headless service: %s in namespace: %s, has no ports", service.Name, service.Namespace)
+	}
+	servicePort := findBestSuitedServicePort(service, customPort)
+	protocol := httpProtocol
+	if isSecureServicePort(servicePort) {
+		protocol = httpsProtocol
+	}
+	for _, endpointSlice := range endpointSlices.Items {
+		if endpointSlice.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+		endpointPort := findEndpointSlicePort(&endpointSlice, servicePort.Name)
+		if endpointPort == nil || endpointPort.Port == nil {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			if len(endpoint.Addresses) > 0 && isReadyEndpoint(&endpoint) {
+				host := endpoint.Addresses[0]
+				if endpointSlice.AddressType == discoveryv1.AddressTypeIPv6 {
+					host = "[" + host + "]"
+				}
+				return buildURI(protocol, host, int(*endpointPort.Port)), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("headless service: %s in namespace: %s, has no ready endpoints", service.Name, service.Namespace)
+}
+
+// isReadyEndpoint returns true if the endpoint can receive traffic, a nil condition must be interpreted as ready.
+func isReadyEndpoint(endpoint *discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
 func resolvePodUri(pod *corev1.Pod, customContainer string, customPort string, outputFormat string) (string, error) {
 	if podIp := pod.Status.PodIP; len(podIp) == 0 {
 		return "", fmt.Errorf("pod: %s in namespace: %s, has no allocated address", pod.Name, pod.Namespace)
@@ -60,6 +60,20 @@ func Test_resolveServiceUriExternalNameServiceIPAddressMode(t *testing.T) {
 	doTestResolveServiceUri(t, service, corev1.ServiceTypeExternalName, KubernetesIPAddress, "http://external.service.com:80")
 }
 
+func Test_resolveServiceUriExternalNameServiceSecurePort(t *testing.T) {
+	service := mockServiceWithPorts(namespace1, service1Name,
+		mockServicePort(httpProtocol, tcp, 8080),
+		mockServicePort(httpsProtocol, tcp, 8443))
+	service.Spec.ExternalName = "external.service.com"
+	doTestResolveServiceUri(t, service, corev1.ServiceTypeExternalName, KubernetesDNSAddress, "https://external.service.com:8443")
+}
+
+func Test_resolveServiceUriExternalNameServiceWithoutPorts(t *testing.T) {
+	service := mockServiceWithPorts(namespace1, service1Name)
+	service.Spec.ExternalName = "external.service.com"
+	doTestResolveServiceUri(t, service, corev1.ServiceTypeExternalName, KubernetesDNSAddress, "http://external.service.com:80")
+}
+
 func doTestResolveServiceUri(t *testing.T, service *corev1.Service, serviceType corev1.ServiceType, outputMode string, expectedUri string) {
 	service.Spec.Type = serviceType
 	result, err := resolveServiceUri(service, "", outputMode)
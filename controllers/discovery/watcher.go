@@ -0,0 +1,232 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+var servicesResource = schema.GroupVersionResource{Version: "v1", Resource: serviceKind}
+var gatewaysResource = schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1beta1", Resource: gatewayKind}
+
+// dependency is a resource a workflow address was resolved from, every resource of the namespace when the name is empty.
+type dependency struct {
+	resource  schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+type informerKey struct {
+	resource  schema.GroupVersionResource
+	namespace string
+}
+
+// ResourceWatcher watches the resources resolved by the service discovery of the workflows, or referenced by their
+// properties, and notifies the workflows to reconcile when one of them changes, so the managed properties never keep a
+// stale value.
+// The informers are started on demand, only for the kinds and the namespaces the workflows depend on.
+type ResourceWatcher struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+	events chan event.GenericEvent
+
+	lock         sync.Mutex
+	ctx          context.Context
+	dependencies map[types.NamespacedName][]dependency
+	informers    map[informerKey]cache.SharedIndexInformer
+}
+
+// NewResourceWatcher creates a ResourceWatcher using the given mapper to skip the kinds not installed in the cluster.
+func NewResourceWatcher(client dynamic.Interface, mapper meta.RESTMapper) *ResourceWatcher {
+	return &ResourceWatcher{
+		client:       client,
+		mapper:       mapper,
+		events:       make(chan event.GenericEvent),
+		dependencies: map[types.NamespacedName][]dependency{},
+		informers:    map[informerKey]cache.SharedIndexInformer{},
+	}
+}
+
+// NewResourceWatcherForConfig creates a ResourceWatcher for the given cluster config.
+func NewResourceWatcherForConfig(cfg *rest.Config, mapper meta.RESTMapper) (*ResourceWatcher, error) {
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewResourceWatcher(client, mapper), nil
+}
+
+// Events returns the channel receiving the workflows to reconcile.
+func (w *ResourceWatcher) Events() <-chan event.GenericEvent {
+	return w.events
+}
+
+// Start runs the informers until the context is done, it implements the manager.Runnable interface.
+func (w *ResourceWatcher) Start(ctx context.Context) error {
+	w.lock.Lock()
+	w.ctx = ctx
+	for _, informer := range w.informers {
+		go informer.Run(ctx.Done())
+	}
+	w.lock.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+// Track records the resources the addresses of the given workflow are resolved from, replacing the previous ones.
+func (w *ResourceWatcher) Track(workflow types.NamespacedName, uris []ResourceUri) {
+	var dependencies []dependency
+	for _, uri := range uris {
+		dependencies = append(dependencies, uriDependencies(uri)...)
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(dependencies) == 0 {
+		delete(w.dependencies, workflow)
+		return
+	}
+	w.dependencies[workflow] = dependencies
+	for _, d := range dependencies {
+		w.ensureInformer(informerKey{resource: d.resource, namespace: d.namespace})
+	}
+}
+
+// Untrack forgets the resources of the given workflow, the informers keep running for the next workflows.
+func (w *ResourceWatcher) Untrack(workflow types.NamespacedName) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.dependencies, workflow)
+}
+
+// ensureInformer starts the informer of the given resources unless it's already running. Must hold the lock.
+func (w *ResourceWatcher) ensureInformer(key informerKey) {
+	if _, ok := w.informers[key]; ok {
+		return
+	}
+	if _, err := w.mapper.KindFor(key.resource); err != nil {
+		klog.V(log.D).InfoS("Skipping the watch of the resources not installed in the cluster", "resource", key.resource.String(), "error", err)
+		return
+	}
+	informer := dynamicinformer.NewFilteredDynamicInformer(w.client, key.resource, key.namespace, 0, cache.Indexers{}, nil).Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.notify(key.resource, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if hasAddressChanged(key.resource, oldObj, newObj) {
+				w.notify(key.resource, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			w.notify(key.resource, obj)
+		},
+	})
+	if err != nil {
+		klog.V(log.E).ErrorS(err, "Failed to watch the discovered resources", "resource", key.resource.String(), "namespace", key.namespace)
+		return
+	}
+	w.informers[key] = informer
+	if w.ctx != nil {
+		go informer.Run(w.ctx.Done())
+	}
+	klog.V(log.I).InfoS("Watching the discovered resources", "resource", key.resource.String(), "namespace", key.namespace)
+}
+
+// notify sends the workflows depending on the given object to reconcile.
+func (w *ResourceWatcher) notify(resource schema.GroupVersionResource, obj interface{}) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	for _, workflow := range w.dependents(resource, object.GetNamespace(), object.GetName()) {
+		klog.V(log.D).InfoS("Discovered resource changed, reconciling the workflow", "resource", resource.String(), "name", object.GetName(), "workflow", workflow.Name)
+		w.events <- event.GenericEvent{Object: &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: workflow.Namespace, Name: workflow.Name}}}
+	}
+}
+
+func (w *ResourceWatcher) dependents(resource schema.GroupVersionResource, namespace, name string) []types.NamespacedName {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var workflows []types.NamespacedName
+	for workflow, dependencies := range w.dependencies {
+		for _, d := range dependencies {
+			if d.resource == resource && d.namespace == namespace && (len(d.name) == 0 || d.name == name) {
+				workflows = append(workflows, workflow)
+				break
+			}
+		}
+	}
+	return workflows
+}
+
+// uriDependencies returns the resources the address of the given uri is resolved from. The workloads are reached
+// through the Services selecting them, so every Service of their namespace is a dependency too. The parent Gateways of
+// the http routes are read from the routes, see GetHTTPRouteParentGateways.
+func uriDependencies(uri ResourceUri) []dependency {
+	resource := schema.GroupVersionResource{Group: uri.GVK.Group, Version: uri.GVK.Version, Resource: uri.GVK.Kind}
+	dependencies := []dependency{{resource: resource, namespace: uri.Namespace, name: uri.Name}}
+	switch uri.GVK.Kind {
+	case podKind, deploymentKind, statefulSetKind, openShiftDeploymentConfigs:
+		dependencies = append(dependencies, dependency{resource: servicesResource, namespace: uri.Namespace})
+	case istioVirtualServiceKind:
+		// the mesh hosts are resolved from the Services they name
+		dependencies = append(dependencies, dependency{resource: servicesResource, namespace: uri.Namespace})
+	}
+	return dependencies
+}
+
+// hasAddressChanged filters out the updates unable to change a resolved address, like the status of the workloads
+// whose address is the one of their Services.
+func hasAddressChanged(resource schema.GroupVersionResource, oldObj, newObj interface{}) bool {
+	oldObject, err := meta.Accessor(oldObj)
+	if err != nil {
+		return true
+	}
+	newObject, err := meta.Accessor(newObj)
+	if err != nil {
+		return true
+	}
+	if oldObject.GetResourceVersion() == newObject.GetResourceVersion() {
+		return false
+	}
+	switch resource.Resource {
+	case deploymentKind, statefulSetKind, openShiftDeploymentConfigs:
+		return oldObject.GetGeneration() != newObject.GetGeneration()
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestResourceWatcher(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{servicesResource: "ServiceList"})
+	watcher := NewResourceWatcher(client, mapper)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go func() {
+		_ = watcher.Start(ctx)
+	}()
+
+	serviceWorkflow := types.NamespacedName{Namespace: namespace1, Name: "service-workflow"}
+	deploymentWorkflow := types.NamespacedName{Namespace: namespace1, Name: "deployment-workflow"}
+	otherWorkflow := types.NamespacedName{Namespace: namespace1, Name: "other-workflow"}
+	watcher.Track(serviceWorkflow, []ResourceUri{*NewResourceUriBuilder(KubernetesScheme).Version("v1").Kind(serviceKind).Namespace(namespace1).Name(service1Name).Build()})
+	// the deployments aren't served by the mapper, only the Services selecting them are watched
+	watcher.Track(deploymentWorkflow, []ResourceUri{*NewResourceUriBuilder(KubernetesScheme).Group("apps").Version("v1").Kind(deploymentKind).Namespace(namespace1).Name("my-deployment").Build()})
+	watcher.Track(otherWorkflow, []ResourceUri{*NewResourceUriBuilder(KubernetesScheme).Version("v1").Kind(serviceKind).Namespace(namespace1).Name("other-service").Build()})
+	assert.Len(t, watcher.informers, 1)
+
+	service := &unstructured.Unstructured{}
+	service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+	service.SetNamespace(namespace1)
+	service.SetName(service1Name)
+	_, err := client.Resource(servicesResource).Namespace(namespace1).Create(ctx, service, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []types.NamespacedName{serviceWorkflow, deploymentWorkflow}, receiveWorkflows(watcher, 2))
+
+	// the workflows no longer depending on the Service aren't notified
+	watcher.Untrack(deploymentWorkflow)
+	assert.NoError(t, client.Resource(servicesResource).Namespace(namespace1).Delete(ctx, service1Name, metav1.DeleteOptions{}))
+	assert.Equal(t, []types.NamespacedName{serviceWorkflow}, receiveWorkflows(watcher, 2))
+}
+
+// receiveWorkflows returns the notified workflows, up to the given count, waiting a bit for each of them.
+func receiveWorkflows(watcher *ResourceWatcher, count int) []types.NamespacedName {
+	var workflows []types.NamespacedName
+	for i := 0; i < count; i++ {
+		select {
+		case e := <-watcher.Events():
+			workflows = append(workflows, types.NamespacedName{Namespace: e.Object.GetNamespace(), Name: e.Object.GetName()})
+		case <-time.After(time.Second):
+			return workflows
+		}
+	}
+	return workflows
+}
@@ -23,11 +23,15 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	clienteventingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1"
+	clientmessagingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/messaging/v1"
 	clientservingv1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1"
+	clientservingv1beta1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1beta1"
 )
 
 var servingClient clientservingv1.ServingV1Interface
 var eventingClient clienteventingv1.EventingV1Interface
+var messagingClient clientmessagingv1.MessagingV1Interface
+var domainMappingClient clientservingv1beta1.ServingV1beta1Interface
 
 type Availability struct {
 	Eventing bool
@@ -56,6 +60,29 @@ func GetKnativeEventingClient(cfg *rest.Config) (clienteventingv1.EventingV1Inte
 	return eventingClient, nil
 }
 
+func GetKnativeMessagingClient(cfg *rest.Config) (clientmessagingv1.MessagingV1Interface, error) {
+	if messagingClient == nil {
+		if knMessagingClient, err := NewKnativeMessagingClient(cfg); err != nil {
+			return nil, err
+		} else {
+			messagingClient = knMessagingClient
+		}
+	}
+	return messagingClient, nil
+}
+
+// GetKnativeDomainMappingClient returns the serving v1beta1 client, the api version of the DomainMappings.
+func GetKnativeDomainMappingClient(cfg *rest.Config) (clientservingv1beta1.ServingV1beta1Interface, error) {
+	if domainMappingClient == nil {
+		if knDomainMappingClient, err := NewKnativeDomainMappingClient(cfg); err != nil {
+			return nil, err
+		} else {
+			domainMappingClient = knDomainMappingClient
+		}
+	}
+	return domainMappingClient, nil
+}
+
 func NewKnativeServingClient(cfg *rest.Config) (*clientservingv1.ServingV1Client, error) {
 	return clientservingv1.NewForConfig(cfg)
 }
@@ -64,6 +91,14 @@ func NewKnativeEventingClient(cfg *rest.Config) (*clienteventingv1.EventingV1Cli
 	return clienteventingv1.NewForConfig(cfg)
 }
 
+func NewKnativeMessagingClient(cfg *rest.Config) (*clientmessagingv1.MessagingV1Client, error) {
+	return clientmessagingv1.NewForConfig(cfg)
+}
+
+func NewKnativeDomainMappingClient(cfg *rest.Config) (*clientservingv1beta1.ServingV1beta1Client, error) {
+	return clientservingv1beta1.NewForConfig(cfg)
+}
+
 func GetKnativeAvailability(cfg *rest.Config) (*Availability, error) {
 	if cli, err := discovery.NewDiscoveryClientForConfig(cfg); err != nil {
 		return nil, err
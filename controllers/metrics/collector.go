@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	kubeutil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
+)
+
+var (
+	workflowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "workflows"),
+		"Number of workflows by profile and by status and reason of their top level condition.",
+		[]string{"namespace", "profile", "status", "reason"}, nil)
+
+	platformReadyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "platform", "ready"),
+		"Whether the platform is ready (1) or not (0).",
+		[]string{"namespace", "platform"}, nil)
+
+	platformServiceAvailableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "platform", "service_available"),
+		"Whether the deployment of a service enabled in the platform, like the Data Index or the Jobs Service, is available (1) or not (0).",
+		[]string{"namespace", "platform", "service"}, nil)
+)
+
+type workflowKey struct {
+	namespace string
+	profile   string
+	status    string
+	reason    string
+}
+
+// stateCollector reports the current state of the workflows and platforms, read from the manager cache on every
+// scrape so that the series of deleted objects disappear with them.
+type stateCollector struct {
+	client client.Reader
+}
+
+// RegisterStateCollector registers the collector of the workflows and platforms state, read with the given client.
+func RegisterStateCollector(c client.Reader) error {
+	return metrics.Registry.Register(&stateCollector{client: c})
+}
+
+func (s *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workflowsDesc
+	ch <- platformReadyDesc
+	ch <- platformServiceAvailableDesc
+}
+
+func (s *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	s.collectWorkflows(ctx, ch)
+	s.collectPlatforms(ctx, ch)
+}
+
+func (s *stateCollector) collectWorkflows(ctx context.Context, ch chan<- prometheus.Metric) {
+	workflows := &operatorapi.SonataFlowList{}
+	if err := s.client.List(ctx, workflows); err != nil {
+		klog.V(log.E).ErrorS(err, "Failed to list the workflows for the metrics")
+		return
+	}
+	counts := make(map[workflowKey]float64)
+	for _, workflow := range workflows.Items {
+		cond := workflow.Status.GetTopLevelCondition()
+		counts[workflowKey{
+			namespace: workflow.Namespace,
+			profile:   metadata.GetProfileOrDefault(workflow.Annotations).String(),
+			status:    string(cond.Status),
+			reason:    cond.Reason,
+		}]++
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(workflowsDesc, prometheus.GaugeValue, count, key.namespace, key.profile, key.status, key.reason)
+	}
+}
+
+func (s *stateCollector) collectPlatforms(ctx context.Context, ch chan<- prometheus.Metric) {
+	platforms := &operatorapi.SonataFlowPlatformList{}
+	if err := s.client.List(ctx, platforms); err != nil {
+		klog.V(log.E).ErrorS(err, "Failed to list the platforms for the metrics")
+		return
+	}
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		ch <- prometheus.MustNewConstMetric(platformReadyDesc, prometheus.GaugeValue, boolToFloat(platform.Status.IsReady()),
+			platform.Namespace, platform.Name)
+		for _, handler := range []services.PlatformServiceHandler{services.NewDataIndexHandler(platform), services.NewJobServiceHandler(platform)} {
+			// services shared by a cluster platform are reported by the platform deploying them
+			if !handler.IsServiceEnabledInSpec() {
+				continue
+			}
+			deployment := &appsv1.Deployment{}
+			available := false
+			if err := s.client.Get(ctx, types.NamespacedName{Namespace: platform.Namespace, Name: handler.GetServiceName()}, deployment); err == nil {
+				available = kubeutil.IsDeploymentAvailable(deployment)
+			}
+			ch <- prometheus.MustNewConstMetric(platformServiceAvailableDesc, prometheus.GaugeValue, boolToFloat(available),
+				platform.Namespace, platform.Name, handler.GetContainerName())
+		}
+	}
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
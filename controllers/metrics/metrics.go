@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package metrics holds the Prometheus metrics of the operator, served with the controller-runtime ones on the
+// manager metrics endpoint.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+)
+
+const namespace = "sonataflow"
+
+var (
+	buildDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "build_duration_seconds",
+		Help:      "Duration of the workflow builds, from their scheduling to their completion.",
+		Buckets:   []float64{30, 60, 120, 180, 300, 450, 600, 900, 1200, 1800, 3600},
+	}, []string{"strategy", "phase"})
+
+	buildsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "builds_total",
+		Help:      "Number of finished workflow builds by build strategy and final phase.",
+	}, []string{"strategy", "phase"})
+
+	recoveryAttemptsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "workflow_recovery_attempts_total",
+		Help:      "Number of rollouts attempted to recover the deployment of a failing workflow.",
+	}, []string{"namespace", "workflow"})
+
+	discoveryFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "discovery_resolution_failures_total",
+		Help:      "Number of service discovery URIs of a workflow that couldn't be resolved into an address.",
+	}, []string{"namespace", "workflow"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(buildDuration, buildsTotal, recoveryAttemptsTotal, discoveryFailuresTotal)
+}
+
+// ObserveBuildCompletion records the outcome and the duration of a finished build run with the given strategy.
+func ObserveBuildCompletion(build *operatorapi.SonataFlowBuild, strategy operatorapi.BuildStrategy) {
+	if !build.Status.IsFinished() {
+		return
+	}
+	phase := string(build.Status.BuildPhase)
+	buildsTotal.WithLabelValues(string(strategy), phase).Inc()
+	if build.Status.StartTime != nil && build.Status.CompletionTime != nil {
+		buildDuration.WithLabelValues(string(strategy), phase).
+			Observe(build.Status.CompletionTime.Sub(build.Status.StartTime.Time).Seconds())
+	}
+}
+
+// IncRecoveryAttempts records a new attempt to recover the deployment of the given workflow.
+func IncRecoveryAttempts(workflow *operatorapi.SonataFlow) {
+	recoveryAttemptsTotal.WithLabelValues(workflow.Namespace, workflow.Name).Inc()
+}
+
+// IncDiscoveryFailures records a service discovery URI of the given workflow that couldn't be resolved.
+func IncDiscoveryFailures(workflow *operatorapi.SonataFlow) {
+	discoveryFailuresTotal.WithLabelValues(workflow.Namespace, workflow.Name).Inc()
+}
+
+// DeleteWorkflowMetrics drops the series of a deleted workflow, so they don't outlive it.
+func DeleteWorkflowMetrics(workflow *operatorapi.SonataFlow) {
+	recoveryAttemptsTotal.DeleteLabelValues(workflow.Namespace, workflow.Name)
+	discoveryFailuresTotal.DeleteLabelValues(workflow.Namespace, workflow.Name)
+}
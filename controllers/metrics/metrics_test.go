@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+)
+
+func TestObserveBuildCompletion(t *testing.T) {
+	build := test.GetNewEmptySonataFlowBuild("greeting", t.Name())
+	start := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	completion := metav1.Now()
+	build.Status.StartTime = &start
+	build.Status.CompletionTime = &completion
+
+	build.Status.BuildPhase = operatorapi.BuildPhaseRunning
+	ObserveBuildCompletion(build, operatorapi.OperatorBuildStrategy)
+	assert.Equal(t, float64(0), testutil.ToFloat64(buildsTotal.WithLabelValues(string(operatorapi.OperatorBuildStrategy), string(operatorapi.BuildPhaseRunning))))
+
+	build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+	ObserveBuildCompletion(build, operatorapi.OperatorBuildStrategy)
+	assert.Equal(t, float64(1), testutil.ToFloat64(buildsTotal.WithLabelValues(string(operatorapi.OperatorBuildStrategy), string(operatorapi.BuildPhaseFailed))))
+	assert.Equal(t, 1, testutil.CollectAndCount(buildDuration))
+}
+
+func TestWorkflowCounters(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	IncRecoveryAttempts(workflow)
+	IncRecoveryAttempts(workflow)
+	IncDiscoveryFailures(workflow)
+	assert.Equal(t, float64(2), testutil.ToFloat64(recoveryAttemptsTotal.WithLabelValues(workflow.Namespace, workflow.Name)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(discoveryFailuresTotal.WithLabelValues(workflow.Namespace, workflow.Name)))
+
+	DeleteWorkflowMetrics(workflow)
+	assert.Equal(t, 0, testutil.CollectAndCount(recoveryAttemptsTotal))
+	assert.Equal(t, 0, testutil.CollectAndCount(discoveryFailuresTotal))
+}
+
+func TestStateCollector(t *testing.T) {
+	namespace := "state-collector"
+	ready := test.GetBaseSonataFlow(namespace)
+	ready.Name = "ready"
+	ready.Status.Manager().MarkTrue(api.RunningConditionType)
+	failing := test.GetBaseSonataFlow(namespace)
+	failing.Name = "failing"
+	failing.Annotations = map[string]string{metadata.Profile: metadata.DevProfile.String()}
+	failing.Status.Manager().MarkFalse(api.RunningConditionType, api.RedeploymentExhaustedReason, "")
+
+	platform := test.GetBasePlatformInReadyPhase(namespace)
+	platform.Spec.Services = &operatorapi.ServicesPlatformSpec{
+		DataIndex:  &operatorapi.ServiceSpec{Enabled: utils.Pbool(true)},
+		JobService: &operatorapi.ServiceSpec{Enabled: utils.Pbool(true)},
+	}
+	diDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: services.NewDataIndexHandler(platform).GetServiceName()},
+		Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+		}},
+	}
+
+	cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(ready, failing, platform, diDeployment).Build()
+	expected := `
+# HELP sonataflow_platform_ready Whether the platform is ready (1) or not (0).
+# TYPE sonataflow_platform_ready gauge
+sonataflow_platform_ready{namespace="state-collector",platform="sonataflow-platform"} 1
+# HELP sonataflow_platform_service_available Whether the deployment of a service enabled in the platform, like the Data Index or the Jobs Service, is available (1) or not (0).
+# TYPE sonataflow_platform_service_available gauge
+sonataflow_platform_service_available{namespace="state-collector",platform="sonataflow-platform",service="data-index-service"} 1
+sonataflow_platform_service_available{namespace="state-collector",platform="sonataflow-platform",service="jobs-service"} 0
+# HELP sonataflow_workflows Number of workflows by profile and by status and reason of their top level condition.
+# TYPE sonataflow_workflows gauge
+sonataflow_workflows{namespace="state-collector",profile="dev",reason="AttemptToRedeployFailed",status="False"} 1
+sonataflow_workflows{namespace="state-collector",profile="preview",reason="",status="True"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(&stateCollector{client: cli}, strings.NewReader(expected)))
+}
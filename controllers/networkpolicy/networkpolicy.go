@@ -0,0 +1,355 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+const (
+	knativeServingNamespace  = "knative-serving"
+	knativeEventingNamespace = "knative-eventing"
+	dnsPort                  = 53
+	// maxExternalNameHops limits the ExternalName Services followed to resolve an address
+	maxExternalNameHops = 2
+)
+
+// Builder builds the NetworkPolicy of a workflow or of a platform service. The addresses they call are resolved into
+// the pods of the cluster Services they reach, the addresses outside the cluster are ignored.
+type Builder struct {
+	c         client.Client
+	namespace string
+	ingress   []networkingv1.NetworkPolicyPeer
+	egress    []networkingv1.NetworkPolicyEgressRule
+}
+
+// NewBuilder creates a Builder for a NetworkPolicy in the given namespace, also used to resolve the Service hosts
+// without namespace.
+func NewBuilder(c client.Client, namespace string) *Builder {
+	return &Builder{c: c, namespace: namespace}
+}
+
+// AllowIngressFrom allows the traffic from the given peers on every port.
+func (b *Builder) AllowIngressFrom(peers ...networkingv1.NetworkPolicyPeer) *Builder {
+	for _, peer := range peers {
+		if !containsPeer(b.ingress, peer) {
+			b.ingress = append(b.ingress, peer)
+		}
+	}
+	return b
+}
+
+// AllowEgressTo allows the traffic to the given peers on every port.
+func (b *Builder) AllowEgressTo(peers ...networkingv1.NetworkPolicyPeer) *Builder {
+	for _, peer := range peers {
+		b.addEgress(networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{peer}})
+	}
+	return b
+}
+
+// AllowIngressFromAddress allows the traffic from the pods of the Service reached by the given address, see AllowEgressToAddress.
+func (b *Builder) AllowIngressFromAddress(ctx context.Context, address string) *Builder {
+	if rule := b.resolveAddress(ctx, address, 0); rule != nil {
+		b.AllowIngressFrom(rule.To...)
+	}
+	return b
+}
+
+// AllowEgressToAddress allows the traffic to the given address, either an URL, like "http://data-index.sonataflow"
+// or "jdbc:postgresql://postgres:5432/sonataflow", or a "host:port" pair.
+func (b *Builder) AllowEgressToAddress(ctx context.Context, address string) *Builder {
+	if rule := b.resolveAddress(ctx, address, 0); rule != nil {
+		b.addEgress(*rule)
+	}
+	return b
+}
+
+// AllowEgressToAddresses same as AllowEgressToAddress for a comma separated list of addresses, like the Kafka
+// bootstrap servers.
+func (b *Builder) AllowEgressToAddresses(ctx context.Context, addresses string) *Builder {
+	for _, address := range strings.Split(addresses, ",") {
+		b.AllowEgressToAddress(ctx, address)
+	}
+	return b
+}
+
+// AllowEgressToService allows the traffic to the pods of the given Service on the target port of the given Service
+// port, or on every port when zero.
+func (b *Builder) AllowEgressToService(ctx context.Context, name, namespace string, port int) *Builder {
+	if rule := b.resolveService(ctx, name, namespace, port, 0); rule != nil {
+		b.addEgress(*rule)
+	}
+	return b
+}
+
+// AllowEgressToPersistence allows the traffic to the database configured in the given PersistenceOptionsSpec.
+func (b *Builder) AllowEgressToPersistence(ctx context.Context, spec *operatorapi.PersistenceOptionsSpec) *Builder {
+	switch {
+	case spec == nil:
+	case spec.PostgreSQL != nil:
+		if ref := spec.PostgreSQL.ServiceRef; ref != nil && ref.SQLServiceOptions != nil {
+			return b.AllowEgressToService(ctx, ref.Name, b.namespaceOrDefault(ref.Namespace), portOrDefault(ref.Port, constants.DefaultPostgreSQLPort))
+		}
+		return b.AllowEgressToAddress(ctx, spec.PostgreSQL.JdbcUrl)
+	case spec.MongoDB != nil:
+		if ref := spec.MongoDB.ServiceRef; ref != nil {
+			return b.AllowEgressToService(ctx, ref.Name, b.namespaceOrDefault(ref.Namespace), portOrDefault(ref.Port, constants.DefaultMongoDBPort))
+		}
+		return b.AllowEgressToAddress(ctx, spec.MongoDB.ConnectionString)
+	case spec.Infinispan != nil:
+		if ref := spec.Infinispan.ServiceRef; ref != nil {
+			return b.AllowEgressToService(ctx, ref.Name, b.namespaceOrDefault(ref.Namespace), portOrDefault(ref.Port, constants.DefaultInfinispanPort))
+		}
+		return b.AllowEgressToAddresses(ctx, spec.Infinispan.Hosts)
+	}
+	return b
+}
+
+// Build returns the NetworkPolicy selecting the pods with the given labels. The cluster DNS is always allowed.
+func (b *Builder) Build(name string, labels, podSelector map[string]string) *networkingv1.NetworkPolicy {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: b.namespace, Labels: labels},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: podSelector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Egress:      append([]networkingv1.NetworkPolicyEgressRule{dnsEgressRule()}, b.egress...),
+		},
+	}
+	if len(b.ingress) > 0 {
+		policy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{From: b.ingress}}
+	}
+	return policy
+}
+
+// resolveAddress returns the egress rule reaching the given address, nil if it isn't in the cluster.
+func (b *Builder) resolveAddress(ctx context.Context, address string, hops int) *networkingv1.NetworkPolicyEgressRule {
+	host, port, err := splitAddress(address)
+	if err != nil || len(host) == 0 {
+		klog.V(log.D).InfoS("Skipping the address not resolvable into a NetworkPolicy peer", "address", address, "error", err)
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+		return &networkingv1.NetworkPolicyEgressRule{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: fmt.Sprintf("%s/%d", ip.String(), bits)}}},
+			Ports: tcpPorts(intstr.FromInt(port)),
+		}
+	}
+	name, namespace, ok := splitServiceHost(host, b.namespace)
+	if !ok {
+		klog.V(log.D).InfoS("Skipping the host outside the cluster", "address", address)
+		return nil
+	}
+	return b.resolveService(ctx, name, namespace, port, hops)
+}
+
+// resolveService returns the egress rule reaching the pods of the given Service, nil if it doesn't exist.
+func (b *Builder) resolveService(ctx context.Context, name, namespace string, port int, hops int) *networkingv1.NetworkPolicyEgressRule {
+	service := &corev1.Service{}
+	if err := b.c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, service); err != nil {
+		if !errors.IsNotFound(err) {
+			klog.V(log.E).ErrorS(err, "Failed to get the Service to allow in the NetworkPolicy", "service", name, "namespace", namespace)
+		}
+		return nil
+	}
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		if hops >= maxExternalNameHops {
+			return nil
+		}
+		return b.resolveAddress(ctx, net.JoinHostPort(service.Spec.ExternalName, strconv.Itoa(port)), hops+1)
+	}
+	peer := NamespacePeer(namespace)
+	if len(service.Spec.Selector) > 0 {
+		peer.PodSelector = &metav1.LabelSelector{MatchLabels: service.Spec.Selector}
+	}
+	return &networkingv1.NetworkPolicyEgressRule{
+		To:    []networkingv1.NetworkPolicyPeer{peer},
+		Ports: tcpPorts(targetPort(service, port)),
+	}
+}
+
+func (b *Builder) addEgress(rule networkingv1.NetworkPolicyEgressRule) {
+	for _, existing := range b.egress {
+		if equality.Semantic.DeepEqual(existing, rule) {
+			return
+		}
+	}
+	b.egress = append(b.egress, rule)
+}
+
+func (b *Builder) namespaceOrDefault(namespace string) string {
+	if len(namespace) == 0 {
+		return b.namespace
+	}
+	return namespace
+}
+
+// NamespacePeer returns the peer selecting every pod of the given namespace.
+func NamespacePeer(namespace string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}},
+	}
+}
+
+// KnativeServingPeer returns the peer selecting the Knative Serving activator and gateways.
+func KnativeServingPeer() networkingv1.NetworkPolicyPeer {
+	return NamespacePeer(knativeServingNamespace)
+}
+
+// KnativeEventingPeer returns the peer selecting the Knative Eventing brokers and channels dispatchers.
+func KnativeEventingPeer() networkingv1.NetworkPolicyPeer {
+	return NamespacePeer(knativeEventingNamespace)
+}
+
+// WorkflowsPeer returns the peer selecting the pods of every workflow, in any namespace.
+func WorkflowsPeer() networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      workflowproj.LabelWorkflow,
+			Operator: metav1.LabelSelectorOpExists,
+		}}},
+	}
+}
+
+// ServicesPeer returns the peer selecting the pods of the given platform services.
+func ServicesPeer(namespace string, serviceNames ...string) networkingv1.NetworkPolicyPeer {
+	peer := NamespacePeer(namespace)
+	peer.PodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key:      workflowproj.LabelService,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   serviceNames,
+	}}}
+	return peer
+}
+
+func dnsEgressRule() networkingv1.NetworkPolicyEgressRule {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	port := intstr.FromInt(dnsPort)
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &port}, {Protocol: &tcp, Port: &port}},
+	}
+}
+
+func tcpPorts(port intstr.IntOrString) []networkingv1.NetworkPolicyPort {
+	if port.Type == intstr.Int && port.IntVal == 0 {
+		return nil
+	}
+	tcp := corev1.ProtocolTCP
+	return []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}}
+}
+
+// targetPort returns the pod port the given Service port forwards to, NetworkPolicies apply to the pods.
+func targetPort(service *corev1.Service, port int) intstr.IntOrString {
+	for _, servicePort := range service.Spec.Ports {
+		if int(servicePort.Port) != port {
+			continue
+		}
+		if servicePort.TargetPort.Type == intstr.String || servicePort.TargetPort.IntVal > 0 {
+			return servicePort.TargetPort
+		}
+		break
+	}
+	return intstr.FromInt(port)
+}
+
+func containsPeer(peers []networkingv1.NetworkPolicyPeer, peer networkingv1.NetworkPolicyPeer) bool {
+	for _, p := range peers {
+		if equality.Semantic.DeepEqual(p, peer) {
+			return true
+		}
+	}
+	return false
+}
+
+func portOrDefault(port *int, defaultPort int) int {
+	if port == nil {
+		return defaultPort
+	}
+	return *port
+}
+
+// splitAddress returns the host and the port of an URL or of a "host:port" pair. The port is zero when unknown.
+func splitAddress(address string) (string, int, error) {
+	address = strings.TrimPrefix(strings.TrimSpace(address), "jdbc:")
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return "", 0, err
+		}
+		port := 0
+		switch {
+		case len(u.Port()) > 0:
+			port, err = strconv.Atoi(u.Port())
+		case u.Scheme == "http":
+			port = 80
+		case u.Scheme == "https":
+			port = 443
+		}
+		return u.Hostname(), port, err
+	}
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		// no port
+		return address, 0, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	return host, port, err
+}
+
+// splitServiceHost returns the name and the namespace of the Service reached by the given host, like "name",
+// "name.namespace" or "name.namespace.svc.cluster.local". Returns false for the hosts outside the cluster.
+func splitServiceHost(host, defaultNamespace string) (string, string, bool) {
+	host = strings.TrimSuffix(host, ".")
+	if i := strings.Index(host, ".svc"); i > 0 {
+		host = host[:i]
+	}
+	parts := strings.Split(host, ".")
+	switch len(parts) {
+	case 1:
+		return parts[0], defaultNamespace, true
+	case 2:
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
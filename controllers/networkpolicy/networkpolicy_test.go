@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func Test_splitAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		host    string
+		port    int
+	}{
+		{"http://data-index.sonataflow", "data-index.sonataflow", 80},
+		{"https://jobs-service.sonataflow.svc.cluster.local:8443/jobs", "jobs-service.sonataflow.svc.cluster.local", 8443},
+		{"jdbc:postgresql://postgres:5432/sonataflow", "postgres", 5432},
+		{"my-kafka-bootstrap:9092", "my-kafka-bootstrap", 9092},
+		{" 10.0.0.1 ", "10.0.0.1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			host, port, err := splitAddress(tt.address)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.host, host)
+			assert.Equal(t, tt.port, port)
+		})
+	}
+}
+
+func Test_splitServiceHost(t *testing.T) {
+	name, namespace, ok := splitServiceHost("postgres", "default")
+	assert.True(t, ok)
+	assert.Equal(t, "postgres", name)
+	assert.Equal(t, "default", namespace)
+
+	name, namespace, ok = splitServiceHost("data-index.sonataflow.svc.cluster.local", "default")
+	assert.True(t, ok)
+	assert.Equal(t, "data-index", name)
+	assert.Equal(t, "sonataflow", namespace)
+
+	_, _, ok = splitServiceHost("api.example.com", "default")
+	assert.False(t, ok)
+}
+
+func TestBuilder_AllowEgressToAddress(t *testing.T) {
+	namespace := t.Name()
+	postgres := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "db"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "postgres"},
+			Ports:    []corev1.ServicePort{{Port: 5432, TargetPort: intstr.FromString("postgresql")}},
+		},
+	}
+	alias := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: namespace},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "postgres.db.svc.cluster.local"},
+	}
+	cli := test.NewSonataFlowClientBuilder().WithObjects(postgres, alias).Build()
+
+	policy := NewBuilder(cli, namespace).
+		AllowEgressToAddress(context.TODO(), "jdbc:postgresql://database:5432/sonataflow").
+		AllowEgressToAddress(context.TODO(), "http://10.0.0.1:8080").
+		AllowEgressToAddress(context.TODO(), "https://api.example.com").
+		AllowEgressToAddress(context.TODO(), "http://missing").
+		Build("workflow", nil, map[string]string{"app": "workflow"})
+
+	assert.Equal(t, namespace, policy.Namespace)
+	assert.Len(t, policy.Spec.Egress, 3)
+	assert.Equal(t, dnsEgressRule(), policy.Spec.Egress[0])
+
+	postgresRule := policy.Spec.Egress[1]
+	assert.Equal(t, "db", postgresRule.To[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+	assert.Equal(t, postgres.Spec.Selector, postgresRule.To[0].PodSelector.MatchLabels)
+	assert.Equal(t, intstr.FromString("postgresql"), *postgresRule.Ports[0].Port)
+
+	ipRule := policy.Spec.Egress[2]
+	assert.Equal(t, "10.0.0.1/32", ipRule.To[0].IPBlock.CIDR)
+	assert.Equal(t, intstr.FromInt(8080), *ipRule.Ports[0].Port)
+	assert.Nil(t, policy.Spec.Ingress)
+}
+
+func TestBuilder_AllowEgressToPersistence(t *testing.T) {
+	namespace := t.Name()
+	postgres := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: namespace},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "postgres"},
+			Ports:    []corev1.ServicePort{{Port: 5432}},
+		},
+	}
+	cli := test.NewSonataFlowClientBuilder().WithObjects(postgres).Build()
+	persistence := &operatorapi.PersistenceOptionsSpec{
+		PostgreSQL: &operatorapi.PersistencePostgreSQL{ServiceRef: &operatorapi.PostgreSQLServiceOptions{SQLServiceOptions: &operatorapi.SQLServiceOptions{Name: "postgres"}}},
+	}
+
+	policy := NewBuilder(cli, namespace).
+		AllowIngressFrom(WorkflowsPeer(), WorkflowsPeer()).
+		AllowEgressToPersistence(context.TODO(), persistence).
+		Build("data-index", nil, nil)
+
+	assert.Len(t, policy.Spec.Egress, 2)
+	assert.Equal(t, intstr.FromInt(5432), *policy.Spec.Egress[1].Ports[0].Port)
+	// the duplicated peers are allowed once
+	assert.Len(t, policy.Spec.Ingress, 1)
+	assert.Len(t, policy.Spec.Ingress[0].From, 1)
+}
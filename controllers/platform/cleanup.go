@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/clusterplatform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+// GetPlatformDependents returns the resources that still depend on the given platform: the workflows it serves. The
+// platform can't be deleted until none is left.
+func GetPlatformDependents(ctx context.Context, c ctrl.Client, platform *operatorapi.SonataFlowPlatform) ([]string, error) {
+	var dependents []string
+	if IsActive(platform) && !IsSecondary(platform) {
+		workflows := &operatorapi.SonataFlowList{}
+		if err := c.List(ctx, workflows, ctrl.InNamespace(platform.Namespace)); err != nil {
+			return nil, err
+		}
+		for _, workflow := range workflows.Items {
+			dependents = append(dependents, fmt.Sprintf("%s/%s", operatorapi.SonataFlowKind, workflow.Name))
+		}
+	}
+	return dependents, nil
+}
+
+// GetReferencingClusterPlatform returns the active SonataFlowClusterPlatform sharing the services and the configuration
+// of the given platform cluster-wide, nil if the platform isn't referenced. The reference doesn't hold the platform
+// deletion: the other platforms stop inheriting from it until the SonataFlowClusterPlatform references another one.
+func GetReferencingClusterPlatform(ctx context.Context, c ctrl.Client, platform *operatorapi.SonataFlowPlatform) (*operatorapi.SonataFlowClusterPlatform, error) {
+	cPlatform, err := clusterplatform.GetActiveClusterPlatform(ctx, c)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if cPlatform != nil && cPlatform.Spec.PlatformRef.Name == platform.Name && cPlatform.Spec.PlatformRef.Namespace == platform.Namespace {
+		return cPlatform, nil
+	}
+	return nil, nil
+}
+
+// CleanupPlatform removes the platform resources that can't be garbage collected through owner references, as
+// configured by the platform deletion policies.
+func CleanupPlatform(ctx context.Context, c ctrl.Client, platform *operatorapi.SonataFlowPlatform) error {
+	if platform.Spec.DeletionPolicies.GetBuildCachePolicy() != operatorapi.DeleteDeletionPolicy {
+		return nil
+	}
+	pvcName := defaultKanikoCachePVCName
+	if persistentVolumeClaim, found := platform.Spec.Build.Config.BuildStrategyOptions[kanikoPVCName]; found {
+		pvcName = persistentVolumeClaim
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: platform.Namespace, Name: pvcName}, pvc); err != nil {
+		return ctrl.IgnoreNotFound(err)
+	}
+	// only the claim provisioned by the operator is removed, a claim provided by the user is kept
+	if pvc.Labels["app"] != kanikoCacheAppLabel {
+		return nil
+	}
+	if err := c.Delete(ctx, pvc); err != nil {
+		return ctrl.IgnoreNotFound(err)
+	}
+	klog.V(log.I).InfoS("Kaniko cache deleted", "platform", platform.Name, "namespace", platform.Namespace, "pvc", pvcName)
+	return nil
+}
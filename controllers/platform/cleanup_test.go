@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func TestGetPlatformDependents(t *testing.T) {
+	namespace := t.Name()
+	platform := test.GetBasePlatformInReadyPhase(namespace)
+	workflow := test.GetBaseSonataFlow(namespace)
+	cPlatform := test.GetBaseClusterPlatformInReadyPhase(namespace)
+	cPlatform.Spec.PlatformRef.Name = platform.Name
+
+	cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform, workflow, cPlatform).Build()
+	dependents, err := GetPlatformDependents(context.TODO(), cli, platform)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{operatorapi.SonataFlowKind + "/" + workflow.Name}, dependents)
+
+	// a duplicated platform doesn't serve the workflows of the namespace
+	platform.Status.Manager().MarkFalse(api.SucceedConditionType, operatorapi.PlatformDuplicatedReason, "")
+	cli = test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform, workflow).Build()
+	dependents, err = GetPlatformDependents(context.TODO(), cli, platform)
+	assert.NoError(t, err)
+	assert.Empty(t, dependents)
+}
+
+func TestGetReferencingClusterPlatform(t *testing.T) {
+	namespace := t.Name()
+	platform := test.GetBasePlatformInReadyPhase(namespace)
+	cPlatform := test.GetBaseClusterPlatformInReadyPhase(namespace)
+	cPlatform.Spec.PlatformRef.Name = platform.Name
+
+	cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform, cPlatform).Build()
+	referencing, err := GetReferencingClusterPlatform(context.TODO(), cli, platform)
+	assert.NoError(t, err)
+	assert.NotNil(t, referencing)
+	assert.Equal(t, cPlatform.Name, referencing.Name)
+
+	cli = test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform).Build()
+	referencing, err = GetReferencingClusterPlatform(context.TODO(), cli, platform)
+	assert.NoError(t, err)
+	assert.Nil(t, referencing)
+}
+
+func TestCleanupPlatform(t *testing.T) {
+	namespace := t.Name()
+	newPVC := func(name, app string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"app": app}},
+		}
+	}
+
+	t.Run("retain the build cache by default", func(t *testing.T) {
+		platform := test.GetBasePlatformInReadyPhase(namespace)
+		cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(newPVC(defaultKanikoCachePVCName, kanikoCacheAppLabel)).Build()
+		assert.NoError(t, CleanupPlatform(context.TODO(), cli, platform))
+		assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: defaultKanikoCachePVCName}, &corev1.PersistentVolumeClaim{}))
+	})
+
+	t.Run("delete the build cache provisioned by the operator", func(t *testing.T) {
+		platform := test.GetBasePlatformInReadyPhase(namespace)
+		platform.Spec.DeletionPolicies = &operatorapi.DeletionPoliciesSpec{BuildCache: operatorapi.DeleteDeletionPolicy}
+		cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(newPVC(defaultKanikoCachePVCName, kanikoCacheAppLabel)).Build()
+		assert.NoError(t, CleanupPlatform(context.TODO(), cli, platform))
+		err := cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: defaultKanikoCachePVCName}, &corev1.PersistentVolumeClaim{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("keep the build cache provided by the user", func(t *testing.T) {
+		platform := test.GetBasePlatformInReadyPhase(namespace)
+		platform.Spec.DeletionPolicies = &operatorapi.DeletionPoliciesSpec{BuildCache: operatorapi.DeleteDeletionPolicy}
+		platform.Spec.Build.Config.BuildStrategyOptions = map[string]string{kanikoPVCName: "my-cache"}
+		cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(newPVC("my-cache", "my-app")).Build()
+		assert.NoError(t, CleanupPlatform(context.TODO(), cli, platform))
+		assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "my-cache"}, &corev1.PersistentVolumeClaim{}))
+	})
+}
@@ -0,0 +1,308 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/clusterplatform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+// Fields of the platform that can be inherited from the SonataFlowPlatform referenced by the SonataFlowClusterPlatform.
+// The build strategy and its options depend on the cluster the platform runs into and are never shared.
+const (
+	buildTemplateTimeoutField   = "build.template.timeout"
+	buildTemplateResourcesField = "build.template.resources"
+	buildTemplateArgumentsField = "build.template.arguments"
+	buildTemplateBuildArgsField = "build.template.buildArgs"
+	buildTemplateEnvsField      = "build.template.envs"
+	buildBaseImageField         = "build.config.baseImage"
+	buildTimeoutField           = "build.config.timeout"
+	buildRegistryField          = "build.config.registry"
+	buildHistoryLimitField      = "build.config.historyLimit"
+	persistenceField            = "persistence"
+	monitoringField             = "monitoring"
+)
+
+// sharedConfigCapabilities the SonataFlowClusterPlatform capabilities handled by the effective configuration
+var sharedConfigCapabilities = []operatorapi.WorkFlowCapability{
+	clusterplatform.PlatformBuild,
+	clusterplatform.PlatformProperties,
+	clusterplatform.PlatformPersistence,
+	clusterplatform.PlatformMonitoring,
+}
+
+// GetInheritedCapabilities returns the capabilities shared by the given SonataFlowClusterPlatform that the platform
+// opted in for. Without an explicit selection, the platform only inherits the platform services.
+func GetInheritedCapabilities(cPlatform *operatorapi.SonataFlowClusterPlatform, p *operatorapi.SonataFlowPlatform) []operatorapi.WorkFlowCapability {
+	if cPlatform == nil || cPlatform.Spec.Capabilities == nil {
+		return nil
+	}
+	var capabilities []operatorapi.WorkFlowCapability
+	for _, c := range cPlatform.Spec.Capabilities.Workflows {
+		if isCapabilityRequested(p, c) || (p.Spec.ClusterCapabilities == nil && c == clusterplatform.PlatformServices) {
+			capabilities = append(capabilities, c)
+		}
+	}
+	return capabilities
+}
+
+// isCapabilityRequested whether the platform explicitly selected the given capability of the SonataFlowClusterPlatform.
+func isCapabilityRequested(p *operatorapi.SonataFlowPlatform, c operatorapi.WorkFlowCapability) bool {
+	return p.Spec.ClusterCapabilities != nil && slices.Contains(p.Spec.ClusterCapabilities.Workflows, c)
+}
+
+// NewEffectiveConfig merges the platform with the configuration shared by the SonataFlowPlatform referenced by the
+// SonataFlowClusterPlatform. Only the given capabilities are merged, and the fields set in the platform are kept.
+// The shared platform is nil when the platform is the one referenced by the SonataFlowClusterPlatform.
+func NewEffectiveConfig(p, shared *operatorapi.SonataFlowPlatform, capabilities []operatorapi.WorkFlowCapability) *operatorapi.PlatformEffectiveConfigStatus {
+	spec := p.Spec.DeepCopy()
+	merger := &configMerger{}
+	if shared != nil {
+		merger.merge(spec, getSharedSpec(shared), capabilities)
+	}
+	return &operatorapi.PlatformEffectiveConfigStatus{
+		Build:           spec.Build,
+		Properties:      spec.Properties,
+		Persistence:     spec.Persistence,
+		Monitoring:      spec.Monitoring,
+		InheritedFields: merger.inherited,
+	}
+}
+
+// getEffectivePlatform returns a copy of the platform with the fields it inherits from the SonataFlowClusterPlatform.
+func getEffectivePlatform(p *operatorapi.SonataFlowPlatform) *operatorapi.SonataFlowPlatform {
+	effective := p.DeepCopy()
+	applyEffectiveConfig(effective)
+	return effective
+}
+
+// applyEffectiveConfig sets the fields the platform inherits from the SonataFlowClusterPlatform, as recorded in its
+// status. The platform must not be persisted afterward, the inherited fields don't belong to its spec.
+func applyEffectiveConfig(p *operatorapi.SonataFlowPlatform) {
+	effective := p.Status.EffectiveConfig
+	if effective == nil || len(effective.InheritedFields) == 0 {
+		return
+	}
+	merger := &configMerger{only: effective.InheritedFields}
+	merger.merge(&p.Spec, &operatorapi.SonataFlowPlatformSpec{
+		Build:       *effective.Build.DeepCopy(),
+		Properties:  effective.Properties.DeepCopy(),
+		Persistence: effective.Persistence.DeepCopy(),
+		Monitoring:  effective.Monitoring.DeepCopy(),
+	}, sharedConfigCapabilities)
+}
+
+// getSharedSpec returns the spec of the shared platform, with the persistence resolved to the services of its namespace.
+func getSharedSpec(shared *operatorapi.SonataFlowPlatform) *operatorapi.SonataFlowPlatformSpec {
+	spec := shared.Spec.DeepCopy()
+	spec.Persistence = shared.GetPersistence().DeepCopy()
+	if spec.Persistence == nil {
+		return spec
+	}
+	// the provisioned database is reached through the service created in the shared platform namespace
+	spec.Persistence.ProvisionedPostgreSQL = nil
+	if pg := spec.Persistence.PostgreSQL; pg != nil && pg.ServiceRef != nil && len(pg.ServiceRef.Namespace) == 0 {
+		pg.ServiceRef.Namespace = shared.Namespace
+	}
+	if mongo := spec.Persistence.MongoDB; mongo != nil && mongo.ServiceRef != nil && len(mongo.ServiceRef.Namespace) == 0 {
+		mongo.ServiceRef.Namespace = shared.Namespace
+	}
+	if ispn := spec.Persistence.Infinispan; ispn != nil && ispn.ServiceRef != nil && len(ispn.ServiceRef.Namespace) == 0 {
+		ispn.ServiceRef.Namespace = shared.Namespace
+	}
+	return spec
+}
+
+// configMerger fills the unset fields of a platform spec with the shared ones, and records which fields were inherited.
+type configMerger struct {
+	// only restricts the merge to the given fields when not nil
+	only      []string
+	inherited []string
+}
+
+func (m *configMerger) inherit(field string, localSet, sharedSet bool, set func()) {
+	if localSet || !sharedSet {
+		return
+	}
+	if m.only != nil && !slices.Contains(m.only, field) {
+		return
+	}
+	set()
+	m.inherited = append(m.inherited, field)
+}
+
+func (m *configMerger) merge(local, shared *operatorapi.SonataFlowPlatformSpec, capabilities []operatorapi.WorkFlowCapability) {
+	if slices.Contains(capabilities, clusterplatform.PlatformBuild) {
+		m.mergeBuild(&local.Build, &shared.Build)
+	}
+	if slices.Contains(capabilities, clusterplatform.PlatformProperties) && shared.Properties != nil {
+		for _, prop := range shared.Properties.Flow {
+			prop := prop
+			m.inherit(operatorapi.InheritedPropertyField(prop.Name), hasFlowProperty(local.Properties, prop.Name), true, func() {
+				if local.Properties == nil {
+					local.Properties = &operatorapi.PropertyPlatformSpec{}
+				}
+				local.Properties.Flow = append(local.Properties.Flow, *prop.DeepCopy())
+			})
+		}
+	}
+	if slices.Contains(capabilities, clusterplatform.PlatformPersistence) {
+		m.inherit(persistenceField, local.Persistence != nil, shared.Persistence != nil, func() {
+			local.Persistence = shared.Persistence.DeepCopy()
+		})
+	}
+	if slices.Contains(capabilities, clusterplatform.PlatformMonitoring) {
+		m.inherit(monitoringField, local.Monitoring != nil, shared.Monitoring != nil, func() {
+			local.Monitoring = shared.Monitoring.DeepCopy()
+		})
+	}
+}
+
+func (m *configMerger) mergeBuild(local, shared *operatorapi.BuildPlatformSpec) {
+	lt, st := &local.Template, &shared.Template
+	m.inherit(buildTemplateTimeoutField, lt.Timeout.Duration != 0, st.Timeout.Duration != 0, func() {
+		lt.Timeout = st.Timeout
+	})
+	m.inherit(buildTemplateResourcesField, hasResources(&lt.Resources), hasResources(&st.Resources), func() {
+		lt.Resources = *st.Resources.DeepCopy()
+	})
+	m.inherit(buildTemplateArgumentsField, len(lt.Arguments) > 0, len(st.Arguments) > 0, func() {
+		lt.Arguments = slices.Clone(st.Arguments)
+	})
+	m.inherit(buildTemplateBuildArgsField, len(lt.BuildArgs) > 0, len(st.BuildArgs) > 0, func() {
+		lt.BuildArgs = cloneEnvVars(st.BuildArgs)
+	})
+	m.inherit(buildTemplateEnvsField, len(lt.Envs) > 0, len(st.Envs) > 0, func() {
+		lt.Envs = cloneEnvVars(st.Envs)
+	})
+
+	lc, sc := &local.Config, &shared.Config
+	m.inherit(buildBaseImageField, len(lc.BaseImage) > 0, len(sc.BaseImage) > 0, func() {
+		lc.BaseImage = sc.BaseImage
+	})
+	m.inherit(buildTimeoutField, lc.Timeout != nil, sc.Timeout != nil, func() {
+		lc.Timeout = sc.Timeout.DeepCopy()
+	})
+	// the registry address, credentials and CA go together, so the registry is inherited as a whole
+	m.inherit(buildRegistryField, lc.Registry != operatorapi.RegistrySpec{}, sc.Registry != operatorapi.RegistrySpec{}, func() {
+		lc.Registry = sc.Registry
+	})
+	m.inherit(buildHistoryLimitField, lc.HistoryLimit != nil, sc.HistoryLimit != nil, func() {
+		limit := *sc.HistoryLimit
+		lc.HistoryLimit = &limit
+	})
+}
+
+func hasFlowProperty(props *operatorapi.PropertyPlatformSpec, name string) bool {
+	if props == nil {
+		return false
+	}
+	return slices.ContainsFunc(props.Flow, func(p operatorapi.PropertyVar) bool { return p.Name == name })
+}
+
+func hasResources(r *corev1.ResourceRequirements) bool {
+	return len(r.Limits) > 0 || len(r.Requests) > 0 || len(r.Claims) > 0
+}
+
+func cloneEnvVars(envs []corev1.EnvVar) []corev1.EnvVar {
+	cloned := make([]corev1.EnvVar, len(envs))
+	for i := range envs {
+		envs[i].DeepCopyInto(&cloned[i])
+	}
+	return cloned
+}
+
+// isInheritedFromClusterPlatform returns true if the given field of the platform is inherited from the
+// SonataFlowClusterPlatform, in which case the platform defaults must not be applied to it.
+func isInheritedFromClusterPlatform(p *operatorapi.SonataFlowPlatform, field string) bool {
+	return p.Status.EffectiveConfig.IsInherited(field)
+}
+
+// CopySharedPersistenceSecrets copies the Secrets holding the credentials of the persistence inherited from the
+// SonataFlowClusterPlatform into the platform namespace, since the workflows can only reference local Secrets.
+// Only done when the platform explicitly selected the persistence capability. Secrets already existing in the platform
+// namespace, and not copied by the operator, are left untouched.
+func CopySharedPersistenceSecrets(ctx context.Context, c ctrl.Client, p *operatorapi.SonataFlowPlatform, sourceNamespace string) error {
+	effective := p.Status.EffectiveConfig
+	if !isCapabilityRequested(p, clusterplatform.PlatformPersistence) || !effective.IsInherited(persistenceField) || sourceNamespace == p.Namespace {
+		return nil
+	}
+	for _, name := range getPersistenceSecretNames(effective.Persistence) {
+		source := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: sourceNamespace, Name: name}, source); err != nil {
+			if errors.IsNotFound(err) {
+				klog.V(log.I).InfoS("Shared persistence secret not found", "namespace", sourceNamespace, "name", name)
+				continue
+			}
+			return err
+		}
+		sharedFrom := fmt.Sprintf("%s/%s", sourceNamespace, name)
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: p.Namespace, Name: name}}
+		if err := c.Get(ctx, ctrl.ObjectKeyFromObject(secret), secret); err == nil && secret.Annotations[metadata.SharedFromAnnotation] != sharedFrom {
+			klog.V(log.I).InfoS("Keeping the local secret instead of the shared one", "namespace", p.Namespace, "name", name)
+			continue
+		} else if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[metadata.SharedFromAnnotation] = sharedFrom
+			secret.Type = source.Type
+			secret.Data = source.Data
+			return controllerutil.SetControllerReference(p, secret, c.Scheme())
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getPersistenceSecretNames(persistence *operatorapi.PlatformPersistenceOptionsSpec) []string {
+	var names []string
+	if persistence == nil {
+		return names
+	}
+	if persistence.PostgreSQL != nil {
+		names = append(names, persistence.PostgreSQL.SecretRef.Name)
+	}
+	if persistence.MongoDB != nil {
+		names = append(names, persistence.MongoDB.SecretRef.Name)
+	}
+	if persistence.Infinispan != nil {
+		names = append(names, persistence.Infinispan.SecretRef.Name)
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/clusterplatform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func TestGetInheritedCapabilities(t *testing.T) {
+	cPlatform := test.GetBaseClusterPlatformInReadyPhase(t.Name())
+	cPlatform.Spec.Capabilities = &operatorapi.SonataFlowClusterPlatformCapSpec{
+		Workflows: []operatorapi.WorkFlowCapability{clusterplatform.PlatformServices, clusterplatform.PlatformMonitoring},
+	}
+	p := test.GetBasePlatformInReadyPhase(t.Name())
+
+	assert.Equal(t, []operatorapi.WorkFlowCapability{clusterplatform.PlatformServices}, GetInheritedCapabilities(cPlatform, p))
+
+	p.Spec.ClusterCapabilities = &operatorapi.SonataFlowClusterPlatformCapSpec{
+		Workflows: []operatorapi.WorkFlowCapability{clusterplatform.PlatformMonitoring, clusterplatform.PlatformBuild},
+	}
+	assert.Equal(t, []operatorapi.WorkFlowCapability{clusterplatform.PlatformMonitoring}, GetInheritedCapabilities(cPlatform, p))
+
+	p.Spec.ClusterCapabilities = &operatorapi.SonataFlowClusterPlatformCapSpec{}
+	assert.Empty(t, GetInheritedCapabilities(cPlatform, p))
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	shared := test.GetBasePlatformInReadyPhase("shared")
+	shared.Spec.Build.Template.Resources = corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	shared.Spec.Build.Config.Timeout = &metav1.Duration{Duration: 10 * time.Minute}
+	shared.Spec.Build.Config.BuildStrategy = operatorapi.PlatformBuildStrategy
+	shared.Spec.Monitoring = &operatorapi.MonitoringSpec{Enabled: true}
+	shared.Spec.Persistence = &operatorapi.PlatformPersistenceOptionsSpec{
+		ProvisionedPostgreSQL: &operatorapi.ProvisionedPostgreSQLOptions{},
+	}
+
+	p := test.GetBasePlatformInReadyPhase(t.Name())
+	p.Spec.Build.Config.Timeout = &metav1.Duration{Duration: time.Minute}
+	p.Spec.Build.Config.BuildStrategy = operatorapi.OperatorBuildStrategy
+	p.Spec.Properties = nil
+
+	effective := NewEffectiveConfig(p, shared, sharedConfigCapabilities)
+	assert.ElementsMatch(t, []string{
+		buildTemplateResourcesField,
+		operatorapi.InheritedPropertyField("quarkus.log.level"),
+		persistenceField,
+		monitoringField,
+	}, effective.InheritedFields)
+	// the local fields and the build strategy are kept
+	assert.Equal(t, time.Minute, effective.Build.Config.Timeout.Duration)
+	assert.Equal(t, operatorapi.OperatorBuildStrategy, effective.Build.Config.BuildStrategy)
+	assert.Equal(t, "quay.io/kiegroup", effective.Build.Config.Registry.Address)
+	// the provisioned database is reached in the shared platform namespace
+	assert.Nil(t, effective.Persistence.ProvisionedPostgreSQL)
+	assert.Equal(t, shared.Namespace, effective.Persistence.PostgreSQL.ServiceRef.Namespace)
+	assert.Equal(t, shared.GetProvisionedPostgreSQLName(), effective.Persistence.PostgreSQL.SecretRef.Name)
+	assert.Empty(t, NewEffectiveConfig(p, nil, sharedConfigCapabilities).InheritedFields)
+
+	// only the inherited fields are applied, the ones the platform sets since then take precedence
+	p.Status.EffectiveConfig = effective
+	p.Spec.Monitoring = &operatorapi.MonitoringSpec{Enabled: false}
+	applyEffectiveConfig(p)
+	assert.Equal(t, resource.MustParse("2Gi"), p.Spec.Build.Template.Resources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, "INFO", p.Spec.Properties.Flow[0].Value)
+	assert.NotNil(t, p.Spec.Persistence.PostgreSQL)
+	assert.False(t, p.Spec.Monitoring.IsEnabled())
+}
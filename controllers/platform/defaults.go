@@ -38,6 +38,8 @@ const defaultSonataFlowPlatformName = "sonataflow-platform"
 
 func ConfigureDefaults(ctx context.Context, c client.Client, p *operatorapi.SonataFlowPlatform, verbose bool) error {
 	// update missing fields in the resource
+	// the Tekton strategy runs on any cluster, it's the only one users can choose
+	tekton := p.Spec.Build.Config.BuildStrategy == operatorapi.TektonBuildStrategy
 	if p.Status.Cluster == "" || utils.IsOpenShift() {
 		p.Status.Cluster = operatorapi.PlatformClusterOpenShift
 		p.Spec.Build.Config.BuildStrategy = operatorapi.PlatformBuildStrategy
@@ -46,6 +48,9 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *operatorapi.Sona
 		p.Status.Cluster = operatorapi.PlatformClusterKubernetes
 		p.Spec.Build.Config.BuildStrategy = operatorapi.OperatorBuildStrategy
 	}
+	if tekton {
+		p.Spec.Build.Config.BuildStrategy = operatorapi.TektonBuildStrategy
+	}
 
 	err := setPlatformDefaults(p, verbose)
 	if err != nil {
@@ -57,8 +62,8 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *operatorapi.Sona
 		return err
 	}
 
-	if verbose && p.Spec.Build.Config.Timeout.Duration != 0 {
-		klog.V(log.I).InfoS("Maven Timeout set", "timeout", p.Spec.Build.Config.Timeout.Duration)
+	if verbose && p.Spec.Build.Config.GetTimeout().Duration != 0 {
+		klog.V(log.I).InfoS("Maven Timeout set", "timeout", p.Spec.Build.Config.GetTimeout().Duration)
 	}
 
 	return createOrUpdatePlatform(ctx, c, p)
@@ -131,7 +131,7 @@ func createPersistentVolumeClaim(ctx context.Context, client client.Client, plat
 			Namespace: platform.Namespace,
 			Name:      pvcName,
 			Labels: map[string]string{
-				"app": "kogito-serverless-operator",
+				"app": kanikoCacheAppLabel,
 			},
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
@@ -61,6 +61,11 @@ func (action *serviceAction) Handle(ctx context.Context, platform *operatorapi.S
 		return nil, err
 	}
 
+	// the platform services connect to the provisioned database, if any
+	if err := createOrUpdateProvisionedPostgreSQL(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+
 	psDI := services.NewDataIndexHandler(platform)
 	if psDI.IsServiceSetInSpec() {
 		if err := createOrUpdateServiceComponents(ctx, action.client, platform, psDI); err != nil {
@@ -85,7 +90,13 @@ func createOrUpdateServiceComponents(ctx context.Context, client client.Client,
 	if err := createOrUpdateDeployment(ctx, client, platform, psh); err != nil {
 		return err
 	}
-	return createOrUpdateService(ctx, client, platform, psh)
+	if err := createOrUpdateService(ctx, client, platform, psh); err != nil {
+		return err
+	}
+	if err := createOrUpdateServiceMonitor(ctx, client, platform, psh); err != nil {
+		return err
+	}
+	return createOrUpdateNetworkPolicy(ctx, client, platform, psh)
 }
 
 func createOrUpdateDeployment(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform, psh services.PlatformServiceHandler) error {
@@ -204,6 +215,12 @@ func createOrUpdateService(ctx context.Context, client client.Client, platform *
 				Port:       80,
 				TargetPort: variables.DefaultHTTPWorkflowPortIntStr,
 			},
+			{
+				Name:       constants.MetricsPortName,
+				Protocol:   corev1.ProtocolTCP,
+				Port:       variables.DefaultHTTPWorkflowPortIntStr.IntVal,
+				TargetPort: variables.DefaultHTTPWorkflowPortIntStr,
+			},
 		},
 		Selector: selectorLbl,
 	}
@@ -38,6 +38,8 @@ const (
 	kanikoPVCName           = "KanikoPersistentVolumeClaim"
 	kanikoWarmerImage       = "KanikoWarmerImage"
 	kanikoBuildCacheEnabled = "KanikoBuildCacheEnabled"
+	// kanikoCacheAppLabel identifies the Kaniko cache PersistentVolumeClaim provisioned by the operator
+	kanikoCacheAppLabel = "kogito-serverless-operator"
 )
 
 func IsKanikoCacheEnabled(platform *v08.SonataFlowPlatform) bool {
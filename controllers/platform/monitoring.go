@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/monitoring"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+)
+
+// prometheusAvailability verifies if the Prometheus Operator is installed, replaced in tests.
+var prometheusAvailability = monitoring.GetPrometheusAvailability
+
+// createOrUpdateServiceMonitor creates the Prometheus Operator monitor scraping the metrics of the given platform
+// service when the platform monitoring is enabled, and removes the monitors no longer required.
+func createOrUpdateServiceMonitor(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform, psh services.PlatformServiceHandler) error {
+	monitoringSpec := platform.Spec.Monitoring
+	if !monitoringSpec.IsEnabled() {
+		return removeServiceMonitors(ctx, client, platform, psh, &monitoringv1.ServiceMonitor{}, &monitoringv1.PodMonitor{})
+	}
+	if avail, err := prometheusAvailability(client.GetConfig()); err != nil || !avail {
+		klog.V(log.I).InfoS("Prometheus Operator is not installed, skipping the platform service monitor", "service", psh.GetServiceName())
+		return nil
+	}
+
+	lbl, selectorLbl := getLabels(platform, psh)
+	for k, v := range monitoringSpec.Labels {
+		lbl[k] = v
+	}
+	objectMeta := metav1.ObjectMeta{Namespace: platform.Namespace, Name: psh.GetServiceName()}
+	var monitor ctrl.Object
+	var mutate controllerutil.MutateFn
+	if monitoringSpec.GetType() == operatorapi.PodMonitorType {
+		if err := removeServiceMonitors(ctx, client, platform, psh, &monitoringv1.ServiceMonitor{}); err != nil {
+			return err
+		}
+		podMonitor := &monitoringv1.PodMonitor{ObjectMeta: objectMeta}
+		monitor, mutate = podMonitor, func() error {
+			podMonitor.Labels = lbl
+			podMonitor.Spec = monitoringv1.PodMonitorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: selectorLbl},
+				PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{
+					Port:     utils.DefaultServicePortName,
+					Path:     constants.QuarkusMetricsPath,
+					Interval: monitoringSpec.Interval,
+				}},
+			}
+			return nil
+		}
+	} else {
+		if err := removeServiceMonitors(ctx, client, platform, psh, &monitoringv1.PodMonitor{}); err != nil {
+			return err
+		}
+		serviceMonitor := &monitoringv1.ServiceMonitor{ObjectMeta: objectMeta}
+		monitor, mutate = serviceMonitor, func() error {
+			serviceMonitor.Labels = lbl
+			serviceMonitor.Spec = monitoringv1.ServiceMonitorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: selectorLbl},
+				Endpoints: []monitoringv1.Endpoint{{
+					Port:     constants.MetricsPortName,
+					Path:     constants.QuarkusMetricsPath,
+					Interval: monitoringSpec.Interval,
+				}},
+			}
+			return nil
+		}
+	}
+	if err := controllerutil.SetControllerReference(platform, monitor, client.Scheme()); err != nil {
+		return err
+	}
+	if op, err := controllerutil.CreateOrUpdate(ctx, client, monitor, mutate); err != nil {
+		return err
+	} else {
+		klog.V(log.I).InfoS("Monitor successfully reconciled", "operation", op, "service", psh.GetServiceName())
+	}
+	return nil
+}
+
+func removeServiceMonitors(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform, psh services.PlatformServiceHandler, monitors ...ctrl.Object) error {
+	for _, monitor := range monitors {
+		if err := client.Get(ctx, types.NamespacedName{Namespace: platform.Namespace, Name: psh.GetServiceName()}, monitor); err != nil {
+			// the Prometheus Operator might not be installed in the cluster
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(monitor, platform) {
+			continue
+		}
+		if err := client.Delete(ctx, monitor); ctrl.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	clientr "github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	kubeutil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
+)
+
+func TestPlatformServicesMonitoring(t *testing.T) {
+	defer func(availability func(cfg *rest.Config) (bool, error)) {
+		prometheusAvailability = availability
+	}(prometheusAvailability)
+	prometheusAvailability = func(cfg *rest.Config) (bool, error) {
+		return true, nil
+	}
+
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	platform.Spec.Services = &operatorapi.ServicesPlatformSpec{DataIndex: &operatorapi.ServiceSpec{}}
+	platform.Spec.Monitoring = &operatorapi.MonitoringSpec{Enabled: true, Labels: map[string]string{"release": "prometheus"}}
+	ctrlClient := test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform).Build()
+	cli, err := clientr.FromCtrlClientSchemeAndConfig(ctrlClient, scheme.Scheme, &rest.Config{})
+	assert.NoError(t, err)
+
+	psDI := services.NewDataIndexHandler(platform)
+	assert.NoError(t, createOrUpdateServiceComponents(context.TODO(), cli, platform, psDI))
+	name := types.NamespacedName{Namespace: platform.Namespace, Name: psDI.GetServiceName()}
+
+	service := &corev1.Service{}
+	assert.NoError(t, cli.Get(context.TODO(), name, service))
+	metricsPort, _ := kubeutil.GetServicePortByName(constants.MetricsPortName, service)
+	assert.NotNil(t, metricsPort)
+	serviceMonitor := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, cli.Get(context.TODO(), name, serviceMonitor))
+	assert.Equal(t, "prometheus", serviceMonitor.Labels["release"])
+	assert.Equal(t, service.Spec.Selector, serviceMonitor.Spec.Selector.MatchLabels)
+	assert.Equal(t, constants.MetricsPortName, serviceMonitor.Spec.Endpoints[0].Port)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: platform.Namespace, Name: psDI.GetServiceCmName()}, cm))
+	assert.Contains(t, cm.Data["application.properties"], constants.QuarkusMicrometerPrometheusEnabled+" = true")
+
+	// disabling the monitoring removes the monitor
+	platform.Spec.Monitoring.Enabled = false
+	assert.NoError(t, createOrUpdateServiceMonitor(context.TODO(), cli, platform, psDI))
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), name, &monitoringv1.ServiceMonitor{})))
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/networkpolicy"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+// createOrUpdateNetworkPolicy creates the NetworkPolicy isolating the given platform service when the platform
+// network isolation is enabled, and removes it otherwise.
+func createOrUpdateNetworkPolicy(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform, psh services.PlatformServiceHandler) error {
+	isolation := platform.Spec.NetworkIsolation
+	if !isolation.IsEnabled() {
+		return removeNetworkPolicy(ctx, client, platform, psh)
+	}
+
+	// the workflows and the platform services talk to each other, the Data Index also consumes the workflow events
+	platformServices := networkpolicy.ServicesPeer(platform.Namespace, services.NewDataIndexHandler(platform).GetServiceName(), services.NewJobServiceHandler(platform).GetServiceName())
+	builder := networkpolicy.NewBuilder(client, platform.Namespace).
+		AllowIngressFrom(networkpolicy.WorkflowsPeer(), platformServices).
+		AllowEgressTo(networkpolicy.WorkflowsPeer(), platformServices).
+		AllowEgressToPersistence(ctx, psh.GetPersistence())
+	if backend := operatorapi.GetPlatformEventingBackend(platform); backend.IsKafka() {
+		builder.AllowEgressToAddresses(ctx, backend.Kafka.BootstrapServers)
+	} else {
+		builder.AllowIngressFrom(networkpolicy.KnativeEventingPeer()).AllowEgressTo(networkpolicy.KnativeEventingPeer())
+	}
+	if operatorNamespace := GetOperatorNamespace(); len(operatorNamespace) > 0 {
+		// the operator counts the active instances of the workflow versions and reads the scheduled jobs
+		builder.AllowIngressFrom(networkpolicy.NamespacePeer(operatorNamespace))
+	}
+	builder.AllowIngressFrom(isolation.IngressPeers...).AllowEgressTo(isolation.EgressPeers...)
+
+	lbl, selectorLbl := getLabels(platform, psh)
+	desired := builder.Build(psh.GetServiceName(), lbl, selectorLbl)
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: desired.ObjectMeta}
+	if err := controllerutil.SetControllerReference(platform, policy, client.Scheme()); err != nil {
+		return err
+	}
+	if op, err := controllerutil.CreateOrUpdate(ctx, client, policy, func() error {
+		policy.Labels = desired.Labels
+		policy.Spec = desired.Spec
+		return nil
+	}); err != nil {
+		return err
+	} else {
+		klog.V(log.I).InfoS("NetworkPolicy successfully reconciled", "operation", op, "service", psh.GetServiceName())
+	}
+	return nil
+}
+
+func removeNetworkPolicy(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform, psh services.PlatformServiceHandler) error {
+	policy := &networkingv1.NetworkPolicy{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: platform.Namespace, Name: psh.GetServiceName()}, policy); err != nil {
+		return ctrl.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(policy, platform) {
+		return nil
+	}
+	return ctrl.IgnoreNotFound(client.Delete(ctx, policy))
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	clientr "github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/networkpolicy"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func TestPlatformServicesNetworkPolicy(t *testing.T) {
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	platform.Spec.Services = &operatorapi.ServicesPlatformSpec{DataIndex: &operatorapi.ServiceSpec{}}
+	monitoringPeer := networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}}}
+	platform.Spec.NetworkIsolation = &operatorapi.NetworkIsolationSpec{Enabled: true, IngressPeers: []networkingv1.NetworkPolicyPeer{monitoringPeer}}
+	t.Setenv(operatorNamespaceEnvVariable, "sonataflow-operator-system")
+	ctrlClient := test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform).Build()
+	cli, err := clientr.FromCtrlClientSchemeAndConfig(ctrlClient, scheme.Scheme, &rest.Config{})
+	assert.NoError(t, err)
+
+	psDI := services.NewDataIndexHandler(platform)
+	assert.NoError(t, createOrUpdateServiceComponents(context.TODO(), cli, platform, psDI))
+	name := types.NamespacedName{Namespace: platform.Namespace, Name: psDI.GetServiceName()}
+	policy := &networkingv1.NetworkPolicy{}
+	assert.NoError(t, cli.Get(context.TODO(), name, policy))
+	assert.True(t, metav1.IsControlledBy(policy, platform))
+	_, selectorLbl := getLabels(platform, psDI)
+	assert.Equal(t, selectorLbl, policy.Spec.PodSelector.MatchLabels)
+	assert.Contains(t, policy.Spec.Ingress[0].From, networkpolicy.WorkflowsPeer())
+	assert.Contains(t, policy.Spec.Ingress[0].From, networkpolicy.KnativeEventingPeer())
+	assert.Contains(t, policy.Spec.Ingress[0].From, monitoringPeer)
+	assert.Contains(t, policy.Spec.Ingress[0].From, networkpolicy.NamespacePeer("sonataflow-operator-system"))
+
+	// disabling the isolation removes the policy
+	platform.Spec.NetworkIsolation.Enabled = false
+	assert.NoError(t, createOrUpdateNetworkPolicy(context.TODO(), cli, platform, psDI))
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), name, &networkingv1.NetworkPolicy{})))
+}
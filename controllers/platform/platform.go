@@ -122,6 +122,42 @@ func GetActivePlatform(ctx context.Context, c ctrl.Client, namespace string) (*o
 	return getLocalPlatform(ctx, c, namespace, true)
 }
 
+// GetEffectivePlatform returns the currently installed active platform in the local namespace, with the fields it
+// inherits from the SonataFlowClusterPlatform. The returned platform is read-only, it must never be persisted.
+func GetEffectivePlatform(ctx context.Context, c ctrl.Client, namespace string) (*operatorapi.SonataFlowPlatform, error) {
+	p, err := GetActivePlatform(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return getEffectivePlatform(p), nil
+}
+
+// FindActivePlatform returns the currently installed active platform in the local namespace, or nil if there's none.
+// Unlike GetActivePlatform, it never creates a default platform.
+func FindActivePlatform(ctx context.Context, c ctrl.Reader, namespace string) (*operatorapi.SonataFlowPlatform, error) {
+	lst, err := listPrimaryPlatforms(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range lst.Items {
+		platform := p // pin
+		if IsActive(&platform) {
+			return &platform, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindEffectivePlatform is FindActivePlatform with the fields the platform inherits from the SonataFlowClusterPlatform.
+// The returned platform is read-only, it must never be persisted.
+func FindEffectivePlatform(ctx context.Context, c ctrl.Reader, namespace string) (*operatorapi.SonataFlowPlatform, error) {
+	p, err := FindActivePlatform(ctx, c, namespace)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	return getEffectivePlatform(p), nil
+}
+
 // getLocalPlatform returns the currently installed platform or any platform existing in local namespace.
 func getLocalPlatform(ctx context.Context, c ctrl.Client, namespace string, active bool) (*operatorapi.SonataFlowPlatform, error) {
 	klog.V(log.D).InfoS("Finding available platforms")
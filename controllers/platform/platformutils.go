@@ -53,6 +53,11 @@ func configureRegistry(ctx context.Context, c client.Client, p *operatorapi.Sona
 		return nil
 	}
 
+	if isInheritedFromClusterPlatform(p, buildRegistryField) {
+		klog.V(log.D).InfoS("Platform registry inherited from the cluster platform")
+		return nil
+	}
+
 	if p.Spec.Build.Config.Registry.Address == "" && p.Status.Cluster == operatorapi.PlatformClusterKubernetes {
 		// try KEP-1755
 		address, err := GetRegistryAddress(ctx, c)
@@ -84,7 +89,7 @@ func setPlatformDefaults(p *operatorapi.SonataFlowPlatform, verbose bool) error
 		p.Spec.Build.Config.Timeout = &metav1.Duration{
 			Duration: d,
 		}
-	} else {
+	} else if !isInheritedFromClusterPlatform(p, buildTimeoutField) {
 		klog.V(log.D).InfoS("SonataFlow Platform setting default build timeout to 5 minutes", "namespace", p.Namespace)
 		p.Spec.Build.Config.Timeout = &metav1.Duration{
 			Duration: 5 * time.Minute,
@@ -93,7 +98,7 @@ func setPlatformDefaults(p *operatorapi.SonataFlowPlatform, verbose bool) error
 
 	if p.Spec.Build.Config.IsStrategyOptionEnabled(kanikoBuildCacheEnabled) {
 		p.Spec.Build.Config.BuildStrategyOptions[kanikoPVCName] = p.Name
-		if len(p.Spec.Build.Config.BaseImage) == 0 {
+		if len(p.Spec.Build.Config.BaseImage) == 0 && !isInheritedFromClusterPlatform(p, buildBaseImageField) {
 			p.Spec.Build.Config.BaseImage = workflowdef.GetDefaultWorkflowBuilderImageTag()
 		}
 	}
@@ -0,0 +1,295 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/cfg"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	kubeutil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+const (
+	provisionedPostgreSQLContainerName = "postgresql"
+	provisionedPostgreSQLUser          = "sonataflow"
+	provisionedPostgreSQLDataPath      = "/var/lib/postgresql/data"
+	provisionedPostgreSQLVolumeName    = "data"
+	provisionedPostgreSQLPortName      = "postgresql"
+	provisionedPostgreSQLPasswordBytes = 16
+)
+
+var defaultProvisionedPostgreSQLStorageSize = resource.MustParse("1Gi")
+
+// createOrUpdateProvisionedPostgreSQL provisions the postgresql database configured in the platform persistence, and
+// reports it in the platform status. The generated credentials are kept across reconciliations.
+func createOrUpdateProvisionedPostgreSQL(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform) error {
+	if platform.Spec.Persistence == nil || platform.Spec.Persistence.ProvisionedPostgreSQL == nil {
+		platform.Status.Persistence = nil
+		return nil
+	}
+	if err := createProvisionedPostgreSQLSecret(ctx, client, platform); err != nil {
+		return err
+	}
+	if err := createProvisionedPostgreSQLPVC(ctx, client, platform); err != nil {
+		return err
+	}
+	statefulSet, err := createOrUpdateProvisionedPostgreSQLStatefulSet(ctx, client, platform)
+	if err != nil {
+		return err
+	}
+	if err = createOrUpdateProvisionedPostgreSQLService(ctx, client, platform); err != nil {
+		return err
+	}
+
+	persistence := platform.GetPersistence().PostgreSQL
+	platform.Status.Persistence = &operatorapi.PlatformPersistenceStatus{
+		PostgreSQL: &operatorapi.ProvisionedPostgreSQLStatus{
+			ServiceRef: *persistence.ServiceRef,
+			SecretRef:  persistence.SecretRef,
+			Ready:      statefulSet.Status.ReadyReplicas > 0,
+		},
+	}
+	return nil
+}
+
+func getProvisionedPostgreSQLLabels(platform *operatorapi.SonataFlowPlatform) (map[string]string, map[string]string) {
+	lbl := map[string]string{
+		workflowproj.LabelApp:     platform.Name,
+		workflowproj.LabelService: platform.GetProvisionedPostgreSQLName(),
+	}
+	selectorLbl := map[string]string{
+		workflowproj.LabelService: platform.GetProvisionedPostgreSQLName(),
+	}
+	return lbl, selectorLbl
+}
+
+// createProvisionedPostgreSQLSecret creates the Secret with the database credentials, the password is only generated
+// when the Secret is created.
+func createProvisionedPostgreSQLSecret(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform) error {
+	lbl, _ := getProvisionedPostgreSQLLabels(platform)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: platform.Namespace,
+			Name:      platform.GetProvisionedPostgreSQLName(),
+			Labels:    lbl,
+		}}
+	if err := controllerutil.SetControllerReference(platform, secret, client.Scheme()); err != nil {
+		return err
+	}
+	if op, err := controllerutil.CreateOrUpdate(ctx, client, secret, func() error {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if len(secret.Data[operatorapi.ProvisionedPostgreSQLUserKey]) == 0 {
+			secret.Data[operatorapi.ProvisionedPostgreSQLUserKey] = []byte(provisionedPostgreSQLUser)
+		}
+		if len(secret.Data[operatorapi.ProvisionedPostgreSQLPasswordKey]) == 0 {
+			password := make([]byte, provisionedPostgreSQLPasswordBytes)
+			if _, err := rand.Read(password); err != nil {
+				return err
+			}
+			secret.Data[operatorapi.ProvisionedPostgreSQLPasswordKey] = []byte(hex.EncodeToString(password))
+		}
+		return nil
+	}); err != nil {
+		return err
+	} else {
+		klog.V(log.I).InfoS("Provisioned PostgreSQL Secret successfully reconciled", "operation", op)
+	}
+	return nil
+}
+
+// createProvisionedPostgreSQLPVC creates the PersistentVolumeClaim storing the database, its spec is immutable once created.
+func createProvisionedPostgreSQLPVC(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform) error {
+	options := platform.Spec.Persistence.ProvisionedPostgreSQL
+	storageSize := defaultProvisionedPostgreSQLStorageSize
+	if options.StorageSize != nil {
+		storageSize = *options.StorageSize
+	}
+	lbl, _ := getProvisionedPostgreSQLLabels(platform)
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: platform.Namespace,
+			Name:      platform.GetProvisionedPostgreSQLName(),
+			Labels:    lbl,
+		}}
+	if err := controllerutil.SetControllerReference(platform, pvc, client.Scheme()); err != nil {
+		return err
+	}
+	if op, err := controllerutil.CreateOrUpdate(ctx, client, pvc, func() error {
+		if kubeutil.IsObjectNew(pvc) {
+			pvc.Spec = corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				StorageClassName: options.StorageClassName,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: storageSize},
+				},
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	} else {
+		klog.V(log.I).InfoS("Provisioned PostgreSQL PersistentVolumeClaim successfully reconciled", "operation", op)
+	}
+	return nil
+}
+
+func createOrUpdateProvisionedPostgreSQLStatefulSet(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform) (*appsv1.StatefulSet, error) {
+	options := platform.Spec.Persistence.ProvisionedPostgreSQL
+	image := options.Image
+	if len(image) == 0 {
+		image = cfg.GetCfg().PostgreSQLImageTag
+	}
+	name := platform.GetProvisionedPostgreSQLName()
+	secretEnv := func(env, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: env,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	readyProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "pg_isready -U \"$POSTGRES_USER\" -d \"$POSTGRES_DB\""}},
+		},
+		InitialDelaySeconds: int32(5),
+		TimeoutSeconds:      int32(5),
+		PeriodSeconds:       int32(10),
+		FailureThreshold:    int32(6),
+	}
+	liveProbe := readyProbe.DeepCopy()
+	liveProbe.InitialDelaySeconds = int32(30)
+	container := corev1.Container{
+		Name:            provisionedPostgreSQLContainerName,
+		Image:           image,
+		ImagePullPolicy: kubeutil.GetImagePullPolicy(image),
+		Env: []corev1.EnvVar{
+			secretEnv("POSTGRES_USER", operatorapi.ProvisionedPostgreSQLUserKey),
+			secretEnv("POSTGRES_PASSWORD", operatorapi.ProvisionedPostgreSQLPasswordKey),
+			{Name: "POSTGRES_DB", Value: platform.GetProvisionedPostgreSQLDatabaseName()},
+			// the volume root might hold a lost+found directory, not accepted by initdb
+			{Name: "PGDATA", Value: provisionedPostgreSQLDataPath + "/pgdata"},
+		},
+		Ports: []corev1.ContainerPort{{
+			Name:          provisionedPostgreSQLPortName,
+			ContainerPort: int32(constants.DefaultPostgreSQLPort),
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		Resources:      options.Resources,
+		ReadinessProbe: readyProbe,
+		LivenessProbe:  liveProbe,
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      provisionedPostgreSQLVolumeName,
+			MountPath: provisionedPostgreSQLDataPath,
+		}},
+	}
+
+	replicas := int32(1)
+	lbl, selectorLbl := getProvisionedPostgreSQLLabels(platform)
+	statefulSetSpec := appsv1.StatefulSetSpec{
+		Replicas:    &replicas,
+		ServiceName: name,
+		Selector:    &metav1.LabelSelector{MatchLabels: selectorLbl},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: lbl},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{container},
+				Volumes: []corev1.Volume{{
+					Name: provisionedPostgreSQLVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+					},
+				}},
+			},
+		},
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: platform.Namespace,
+			Name:      name,
+			Labels:    lbl,
+		}}
+	if err := controllerutil.SetControllerReference(platform, statefulSet, client.Scheme()); err != nil {
+		return nil, err
+	}
+	if op, err := controllerutil.CreateOrUpdate(ctx, client, statefulSet, func() error {
+		// only the template can be updated, selector and service name are immutable
+		if kubeutil.IsObjectNew(statefulSet) {
+			statefulSet.Spec = statefulSetSpec
+		} else {
+			statefulSet.Spec.Template = statefulSetSpec.Template
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	} else {
+		klog.V(log.I).InfoS("Provisioned PostgreSQL StatefulSet successfully reconciled", "operation", op)
+	}
+	return statefulSet, nil
+}
+
+func createOrUpdateProvisionedPostgreSQLService(ctx context.Context, client client.Client, platform *operatorapi.SonataFlowPlatform) error {
+	lbl, selectorLbl := getProvisionedPostgreSQLLabels(platform)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: platform.Namespace,
+			Name:      platform.GetProvisionedPostgreSQLName(),
+			Labels:    lbl,
+		}}
+	if err := controllerutil.SetControllerReference(platform, svc, client.Scheme()); err != nil {
+		return err
+	}
+	if op, err := controllerutil.CreateOrUpdate(ctx, client, svc, func() error {
+		svc.Spec.Selector = selectorLbl
+		svc.Spec.Ports = []corev1.ServicePort{{
+			Name:       provisionedPostgreSQLPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       int32(constants.DefaultPostgreSQLPort),
+			TargetPort: intstr.FromString(provisionedPostgreSQLPortName),
+		}}
+		return nil
+	}); err != nil {
+		return err
+	} else {
+		klog.V(log.I).InfoS("Provisioned PostgreSQL Service successfully reconciled", "operation", op)
+	}
+	return nil
+}
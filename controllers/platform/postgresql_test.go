@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	clientr "github.com/apache/incubator-kie-kogito-serverless-operator/container-builder/client"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func TestProvisionedPostgreSQL(t *testing.T) {
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	storageSize := resource.MustParse("5Gi")
+	platform.Spec.Persistence = &operatorapi.PlatformPersistenceOptionsSpec{
+		ProvisionedPostgreSQL: &operatorapi.ProvisionedPostgreSQLOptions{StorageSize: &storageSize},
+	}
+	platform.Spec.Services = &operatorapi.ServicesPlatformSpec{DataIndex: &operatorapi.ServiceSpec{}}
+	ctrlClient := test.NewSonataFlowClientBuilder().WithRuntimeObjects(platform).Build()
+	cli, err := clientr.FromCtrlClientSchemeAndConfig(ctrlClient, scheme.Scheme, &rest.Config{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, createOrUpdateProvisionedPostgreSQL(context.TODO(), cli, platform))
+	name := types.NamespacedName{Namespace: platform.Namespace, Name: platform.GetProvisionedPostgreSQLName()}
+	secret := &corev1.Secret{}
+	assert.NoError(t, cli.Get(context.TODO(), name, secret))
+	password := string(secret.Data[operatorapi.ProvisionedPostgreSQLPasswordKey])
+	assert.NotEmpty(t, password)
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, cli.Get(context.TODO(), name, pvc))
+	assert.Equal(t, storageSize, pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	statefulSet := &appsv1.StatefulSet{}
+	assert.NoError(t, cli.Get(context.TODO(), name, statefulSet))
+	assert.Equal(t, name.Name, statefulSet.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.NoError(t, cli.Get(context.TODO(), name, &corev1.Service{}))
+
+	assert.NotNil(t, platform.Status.Persistence.PostgreSQL)
+	assert.Equal(t, name.Name, platform.Status.Persistence.PostgreSQL.ServiceRef.Name)
+	assert.Equal(t, name.Name, platform.Status.Persistence.PostgreSQL.SecretRef.Name)
+	assert.False(t, platform.Status.Persistence.PostgreSQL.Ready)
+
+	// the credentials are generated only once
+	assert.NoError(t, createOrUpdateProvisionedPostgreSQL(context.TODO(), cli, platform))
+	assert.NoError(t, cli.Get(context.TODO(), name, secret))
+	assert.Equal(t, password, string(secret.Data[operatorapi.ProvisionedPostgreSQLPasswordKey]))
+
+	// the platform services use their own schema in the provisioned database
+	container := services.NewDataIndexHandler(platform).ConfigurePersistence(&corev1.Container{})
+	env := map[string]corev1.EnvVar{}
+	for _, e := range container.Env {
+		env[e.Name] = e
+	}
+	assert.Equal(t, "jdbc:postgresql://"+name.Name+"."+platform.Namespace+":5432/sonataflow?currentSchema=sonataflow-platform-data-index-service",
+		env["QUARKUS_DATASOURCE_JDBC_URL"].Value)
+	assert.Equal(t, name.Name, env["QUARKUS_DATASOURCE_PASSWORD"].ValueFrom.SecretKeyRef.Name)
+}
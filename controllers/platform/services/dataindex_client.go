@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	dataIndexGraphQLPath = "/graphql"
+	// activeProcessInstancesQuery lists the instances of a given process version that haven't completed nor been aborted
+	activeProcessInstancesQuery = `query ($processId: String, $version: String) {
+  ProcessInstances(where: {processId: {equal: $processId}, version: {equal: $version}, state: {in: [PENDING, ACTIVE, SUSPENDED, ERROR]}}) { id }
+}`
+	// nextScheduledJobQuery gets the next job of a given process to be fired by the Jobs Service
+	nextScheduledJobQuery = `query ($processId: String) {
+  Jobs(where: {processId: {equal: $processId}, status: {in: [SCHEDULED, RETRY]}}, orderBy: {expirationTime: ASC}, pagination: {limit: 1}) { expirationTime }
+}`
+	dataIndexRequestTimeout = 10 * time.Second
+)
+
+// DataIndexClient queries the workflow instances indexed by the Data Index.
+type DataIndexClient interface {
+	// CountActiveProcessInstances returns the number of instances of the given workflow version that are still running.
+	CountActiveProcessInstances(ctx context.Context, processId, version string) (int32, error)
+	// NextScheduledJobTime returns the time the next Jobs Service job of the given workflow is due, nil if none is scheduled.
+	NextScheduledJobTime(ctx context.Context, processId string) (*time.Time, error)
+}
+
+// NewDataIndexClient creates a DataIndexClient for the Data Index reachable at the given base URL.
+func NewDataIndexClient(serviceBaseUrl string) DataIndexClient {
+	return &dataIndexClient{
+		url:    strings.TrimSuffix(serviceBaseUrl, "/") + dataIndexGraphQLPath,
+		client: &http.Client{Timeout: dataIndexRequestTimeout},
+	}
+}
+
+type dataIndexClient struct {
+	url    string
+	client *http.Client
+}
+
+type graphQLRequest struct {
+	Query     string            `json:"query"`
+	Variables map[string]string `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data struct {
+		ProcessInstances []struct {
+			Id string `json:"id"`
+		} `json:"ProcessInstances"`
+		Jobs []struct {
+			ExpirationTime time.Time `json:"expirationTime"`
+		} `json:"Jobs"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (d *dataIndexClient) CountActiveProcessInstances(ctx context.Context, processId, version string) (int32, error) {
+	result, err := d.query(ctx, activeProcessInstancesQuery, map[string]string{"processId": processId, "version": version})
+	if err != nil {
+		return 0, err
+	}
+	return int32(len(result.Data.ProcessInstances)), nil
+}
+
+func (d *dataIndexClient) NextScheduledJobTime(ctx context.Context, processId string) (*time.Time, error) {
+	result, err := d.query(ctx, nextScheduledJobQuery, map[string]string{"processId": processId})
+	if err != nil || len(result.Data.Jobs) == 0 {
+		return nil, err
+	}
+	return &result.Data.Jobs[0].ExpirationTime, nil
+}
+
+func (d *dataIndexClient) query(ctx context.Context, query string, variables map[string]string) (*graphQLResponse, error) {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := d.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the Data Index at %s answered %s", d.url, response.Status)
+	}
+	result := &graphQLResponse{}
+	if err = json.NewDecoder(response.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("the Data Index at %s failed to run the query: %s", d.url, result.Errors[0].Message)
+	}
+	return result, nil
+}
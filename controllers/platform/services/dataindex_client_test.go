@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataIndexClient_CountActiveProcessInstances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dataIndexGraphQLPath, r.URL.Path)
+		request := &graphQLRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		assert.Equal(t, "greeting", request.Variables["processId"])
+		if request.Variables["version"] == "1.0" {
+			_, _ = w.Write([]byte(`{"data":{"ProcessInstances":[{"id":"a"},{"id":"b"}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"ProcessInstances":[]}}`))
+	}))
+	defer server.Close()
+
+	client := NewDataIndexClient(server.URL + "/")
+	count, err := client.CountActiveProcessInstances(context.TODO(), "greeting", "1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), count)
+	count, err = client.CountActiveProcessInstances(context.TODO(), "greeting", "2.0")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), count)
+}
+
+func TestDataIndexClient_CountActiveProcessInstancesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("fail") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors":[{"message":"unknown field"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := NewDataIndexClient(server.URL).CountActiveProcessInstances(context.TODO(), "greeting", "1.0")
+	assert.ErrorContains(t, err, "unknown field")
+	unavailable := &dataIndexClient{url: server.URL + "?fail", client: http.DefaultClient}
+	_, err = unavailable.CountActiveProcessInstances(context.TODO(), "greeting", "1.0")
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestDataIndexClient_NextScheduledJobTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &graphQLRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		if request.Variables["processId"] == "timer" {
+			_, _ = w.Write([]byte(`{"data":{"Jobs":[{"expirationTime":"2024-05-10T10:15:00.000Z"}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"Jobs":[]}}`))
+	}))
+	defer server.Close()
+
+	client := NewDataIndexClient(server.URL)
+	next, err := client.NextScheduledJobTime(context.TODO(), "timer")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 10, 10, 15, 0, 0, time.UTC), next.UTC())
+	next, err = client.NextScheduledJobTime(context.TODO(), "greeting")
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+}
@@ -170,8 +170,16 @@ func GenerateDataIndexWorkflowProperties(workflow *operatorapi.SonataFlow, platf
 			props.Set(constants.KogitoProcessDefinitionsEventsErrorsEnabled, "true")
 			props.Set(constants.KogitoDataIndexHealthCheckEnabled, "true")
 			props.Set(constants.KogitoDataIndexURL, serviceBaseUrl)
-			props.Set(constants.KogitoProcessDefinitionsEventsURL, serviceBaseUrl+constants.KogitoProcessDefinitionsEventsPath)
-			props.Set(constants.KogitoProcessInstancesEventsURL, serviceBaseUrl+constants.KogitoProcessInstancesEventsPath)
+			if backend := operatorapi.GetPlatformEventingBackend(platform); backend.IsKafka() {
+				props.Set(constants.KafkaBootstrapServersProperty, backend.Kafka.BootstrapServers)
+				props.Set(constants.KogitoProcessDefinitionsEventsConnector, constants.SmallRyeKafka)
+				props.Set(constants.KogitoProcessDefinitionsEventsTopic, constants.KogitoProcessDefinitionsTopicName)
+				props.Set(constants.KogitoProcessInstancesEventsConnector, constants.SmallRyeKafka)
+				props.Set(constants.KogitoProcessInstancesEventsTopic, constants.KogitoProcessInstancesTopicName)
+			} else {
+				props.Set(constants.KogitoProcessDefinitionsEventsURL, serviceBaseUrl+constants.KogitoProcessDefinitionsEventsPath)
+				props.Set(constants.KogitoProcessInstancesEventsURL, serviceBaseUrl+constants.KogitoProcessInstancesEventsPath)
+			}
 		}
 	}
 	props.Sort()
@@ -195,10 +203,31 @@ func GenerateJobServiceWorkflowProperties(workflow *operatorapi.SonataFlow, plat
 				props.Set(constants.KogitoJobServiceHealthCheckEnabled, "true")
 			}
 			props.Set(constants.KogitoJobServiceURL, serviceBaseUrl)
-			props.Set(constants.JobServiceRequestEventsURL, serviceBaseUrl+constants.JobServiceJobEventsPath)
+			if backend := operatorapi.GetPlatformEventingBackend(platform); backend.IsKafka() {
+				props.Set(constants.KafkaBootstrapServersProperty, backend.Kafka.BootstrapServers)
+				props.Set(constants.JobServiceRequestEventsConnector, constants.SmallRyeKafka)
+				props.Set(constants.JobServiceRequestEventsTopic, constants.JobServiceRequestEventsTopicName)
+				props.Delete(constants.JobServiceRequestEventsURL)
+			} else {
+				props.Set(constants.JobServiceRequestEventsURL, serviceBaseUrl+constants.JobServiceJobEventsPath)
+			}
 		}
 	}
 	props.Sort()
 
 	return props, nil
 }
+
+// GenerateMonitoringProperties returns the set of application properties exposing the Prometheus metrics endpoint scraped
+// by the Prometheus Operator monitors, if the given monitoring is enabled.
+// Never nil.
+func GenerateMonitoringProperties(monitoring *operatorapi.MonitoringSpec) *properties.Properties {
+	props := properties.NewProperties()
+	if !monitoring.IsEnabled() {
+		return props
+	}
+	props.Set(constants.QuarkusMicrometerEnabled, "true")
+	props.Set(constants.QuarkusMicrometerPrometheusEnabled, "true")
+	props.Set(constants.QuarkusMicrometerPrometheusPath, constants.QuarkusMetricsPath)
+	return props
+}
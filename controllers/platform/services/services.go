@@ -65,6 +65,8 @@ type PlatformServiceHandler interface {
 	// object is the merged result
 	MergeContainerSpec(containerSpec *corev1.Container) (*corev1.Container, error)
 
+	// GetPersistence returns the persistence used by the service, nil if none is configured.
+	GetPersistence() *operatorapi.PersistenceOptionsSpec
 	// ConfigurePersistence sets the persistence's image and environment values when it is defined in the Persistence field of the service, overriding any existing value.
 	ConfigurePersistence(containerSpec *corev1.Container) *corev1.Container
 
@@ -104,11 +106,19 @@ func (d DataIndexHandler) GetContainerName() string {
 }
 
 func (d DataIndexHandler) GetServiceImageName(persistenceType constants.PersistenceType) string {
-	if persistenceType == constants.PersistenceTypePostgreSQL && len(cfg.GetCfg().DataIndexPostgreSQLImageTag) > 0 {
-		return cfg.GetCfg().DataIndexPostgreSQLImageTag
+	var imageTag string
+	switch persistenceType {
+	case constants.PersistenceTypePostgreSQL:
+		imageTag = cfg.GetCfg().DataIndexPostgreSQLImageTag
+	case constants.PersistenceTypeMongoDB:
+		imageTag = cfg.GetCfg().DataIndexMongoDBImageTag
+	case constants.PersistenceTypeInfinispan:
+		imageTag = cfg.GetCfg().DataIndexInfinispanImageTag
+	case constants.PersistenceTypeEphemeral:
+		imageTag = cfg.GetCfg().DataIndexEphemeralImageTag
 	}
-	if persistenceType == constants.PersistenceTypeEphemeral && len(cfg.GetCfg().DataIndexEphemeralImageTag) > 0 {
-		return cfg.GetCfg().DataIndexEphemeralImageTag
+	if len(imageTag) > 0 {
+		return imageTag
 	}
 	// returns "docker.io/apache/incubator-kie-kogito-data-index-<persistence_layer>:<tag>"
 	return fmt.Sprintf("%s-%s-%s:%s", constants.ImageNamePrefix, constants.DataIndexName, persistenceType.String(), version.GetTagVersion())
@@ -176,10 +186,14 @@ func (d DataIndexHandler) GetLocalServiceBaseUrl() string {
 }
 
 func (d DataIndexHandler) GetEnvironmentVariables() []corev1.EnvVar {
+	profile := constants.DataIndexHTTPEventsProfile
+	if operatorapi.GetPlatformEventingBackend(d.platform).IsKafka() {
+		profile = constants.DataIndexKafkaEventsProfile
+	}
 	return []corev1.EnvVar{
 		{
 			Name:  "KOGITO_DATA_INDEX_QUARKUS_PROFILE",
-			Value: "http-events-support",
+			Value: profile,
 		},
 		{
 			Name:  "QUARKUS_HTTP_CORS",
@@ -211,24 +225,28 @@ func (d DataIndexHandler) MergePodSpec(podSpec corev1.PodSpec) (corev1.PodSpec,
 	return *c, err
 }
 
-// hasPostgreSQLConfigured returns true when either the SonataFlow Platform PostgreSQL CR's structure or the one in the Data Index service specification is not nil
-func (d DataIndexHandler) hasPostgreSQLConfigured() bool {
-	return d.IsServiceSetInSpec() &&
-		((d.platform.Spec.Services.DataIndex.Persistence != nil && d.platform.Spec.Services.DataIndex.Persistence.PostgreSQL != nil) ||
-			(d.platform.Spec.Persistence != nil && d.platform.Spec.Persistence.PostgreSQL != nil))
+// GetPersistence returns the persistence configured either in the Data Index service specification or in the
+// SonataFlow Platform, nil if none is configured.
+func (d DataIndexHandler) GetPersistence() *operatorapi.PersistenceOptionsSpec {
+	if !d.IsServiceSetInSpec() {
+		return nil
+	}
+	return persistence.RetrieveConfiguration(d.platform.Spec.Services.DataIndex.Persistence, d.platform.GetPersistence(), d.GetServiceName())
 }
 
 func (d DataIndexHandler) ConfigurePersistence(containerSpec *corev1.Container) *corev1.Container {
-	if d.hasPostgreSQLConfigured() {
-		p := persistence.RetrievePostgreSQLConfiguration(d.platform.Spec.Services.DataIndex.Persistence, d.platform.Spec.Persistence, d.GetServiceName())
-		c := containerSpec.DeepCopy()
-		c.Image = d.GetServiceImageName(constants.PersistenceTypePostgreSQL)
-		c.Env = append(c.Env, persistence.ConfigurePostgreSQLEnv(p.PostgreSQL, d.GetServiceName(), d.platform.Namespace)...)
+	p := d.GetPersistence()
+	persistenceType := persistence.GetPersistenceType(p)
+	if len(persistenceType) == 0 {
+		return containerSpec
+	}
+	c := persistence.ConfigurePersistence(containerSpec, p, d.GetServiceName(), d.platform.Namespace)
+	c.Image = d.GetServiceImageName(persistenceType)
+	if persistenceType == constants.PersistenceTypePostgreSQL {
 		// specific to DataIndex
 		c.Env = append(c.Env, corev1.EnvVar{Name: quarkusHibernateORMDatabaseGeneration, Value: "update"}, corev1.EnvVar{Name: quarkusFlywayMigrateAtStart, Value: "true"})
-		return c
 	}
-	return containerSpec
+	return c
 }
 
 func (d DataIndexHandler) MergeContainerSpec(containerSpec *corev1.Container) (*corev1.Container, error) {
@@ -249,7 +267,13 @@ func (d DataIndexHandler) GetServiceCmName() string {
 func (d DataIndexHandler) GenerateServiceProperties() (*properties.Properties, error) {
 	props := properties.NewProperties()
 	props.Set(constants.KogitoServiceURLProperty, d.GetLocalServiceBaseUrl())
-	props.Set(constants.DataIndexKafkaSmallRyeHealthProperty, "false")
+	if backend := operatorapi.GetPlatformEventingBackend(d.platform); backend.IsKafka() {
+		props.Set(constants.KafkaBootstrapServersProperty, backend.Kafka.BootstrapServers)
+		props.Set(constants.DataIndexKafkaSmallRyeHealthProperty, "true")
+	} else {
+		props.Set(constants.DataIndexKafkaSmallRyeHealthProperty, "false")
+	}
+	props.Merge(GenerateMonitoringProperties(d.platform.Spec.Monitoring))
 	return props, nil
 }
 
@@ -266,11 +290,19 @@ func (j JobServiceHandler) GetContainerName() string {
 }
 
 func (j JobServiceHandler) GetServiceImageName(persistenceType constants.PersistenceType) string {
-	if persistenceType == constants.PersistenceTypePostgreSQL && len(cfg.GetCfg().JobsServicePostgreSQLImageTag) > 0 {
-		return cfg.GetCfg().JobsServicePostgreSQLImageTag
+	var imageTag string
+	switch persistenceType {
+	case constants.PersistenceTypePostgreSQL:
+		imageTag = cfg.GetCfg().JobsServicePostgreSQLImageTag
+	case constants.PersistenceTypeMongoDB:
+		imageTag = cfg.GetCfg().JobsServiceMongoDBImageTag
+	case constants.PersistenceTypeInfinispan:
+		imageTag = cfg.GetCfg().JobsServiceInfinispanImageTag
+	case constants.PersistenceTypeEphemeral:
+		imageTag = cfg.GetCfg().JobsServiceEphemeralImageTag
 	}
-	if persistenceType == constants.PersistenceTypeEphemeral && len(cfg.GetCfg().JobsServiceEphemeralImageTag) > 0 {
-		return cfg.GetCfg().JobsServiceEphemeralImageTag
+	if len(imageTag) > 0 {
+		return imageTag
 	}
 	// returns "docker.io/apache/incubator-kie-kogito-jobs-service-<persistece_layer>:<tag>"
 	return fmt.Sprintf("%s-%s-%s:%s", constants.ImageNamePrefix, constants.JobServiceName, persistenceType.String(), version.GetTagVersion())
@@ -375,25 +407,29 @@ func (j JobServiceHandler) MergeContainerSpec(containerSpec *corev1.Container) (
 	return mergeContainerSpec(containerSpec, &j.platform.Spec.Services.JobService.PodTemplate.Container)
 }
 
-// hasPostgreSQLConfigured returns true when either the SonataFlow Platform PostgreSQL CR's structure or the one in the Job service specification is not nil
-func (j JobServiceHandler) hasPostgreSQLConfigured() bool {
-	return j.IsServiceSetInSpec() &&
-		((j.platform.Spec.Services.JobService.Persistence != nil && j.platform.Spec.Services.JobService.Persistence.PostgreSQL != nil) ||
-			(j.platform.Spec.Persistence != nil && j.platform.Spec.Persistence.PostgreSQL != nil))
+// GetPersistence returns the persistence configured either in the Job service specification or in the
+// SonataFlow Platform, nil if none is configured.
+func (j JobServiceHandler) GetPersistence() *operatorapi.PersistenceOptionsSpec {
+	if !j.IsServiceSetInSpec() {
+		return nil
+	}
+	return persistence.RetrieveConfiguration(j.platform.Spec.Services.JobService.Persistence, j.platform.GetPersistence(), j.GetServiceName())
 }
 
 func (j JobServiceHandler) ConfigurePersistence(containerSpec *corev1.Container) *corev1.Container {
-	if j.hasPostgreSQLConfigured() {
-		c := containerSpec.DeepCopy()
-		c.Image = j.GetServiceImageName(constants.PersistenceTypePostgreSQL)
-		p := persistence.RetrievePostgreSQLConfiguration(j.platform.Spec.Services.JobService.Persistence, j.platform.Spec.Persistence, j.GetServiceName())
-		c.Env = append(c.Env, persistence.ConfigurePostgreSQLEnv(p.PostgreSQL, j.GetServiceName(), j.platform.Namespace)...)
-		// Specific to Job Service
+	p := j.GetPersistence()
+	persistenceType := persistence.GetPersistenceType(p)
+	if len(persistenceType) == 0 {
+		return containerSpec
+	}
+	c := persistence.ConfigurePersistence(containerSpec, p, j.GetServiceName(), j.platform.Namespace)
+	c.Image = j.GetServiceImageName(persistenceType)
+	// Specific to Job Service
+	if persistenceType == constants.PersistenceTypePostgreSQL {
 		c.Env = append(c.Env, corev1.EnvVar{Name: "QUARKUS_FLYWAY_MIGRATE_AT_START", Value: "true"})
-		c.Env = append(c.Env, corev1.EnvVar{Name: "KOGITO_JOBS_SERVICE_LOADJOBERRORSTRATEGY", Value: "FAIL_SERVICE"})
-		return c
 	}
-	return containerSpec
+	c.Env = append(c.Env, corev1.EnvVar{Name: "KOGITO_JOBS_SERVICE_LOADJOBERRORSTRATEGY", Value: "FAIL_SERVICE"})
+	return c
 }
 
 func (j JobServiceHandler) MergePodSpec(podSpec corev1.PodSpec) (corev1.PodSpec, error) {
@@ -407,8 +443,7 @@ func (j JobServiceHandler) GenerateServiceProperties() (*properties.Properties,
 	props.Set(constants.KogitoServiceURLProperty, GenerateServiceURL(constants.KogitoServiceURLProtocol, j.platform.Namespace, j.GetServiceName()))
 	props.Set(constants.JobServiceKafkaSmallRyeHealthProperty, "false")
 	// add data source reactive URL
-	if j.hasPostgreSQLConfigured() {
-		p := persistence.RetrievePostgreSQLConfiguration(j.platform.Spec.Services.JobService.Persistence, j.platform.Spec.Persistence, j.GetServiceName())
+	if p := j.GetPersistence(); persistence.GetPersistenceType(p) == constants.PersistenceTypePostgreSQL {
 		dataSourceReactiveURL, err := generateReactiveURL(p.PostgreSQL, j.GetServiceName(), j.platform.Namespace, constants.DefaultDatabaseName, constants.DefaultPostgreSQLPort)
 		if err != nil {
 			return nil, err
@@ -416,11 +451,19 @@ func (j JobServiceHandler) GenerateServiceProperties() (*properties.Properties,
 		props.Set(constants.JobServiceDataSourceReactiveURL, dataSourceReactiveURL)
 	}
 
-	if isDataIndexEnabled(j.platform) {
+	if backend := operatorapi.GetPlatformEventingBackend(j.platform); backend.IsKafka() {
+		props.Set(constants.KafkaBootstrapServersProperty, backend.Kafka.BootstrapServers)
+		props.Set(constants.JobServiceRequestEventsIncomingConnector, constants.SmallRyeKafka)
+		props.Set(constants.JobServiceRequestEventsIncomingTopic, constants.JobServiceRequestEventsTopicName)
+		if isDataIndexEnabled(j.platform) {
+			props.Set(constants.JobServiceKafkaStatusChangeEvents, "true")
+		}
+	} else if isDataIndexEnabled(j.platform) {
 		di := NewDataIndexHandler(j.platform)
 		props.Set(constants.JobServiceStatusChangeEvents, "true")
 		props.Set(constants.JobServiceStatusChangeEventsURL, di.GetLocalServiceBaseUrl()+"/jobs")
 	}
+	props.Merge(GenerateMonitoringProperties(j.platform.Spec.Monitoring))
 	props.Sort()
 	return props, nil
 }
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -51,3 +52,71 @@ func TestMergeContainerPreservingEnvVars(t *testing.T) {
 	assert.Equal(t, container1.Env[1], corev1.EnvVar{Name: "var2", Value: "value2"})
 	assert.Equal(t, container1.Env[2], corev1.EnvVar{Name: "var3", Value: "value3"})
 }
+
+func TestConfigurePersistence_ServiceMongoDBOverridesPlatformPostgreSQL(t *testing.T) {
+	enabled := true
+	platform := &operatorapi.SonataFlowPlatform{}
+	platform.Name = "platform"
+	platform.Namespace = "sonataflow"
+	platform.Spec.Persistence = &operatorapi.PlatformPersistenceOptionsSpec{
+		PostgreSQL: &operatorapi.PlatformPersistencePostgreSQL{
+			SecretRef: operatorapi.PostgreSQLSecretOptions{Name: "postgresql-secret"},
+			JdbcUrl:   "jdbc:postgresql://postgresql:5432/sonataflow",
+		},
+	}
+	platform.Spec.Services = &operatorapi.ServicesPlatformSpec{
+		DataIndex: &operatorapi.ServiceSpec{
+			Enabled: &enabled,
+			Persistence: &operatorapi.PersistenceOptionsSpec{
+				MongoDB: &operatorapi.PersistenceMongoDB{
+					SecretRef:        operatorapi.MongoDBSecretOptions{Name: "mongodb-secret"},
+					ConnectionString: "mongodb://mongodb:27017",
+				},
+			},
+		},
+		JobService: &operatorapi.ServiceSpec{Enabled: &enabled},
+	}
+
+	di := NewDataIndexHandler(platform)
+	c := di.ConfigurePersistence(&corev1.Container{})
+	assert.Equal(t, di.GetServiceImageName(constants.PersistenceTypeMongoDB), c.Image)
+	assert.Contains(t, c.Image, "data-index-mongodb")
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "QUARKUS_MONGODB_CONNECTION_STRING", Value: "mongodb://mongodb:27017"})
+	assert.NotContains(t, c.Env, corev1.EnvVar{Name: quarkusFlywayMigrateAtStart, Value: "true"})
+
+	js := NewJobServiceHandler(platform)
+	c = js.ConfigurePersistence(&corev1.Container{})
+	assert.Contains(t, c.Image, "jobs-service-postgresql")
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "QUARKUS_DATASOURCE_JDBC_URL", Value: "jdbc:postgresql://postgresql:5432/sonataflow"})
+}
+
+func TestKafkaEventingServices(t *testing.T) {
+	platform := generatePlatform(emptyDataIndexServiceSpec(), emptyJobServiceSpec(), setPlatformName("foo"), setPlatformNamespace("default"))
+	platform.Spec.Eventing = &operatorapi.PlatformEventingSpec{
+		EventingBackendSpec: operatorapi.EventingBackendSpec{
+			Mode:  operatorapi.KafkaEventingMode,
+			Kafka: &operatorapi.KafkaEventingSpec{BootstrapServers: "my-cluster-kafka-bootstrap:9092"},
+		},
+	}
+	enabled := true
+	platform.Spec.Services.DataIndex.Enabled = &enabled
+
+	di := NewDataIndexHandler(platform)
+	assert.Contains(t, di.GetEnvironmentVariables(), corev1.EnvVar{Name: "KOGITO_DATA_INDEX_QUARKUS_PROFILE", Value: constants.DataIndexKafkaEventsProfile})
+	diProps, err := di.GenerateServiceProperties()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", diProps.GetString(constants.KafkaBootstrapServersProperty, ""))
+	assert.Equal(t, "true", diProps.GetString(constants.DataIndexKafkaSmallRyeHealthProperty, ""))
+
+	jsProps, err := NewJobServiceHandler(platform).GenerateServiceProperties()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", jsProps.GetString(constants.KafkaBootstrapServersProperty, ""))
+	assert.Equal(t, constants.SmallRyeKafka, jsProps.GetString(constants.JobServiceRequestEventsIncomingConnector, ""))
+	assert.Equal(t, constants.JobServiceRequestEventsTopicName, jsProps.GetString(constants.JobServiceRequestEventsIncomingTopic, ""))
+	assert.Equal(t, "true", jsProps.GetString(constants.JobServiceKafkaStatusChangeEvents, ""))
+	assert.NotContains(t, jsProps.Keys(), constants.JobServiceStatusChangeEventsURL)
+
+	// without the Kafka cluster the services keep receiving the events over HTTP
+	platform.Spec.Eventing.Kafka = nil
+	assert.Contains(t, NewDataIndexHandler(platform).GetEnvironmentVariables(), corev1.EnvVar{Name: "KOGITO_DATA_INDEX_QUARKUS_PROFILE", Value: constants.DataIndexHTTPEventsProfile})
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+// FieldManager is the field manager owning the fields the operator applies to the workflow objects
+const FieldManager = "sonataflow-operator"
+
+// defaultFieldManager the field manager the API server names after the client user agent, owning the fields written by
+// the previous versions of the operator.
+var defaultFieldManager = strings.Split(rest.DefaultKubernetesUserAgent(), "/")[0]
+
+// csaFieldManagers the field managers of the operator writes not done with server-side apply.
+var csaFieldManagers = sets.New(FieldManager, defaultFieldManager)
+
+// fieldManagerClient writes the objects with the FieldManager, so the fields written by the operator are never seen as
+// owned by another field manager once the server-side apply is enabled.
+type fieldManagerClient struct {
+	client.Client
+}
+
+func (c fieldManagerClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.Client.Create(ctx, obj, append(opts, client.FieldOwner(FieldManager))...)
+}
+
+func (c fieldManagerClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(ctx, obj, append(opts, client.FieldOwner(FieldManager))...)
+}
+
+func (c fieldManagerClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.Client.Patch(ctx, obj, patch, append(opts, client.FieldOwner(FieldManager))...)
+}
+
+// applyObject writes the object with server-side apply. The visitors see the object as a new one, hence only the
+// fields the operator sets are applied, and the fields owned by other field managers are kept. The fields the operator
+// wrote without server-side apply are moved to the FieldManager apply beforehand.
+// When another field manager owns some of the applied fields, the conflict is reported in the FieldsApplied condition
+// of the workflow and the object is left as it is.
+func applyObject(ctx context.Context, workflow *operatorapi.SonataFlow, visitors []MutateVisitor, c client.Client, object client.Object) (client.Object, controllerutil.OperationResult, error) {
+	result := controllerutil.OperationResultNone
+	for _, v := range visitors {
+		if err := v(object)(); err != nil {
+			return nil, result, err
+		}
+	}
+	if err := controllerutil.SetControllerReference(workflow, object, c.Scheme()); err != nil {
+		return nil, result, err
+	}
+	releaseReplicas(workflow, object)
+
+	gvk, err := apiutil.GVKForObject(object, c.Scheme())
+	if err != nil {
+		return nil, result, err
+	}
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	runtimeObj, err := c.Scheme().New(gvk)
+	if err != nil {
+		return nil, result, err
+	}
+	current := runtimeObj.(client.Object)
+	if err = c.Get(ctx, client.ObjectKeyFromObject(object), current); err != nil && !errors.IsNotFound(err) {
+		return nil, result, err
+	}
+	exists := err == nil
+	if exists {
+		if err = upgradeManagedFields(ctx, c, current); err != nil {
+			return nil, result, err
+		}
+	}
+
+	err = c.Patch(ctx, object, client.Apply, client.FieldOwner(FieldManager))
+	if errors.IsNotFound(err) {
+		// the API server creates the objects on apply, clients that don't implement it need them to be created
+		err = c.Create(ctx, object, client.FieldOwner(FieldManager))
+	}
+	if errors.IsConflict(err) && exists {
+		klog.V(log.I).InfoS("Fields owned by another field manager", "kind", gvk.Kind, "name", object.GetName(), "namespace", object.GetNamespace(), "conflict", err.Error())
+		markFieldConflict(workflow, fmt.Sprintf("%s %s: %s", gvk.Kind, object.GetName(), err.Error()))
+		return current, result, nil
+	}
+	if err != nil {
+		return nil, result, err
+	}
+
+	if !exists {
+		result = controllerutil.OperationResultCreated
+	} else if current.GetResourceVersion() != object.GetResourceVersion() {
+		result = controllerutil.OperationResultUpdated
+	}
+	klog.V(log.I).InfoS("Object applied", "result", result, "kind", gvk.String(), "name", object.GetName(), "namespace", object.GetNamespace())
+	return object, result, nil
+}
+
+// upgradeManagedFields moves the fields owned by the operator writes not done with server-side apply, like the ones of
+// the objects created before it was enabled or of the rollouts, to the FieldManager apply, so they never conflict.
+func upgradeManagedFields(ctx context.Context, c client.Client, current client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, csaFieldManagers, FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return c.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// releaseReplicas leaves the replicas of the workflow Deployment to the HorizontalPodAutoscaler, or to whoever scales
+// it, unless the workflow sets them or is hibernated by its idle policy.
+func releaseReplicas(workflow *operatorapi.SonataFlow, object client.Object) {
+	deployment, ok := object.(*appsv1.Deployment)
+	if !ok || deployment.Name != workflow.Name || workflow.Status.IsHibernated() {
+		return
+	}
+	if workflow.Spec.PodTemplate.Replicas == nil || workflow.Spec.PodTemplate.Autoscaling != nil {
+		deployment.Spec.Replicas = nil
+	}
+}
+
+// markFieldConflict adds the conflict to the ones already reported in the FieldsApplied condition.
+func markFieldConflict(workflow *operatorapi.SonataFlow, conflict string) {
+	cond := workflow.Status.GetCondition(api.FieldsAppliedConditionType)
+	if cond.IsFalse() && len(cond.Message) > 0 {
+		conflict = cond.Message + "; " + conflict
+	}
+	workflow.Status.Manager().MarkFalse(api.FieldsAppliedConditionType, api.FieldConflictReason, "%s", conflict)
+}
@@ -0,0 +1,185 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+)
+
+func TestApplyObject(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	pl := test.GetBasePlatformInReadyPhase(t.Name())
+	pl.Spec.ApplyStrategy = v1alpha08.ServerSideApplyStrategy
+	cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, pl).Build()
+	ensurer := NewObjectEnsurerWithPlatform(cl, DeploymentCreator)
+
+	_, result, err := ensurer.Ensure(context.TODO(), workflow, pl, DeploymentMutateVisitor(workflow, pl))
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultCreated, result)
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: workflow.Name, Namespace: workflow.Namespace}, deployment))
+	assert.Nil(t, deployment.Spec.Replicas, "the replicas aren't set by the workflow")
+	assert.Len(t, deployment.OwnerReferences, 1)
+
+	// fields managed by others, like an autoscaler or a sidecar injector
+	deployment.Spec.Replicas = utils.Pint(3)
+	deployment.Spec.Template.Annotations = map[string]string{"sidecar.istio.io/status": "injected"}
+	assert.NoError(t, cl.Update(context.TODO(), deployment))
+
+	_, _, err = ensurer.Ensure(context.TODO(), workflow, pl, DeploymentMutateVisitor(workflow, pl))
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: workflow.Name, Namespace: workflow.Namespace}, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Equal(t, "injected", deployment.Spec.Template.Annotations["sidecar.istio.io/status"])
+}
+
+func TestApplyObjectWithGivenPlatform(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	pl := test.GetBasePlatformInReadyPhase(t.Name())
+	pl.Spec.ApplyStrategy = v1alpha08.ServerSideApplyStrategy
+	// the platform isn't read from the cluster, the one of the reconciliation is used
+	cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*v1alpha08.SonataFlowPlatformList); ok {
+					return fmt.Errorf("unexpected lookup of the platforms")
+				}
+				return c.List(ctx, list, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				assert.Equal(t, types.ApplyPatchType, patch.Type())
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	_, result, err := NewObjectEnsurerWithPlatform(cl, ServiceCreator).Ensure(context.TODO(), workflow, pl, ServiceMutateVisitor(workflow, pl))
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultCreated, result)
+}
+
+func TestApplyObjectUpgradesOperatorFields(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	pl := test.GetBasePlatformInReadyPhase(t.Name())
+	pl.Spec.ApplyStrategy = v1alpha08.ServerSideApplyStrategy
+	existing, err := DeploymentCreator(workflow, pl)
+	assert.NoError(t, err)
+	// written before the server-side apply was enabled, and by a rollout
+	existing.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: defaultFieldManager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{}}}}}`)}},
+		{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:metadata":{"f:annotations":{}}}}}`)}},
+	})
+	cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, pl, existing).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return c.Patch(ctx, obj, patch, opts...)
+				}
+				stored := &appsv1.Deployment{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), stored); err != nil {
+					return err
+				}
+				for _, entry := range stored.GetManagedFields() {
+					if entry.Operation == metav1.ManagedFieldsOperationUpdate {
+						return errors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(),
+							fmt.Errorf("conflict with %q", entry.Manager))
+					}
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	_, _, err = NewObjectEnsurerWithPlatform(cl, DeploymentCreator).Ensure(context.TODO(), workflow, pl, DeploymentMutateVisitor(workflow, pl))
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.GetCondition(api.FieldsAppliedConditionType).IsFalse())
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(existing), deployment))
+	assert.Len(t, deployment.GetManagedFields(), 1)
+	assert.Equal(t, FieldManager, deployment.GetManagedFields()[0].Manager)
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, deployment.GetManagedFields()[0].Operation)
+}
+
+func TestApplyObjectHibernated(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Status.Idle = &v1alpha08.IdleStatus{Hibernated: true}
+	pl := test.GetBasePlatformInReadyPhase(t.Name())
+	pl.Spec.ApplyStrategy = v1alpha08.ServerSideApplyStrategy
+	cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, pl).Build()
+	scaleToZero := func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			object.(*appsv1.Deployment).Spec.Replicas = utils.Pint(0)
+			return nil
+		}
+	}
+
+	_, _, err := NewObjectEnsurerWithPlatform(cl, DeploymentCreator).Ensure(context.TODO(), workflow, pl, DeploymentMutateVisitor(workflow, pl), scaleToZero)
+	assert.NoError(t, err)
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: workflow.Name, Namespace: workflow.Namespace}, deployment))
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas, "the replicas of a hibernated workflow are kept")
+}
+
+func TestApplyObjectConflict(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	pl := test.GetBasePlatformInReadyPhase(t.Name())
+	pl.Spec.ApplyStrategy = v1alpha08.ServerSideApplyStrategy
+	existing, err := DeploymentCreator(workflow, pl)
+	assert.NoError(t, err)
+	cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, pl, existing).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() == types.ApplyPatchType {
+					return errors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(),
+						fmt.Errorf("conflict with \"argocd-controller\": .spec.template.metadata.labels.app"))
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	object, result, err := NewObjectEnsurerWithPlatform(cl, DeploymentCreator).Ensure(context.TODO(), workflow, pl)
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultNone, result)
+	assert.Equal(t, workflow.Name, object.GetName())
+
+	cond := workflow.Status.GetCondition(api.FieldsAppliedConditionType)
+	assert.True(t, cond.IsFalse())
+	assert.Equal(t, api.FieldConflictReason, cond.Reason)
+	assert.Contains(t, cond.Message, "Deployment greeting")
+	assert.Contains(t, cond.Message, "argocd-controller")
+}
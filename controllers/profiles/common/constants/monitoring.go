@@ -0,0 +1,27 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package constants
+
+const (
+	// Quarkus Micrometer configuration exposing the metrics in the Prometheus format.
+	// See: https://quarkus.io/guides/telemetry-micrometer
+	QuarkusMicrometerEnabled           = "quarkus.micrometer.enabled"
+	QuarkusMicrometerPrometheusEnabled = "quarkus.micrometer.export.prometheus.enabled"
+	QuarkusMicrometerPrometheusPath    = "quarkus.micrometer.export.prometheus.path"
+	QuarkusMetricsPath                 = "/q/metrics"
+
+	// MetricsPortName is the name of the Service port used by the Prometheus Operator monitors to scrape the metrics.
+	MetricsPortName = "metrics"
+)
@@ -31,6 +31,11 @@ const (
 	JobServiceURLProtocol            = "http"
 	JobServiceDataSourceReactiveURL  = "quarkus.datasource.reactive.url"
 	JobServiceJobEventsPath          = "/v2/jobs/events"
+	JobServiceRequestEventsTopic     = "mp.messaging.outgoing.kogito-job-service-job-request-events.topic"
+	// JobServiceRequestEventsIncomingConnector configures the Jobs Service to receive the job requests from Kafka.
+	JobServiceRequestEventsIncomingConnector = "mp.messaging.incoming.kogito-job-service-job-request-events.connector"
+	JobServiceRequestEventsIncomingTopic     = "mp.messaging.incoming.kogito-job-service-job-request-events.topic"
+	JobServiceKafkaStatusChangeEvents        = "kogito.jobs-service.kafka.job-status-change-events"
 
 	KogitoProcessInstancesEventsURL             = "mp.messaging.outgoing.kogito-processinstances-events.url"
 	KogitoProcessInstancesEventsEnabled         = "kogito.events.processinstances.enabled"
@@ -40,6 +45,10 @@ const (
 	KogitoProcessDefinitionsEventsErrorsEnabled = "kogito.events.processdefinitions.errors.propagate"
 	KogitoProcessDefinitionsEventsPath          = "/definitions"
 	KogitoUserTasksEventsEnabled                = "kogito.events.usertasks.enabled"
+	KogitoProcessInstancesEventsConnector       = "mp.messaging.outgoing.kogito-processinstances-events.connector"
+	KogitoProcessInstancesEventsTopic           = "mp.messaging.outgoing.kogito-processinstances-events.topic"
+	KogitoProcessDefinitionsEventsConnector     = "mp.messaging.outgoing.kogito-processdefinitions-events.connector"
+	KogitoProcessDefinitionsEventsTopic         = "mp.messaging.outgoing.kogito-processdefinitions-events.topic"
 	// KogitoDataIndexHealthCheckEnabled configures if a workflow must check for the data index availability as part
 	// of its start health check.
 	KogitoDataIndexHealthCheckEnabled = "kogito.data-index.health-enabled"
@@ -55,6 +64,17 @@ const (
 	DataIndexKafkaSmallRyeHealthProperty  = `quarkus.smallrye-health.check."io.quarkus.kafka.client.health.KafkaHealthCheck".enabled`
 	JobServiceKafkaSmallRyeHealthProperty = `quarkus.smallrye-health.check."org.kie.kogito.jobs.service.messaging.http.health.knative.KSinkInjectionHealthCheck".enabled`
 
+	// Kafka topics the workflows and the platform services exchange their events through, in the kafka eventing mode.
+	KogitoProcessInstancesTopicName   = "kogito-processinstances-events"
+	KogitoProcessDefinitionsTopicName = "kogito-processdefinitions-events"
+	KogitoJobsTopicName               = "kogito-jobs-events"
+	JobServiceRequestEventsTopicName  = "kogito-job-service-job-request-events"
+
+	// DataIndexHTTPEventsProfile and DataIndexKafkaEventsProfile are the Quarkus profiles of the Data Index receiving the
+	// events over HTTP or from Kafka.
+	DataIndexHTTPEventsProfile  = "http-events-support"
+	DataIndexKafkaEventsProfile = "kafka-events-support"
+
 	DataIndexServiceName = "data-index-service"
 	JobServiceName       = "jobs-service"
 	ImageNamePrefix      = "docker.io/apache/incubator-kie-kogito"
@@ -62,12 +82,16 @@ const (
 
 	DefaultDatabaseName   string = "sonataflow"
 	DefaultPostgreSQLPort int    = 5432
+	DefaultMongoDBPort    int    = 27017
+	DefaultInfinispanPort int    = 11222
 )
 
 type PersistenceType string
 
 const (
 	PersistenceTypePostgreSQL PersistenceType = "postgresql"
+	PersistenceTypeMongoDB    PersistenceType = "mongodb"
+	PersistenceTypeInfinispan PersistenceType = "infinispan"
 	PersistenceTypeEphemeral  PersistenceType = "ephemeral"
 )
 
@@ -23,4 +23,20 @@ const (
 	KnativeHealthEnabled                     = "org.kie.kogito.addons.knative.eventing.health-enabled"
 	KnativeInjectedEnvVar                    = "${K_SINK}"
 	KnativeEventingBrokerDefault             = "default"
+
+	SmallRyeKafka                 = "smallrye-kafka"
+	KafkaBootstrapServersProperty = "kafka.bootstrap.servers"
+	// KafkaIncomingEventsConnector format of the connector of the channel consuming the events of a given type.
+	KafkaIncomingEventsConnector = "mp.messaging.incoming.%s.connector"
+	KafkaIncomingEventsTopic     = "mp.messaging.incoming.%s.topic"
+	KafkaIncomingEventsGroupID   = "mp.messaging.incoming.%s.group.id"
+	// KafkaOutgoingEventsConnector format of the connector of the channel producing the events of a given type.
+	KafkaOutgoingEventsConnector = "mp.messaging.outgoing.%s.connector"
+	KafkaOutgoingEventsTopic     = "mp.messaging.outgoing.%s.topic"
+
+	// SecretPropertiesVolumeName volume holding the Secret-backed workflow properties delivered as files.
+	SecretPropertiesVolumeName = "workflow-secret-properties"
+	SecretPropertiesMountPath  = "/deployments/secrets"
+	// SecretPropertiesFileLocations the SmallRye Config file system config source directories, every file is a property.
+	SecretPropertiesFileLocations = "smallrye.config.source.file.locations"
 )
@@ -72,7 +72,13 @@ func (d *deploymentHandler) getDeployment(ctx context.Context, workflow *operato
 			}
 			return nil, err
 		}
-		deploymentName = ksvc.Status.LatestCreatedRevisionName + knativeDeploymentSuffix
+		revision := ksvc.Status.LatestCreatedRevisionName
+		// while a rollout is verifying a new revision, or after rolling it back, the workflow is served by the stable one
+		if rollout := workflow.Status.Rollout; rollout != nil && len(rollout.StableRevision) > 0 &&
+			(rollout.Phase == operatorapi.RolloutPhaseProgressing || rollout.Phase == operatorapi.RolloutPhaseRolledBack) {
+			revision = rollout.StableRevision
+		}
+		deploymentName = revision + knativeDeploymentSuffix
 	}
 	deployment := &appsv1.Deployment{}
 	if err := d.c.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: deploymentName}, deployment); err != nil {
@@ -92,7 +98,7 @@ func (d *deploymentHandler) RolloutDeployment(ctx context.Context, workflow *ope
 	if err = kubeutil.MarkDeploymentToRollout(deployment); err != nil {
 		return err
 	}
-	return d.c.Update(ctx, deployment)
+	return d.c.Update(ctx, deployment, client.FieldOwner(FieldManager))
 }
 
 func (d *deploymentHandler) SyncDeploymentStatus(ctx context.Context, workflow *operatorapi.SonataFlow) (ctrl.Result, error) {
@@ -23,6 +23,10 @@ import (
 	"context"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -35,7 +39,7 @@ var _ ObjectEnsurer = &noopObjectEnsurer{}
 var _ ObjectsEnsurer = &defaultObjectsEnsurer{}
 
 type ObjectEnsurer interface {
-	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error)
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error)
 }
 type ObjectEnsurerWithPlatform interface {
 	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error)
@@ -74,14 +78,14 @@ type defaultObjectEnsurer struct {
 	creator ObjectCreator
 }
 
-func (d *defaultObjectEnsurer) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error) {
+func (d *defaultObjectEnsurer) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error) {
 	result := controllerutil.OperationResultNone
 
 	object, err := d.creator(workflow)
 	if err != nil || object == nil {
 		return nil, result, err
 	}
-	return ensureObject(ctx, workflow, visitors, result, d.c, object)
+	return ensureObject(ctx, workflow, pl, visitors, d.c, object)
 }
 
 // defaultObjectEnsurerWithPlatform is the equivalent of defaultObjectEnsurer for resources that require a reference to the SonataFlowPlatform
@@ -97,19 +101,7 @@ func (d *defaultObjectEnsurerWithPlatform) Ensure(ctx context.Context, workflow
 	if err != nil {
 		return nil, result, err
 	}
-	if result, err = controllerutil.CreateOrPatch(ctx, d.c, object,
-		func() error {
-			for _, v := range visitors {
-				if visitorErr := v(object)(); visitorErr != nil {
-					return visitorErr
-				}
-			}
-			return controllerutil.SetControllerReference(workflow, object, d.c.Scheme())
-		}); err != nil {
-		return nil, result, err
-	}
-	klog.V(log.I).InfoS("Object operation finalized", "result", result, "kind", object.GetObjectKind().GroupVersionKind().String(), "name", object.GetName(), "namespace", object.GetNamespace())
-	return object, result, nil
+	return ensureObject(ctx, workflow, pl, visitors, d.c, object)
 }
 
 // NewNoopObjectEnsurer see noopObjectEnsurer
@@ -121,14 +113,14 @@ func NewNoopObjectEnsurer() ObjectEnsurer {
 type noopObjectEnsurer struct {
 }
 
-func (d *noopObjectEnsurer) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error) {
+func (d *noopObjectEnsurer) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) (client.Object, controllerutil.OperationResult, error) {
 	result := controllerutil.OperationResultNone
 	return nil, result, nil
 }
 
 // ObjectsEnsurer is an ensurer to apply multiple objects
 type ObjectsEnsurer interface {
-	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, visitors ...MutateVisitor) []ObjectEnsurerResult
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) []ObjectEnsurerResult
 }
 
 type ObjectEnsurerResult struct {
@@ -150,7 +142,7 @@ type defaultObjectsEnsurer struct {
 	creator ObjectsCreator
 }
 
-func (d *defaultObjectsEnsurer) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, visitors ...MutateVisitor) []ObjectEnsurerResult {
+func (d *defaultObjectsEnsurer) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) []ObjectEnsurerResult {
 	result := controllerutil.OperationResultNone
 
 	objects, err := d.creator(workflow)
@@ -159,7 +151,7 @@ func (d *defaultObjectsEnsurer) Ensure(ctx context.Context, workflow *operatorap
 	}
 	var ensureResult []ObjectEnsurerResult
 	for _, object := range objects {
-		ensureObject, c, err := ensureObject(ctx, workflow, visitors, result, d.c, object)
+		ensureObject, c, err := ensureObject(ctx, workflow, pl, visitors, d.c, object)
 		ensureResult = append(ensureResult, ObjectEnsurerResult{ensureObject, c, err})
 		if err != nil {
 			return ensureResult
@@ -168,8 +160,45 @@ func (d *defaultObjectsEnsurer) Ensure(ctx context.Context, workflow *operatorap
 	return ensureResult
 }
 
-func ensureObject(ctx context.Context, workflow *operatorapi.SonataFlow, visitors []MutateVisitor, result controllerutil.OperationResult, c client.Client, object client.Object) (client.Object, controllerutil.OperationResult, error) {
-	if result, err := controllerutil.CreateOrPatch(ctx, c, object,
+// ObjectsEnsurerWithPlatform is the equivalent of ObjectsEnsurer for resources that require a reference to the SonataFlowPlatform
+type ObjectsEnsurerWithPlatform interface {
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) []ObjectEnsurerResult
+}
+
+func NewObjectsEnsurerWithPlatform(client client.Client, creator ObjectsCreatorWithPlatform) ObjectsEnsurerWithPlatform {
+	return &defaultObjectsEnsurerWithPlatform{
+		c:       client,
+		creator: creator,
+	}
+}
+
+type defaultObjectsEnsurerWithPlatform struct {
+	c       client.Client
+	creator ObjectsCreatorWithPlatform
+}
+
+func (d *defaultObjectsEnsurerWithPlatform) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors ...MutateVisitor) []ObjectEnsurerResult {
+	objects, err := d.creator(workflow, pl)
+	if err != nil {
+		return []ObjectEnsurerResult{{nil, controllerutil.OperationResultNone, err}}
+	}
+	var ensureResult []ObjectEnsurerResult
+	for _, object := range objects {
+		ensureObject, c, err := ensureObject(ctx, workflow, pl, visitors, d.c, object)
+		ensureResult = append(ensureResult, ObjectEnsurerResult{ensureObject, c, err})
+		if err != nil {
+			return ensureResult
+		}
+	}
+	return ensureResult
+}
+
+// ensureObject writes the object with the apply strategy configured in the platform.
+func ensureObject(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, visitors []MutateVisitor, c client.Client, object client.Object) (client.Object, controllerutil.OperationResult, error) {
+	if pl.IsServerSideApplyEnabled() {
+		return applyObject(ctx, workflow, visitors, c, object)
+	}
+	result, err := controllerutil.CreateOrPatch(ctx, fieldManagerClient{c}, object,
 		func() error {
 			for _, v := range visitors {
 				if visitorErr := v(object)(); visitorErr != nil {
@@ -177,9 +206,29 @@ func ensureObject(ctx context.Context, workflow *operatorapi.SonataFlow, visitor
 				}
 			}
 			return controllerutil.SetControllerReference(workflow, object, c.Scheme())
-		}); err != nil {
+		})
+	if err != nil {
 		return nil, result, err
 	}
 	klog.V(log.I).InfoS("Object operation finalized", "result", result, "kind", object.GetObjectKind().GroupVersionKind().String(), "name", object.GetName(), "namespace", object.GetNamespace())
 	return object, result, nil
 }
+
+// removeControlledObject deletes the given object of the workflow, if it exists and is controlled by the workflow.
+// Returns true if the object has been deleted.
+func removeControlledObject(ctx context.Context, c client.Client, workflow *operatorapi.SonataFlow, object client.Object) (bool, error) {
+	if err := c.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, object); err != nil {
+		// optional APIs, like the Gateway API, might not be installed in the cluster
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return false, nil
+		}
+		return false, client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(object, workflow) {
+		return false, nil
+	}
+	if err := c.Delete(ctx, object); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return true, nil
+}
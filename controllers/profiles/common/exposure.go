@@ -0,0 +1,165 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+)
+
+var _ ExposureHandler = &exposureObjectManager{}
+
+// ExposureHandler exposes the workflow outside the cluster as configured in its exposure spec.
+type ExposureHandler interface {
+	// Ensure creates the Ingress or the HTTPRoute exposing the workflow, removes the one no longer configured,
+	// and sets the workflow status endpoint to the exposed URL.
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform) ([]client.Object, error)
+}
+
+type exposureObjectManager struct {
+	ingress   ObjectEnsurer
+	httpRoute ObjectEnsurer
+	*StateSupport
+}
+
+func NewExposureHandler(support *StateSupport) ExposureHandler {
+	return &exposureObjectManager{
+		ingress:      NewObjectEnsurer(support.C, IngressCreator),
+		httpRoute:    NewObjectEnsurer(support.C, HTTPRouteCreator),
+		StateSupport: support,
+	}
+}
+
+func (e *exposureObjectManager) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
+	exposure := workflow.Spec.Exposure
+	// Knative Serving exposes the workflow by itself
+	if exposure == nil || workflow.IsKnativeDeployment() {
+		removedIngress, err := removeControlledObject(ctx, e.C, workflow, &networkingv1.Ingress{})
+		if err != nil {
+			return nil, err
+		}
+		removedRoute, err := removeControlledObject(ctx, e.C, workflow, &gatewayv1beta1.HTTPRoute{})
+		if err != nil {
+			return nil, err
+		}
+		if removedIngress || removedRoute {
+			workflow.Status.Endpoint = nil
+		}
+		return nil, nil
+	}
+
+	if exposure.GetType() == operatorapi.HTTPRouteExposureType {
+		if _, err := removeControlledObject(ctx, e.C, workflow, &networkingv1.Ingress{}); err != nil {
+			return nil, err
+		}
+		route, _, err := e.httpRoute.Ensure(ctx, workflow, pl, HTTPRouteMutateVisitor(workflow))
+		if err != nil {
+			return nil, err
+		}
+		if workflow.Status.Endpoint, err = e.httpRouteEndpoint(ctx, workflow, route.(*gatewayv1beta1.HTTPRoute)); err != nil {
+			return nil, err
+		}
+		return []client.Object{route}, nil
+	}
+
+	if _, err := removeControlledObject(ctx, e.C, workflow, &gatewayv1beta1.HTTPRoute{}); err != nil {
+		return nil, err
+	}
+	ingress, _, err := e.ingress.Ensure(ctx, workflow, pl, IngressMutateVisitor(workflow))
+	if err != nil {
+		return nil, err
+	}
+	workflow.Status.Endpoint = ingressEndpoint(workflow, ingress.(*networkingv1.Ingress))
+	return []client.Object{ingress}, nil
+}
+
+// ingressEndpoint gets the URL of the workflow exposed by the given Ingress. When the exposure has no host, the address
+// published by the ingress controller is used, nil is returned until there's one.
+func ingressEndpoint(workflow *operatorapi.SonataFlow, ingress *networkingv1.Ingress) *apis.URL {
+	host := workflow.Spec.Exposure.Host
+	if len(host) == 0 {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if host = lb.Hostname; len(host) == 0 {
+				host = lb.IP
+			}
+			if len(host) > 0 {
+				break
+			}
+		}
+	}
+	if len(host) == 0 {
+		return nil
+	}
+	url := apis.HTTP(host)
+	if len(ingress.Spec.TLS) > 0 {
+		url = apis.HTTPS(host)
+	}
+	url.Path = workflow.Spec.Exposure.GetPath()
+	return url
+}
+
+// httpRouteEndpoint gets the URL of the workflow exposed by the given HTTPRoute. The scheme and the port are taken from
+// the Gateway listener the route is attached to, and the address published by the Gateway is used when the exposure has
+// no host. Returns nil until the URL can be determined.
+func (e *exposureObjectManager) httpRouteEndpoint(ctx context.Context, workflow *operatorapi.SonataFlow, route *gatewayv1beta1.HTTPRoute) (*apis.URL, error) {
+	exposure := workflow.Spec.Exposure
+	gateway := &gatewayv1beta1.Gateway{}
+	gatewayKey := types.NamespacedName{Namespace: exposure.Gateway.Namespace, Name: exposure.Gateway.Name}
+	if len(gatewayKey.Namespace) == 0 {
+		gatewayKey.Namespace = route.Namespace
+	}
+	if err := e.C.Get(ctx, gatewayKey, gateway); err != nil {
+		if err = client.IgnoreNotFound(err); err != nil {
+			return nil, err
+		}
+		// the route isn't attached yet, but the host is enough to know where the workflow is going to be
+		if len(exposure.Host) == 0 {
+			return nil, nil
+		}
+		url := apis.HTTP(exposure.Host)
+		url.Path = exposure.GetPath()
+		return url, nil
+	}
+
+	host := exposure.Host
+	if len(host) == 0 && len(gateway.Status.Addresses) > 0 {
+		host = gateway.Status.Addresses[0].Value
+	}
+	if len(host) == 0 {
+		return nil, nil
+	}
+	scheme, port := "http", gatewayv1beta1.PortNumber(80)
+	for _, listener := range gateway.Spec.Listeners {
+		if len(exposure.Gateway.SectionName) == 0 || string(listener.Name) == exposure.Gateway.SectionName {
+			if listener.Protocol == gatewayv1beta1.HTTPSProtocolType {
+				scheme = "https"
+			}
+			port = listener.Port
+			break
+		}
+	}
+	if (scheme == "http" && port != 80) || (scheme == "https" && port != 443) {
+		host = fmt.Sprintf("%s:%d", host, port)
+	}
+	return &apis.URL{Scheme: scheme, Host: host, Path: exposure.GetPath()}, nil
+}
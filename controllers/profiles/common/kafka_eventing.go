@@ -0,0 +1,114 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+var kafkaTopicGVK = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "KafkaTopic"}
+
+var invalidKafkaTopicNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+const kafkaTopicClusterLabel = "strimzi.io/cluster"
+
+// KafkaTopicsCreator creates the Strimzi KafkaTopics of the events of the workflow, and of the events sent to the
+// Data Index and the Jobs Service when the platform services use Kafka. Topics whose names only differ by characters
+// not allowed in a KafkaTopic name share the same KafkaTopic: the first one is created and the others are reported.
+func KafkaTopicsCreator(workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, kafka *operatorapi.KafkaEventingSpec) []*unstructured.Unstructured {
+	if kafka == nil || kafka.Topics == nil {
+		return nil
+	}
+	var topics []string
+	for _, event := range workflow.Spec.Flow.Events {
+		topics = append(topics, operatorapi.GetEventTopic(workflow, event.Name, event.Type))
+	}
+	if operatorapi.GetPlatformEventingBackend(pl).IsKafka() && workflow.Status.Services != nil {
+		if workflow.Status.Services.DataIndexRef != nil {
+			topics = append(topics, constants.KogitoProcessInstancesTopicName, constants.KogitoProcessDefinitionsTopicName)
+		}
+		if workflow.Status.Services.JobServiceRef != nil {
+			topics = append(topics, constants.JobServiceRequestEventsTopicName)
+		}
+		if workflow.Status.Services.DataIndexRef != nil && workflow.Status.Services.JobServiceRef != nil {
+			// the Jobs Service sends the job status changes to the Data Index
+			topics = append(topics, constants.KogitoJobsTopicName)
+		}
+	}
+
+	var kafkaTopics []*unstructured.Unstructured
+	names := map[string]string{}
+	for _, topic := range topics {
+		name := strings.Trim(invalidKafkaTopicNameChars.ReplaceAllString(strings.ToLower(topic), "-"), ".-")
+		if previous, exists := names[name]; exists {
+			if previous != topic {
+				klog.V(log.W).InfoS("Topics sharing the same KafkaTopic name, only the first one is created",
+					"workflow", workflow.Name, "name", name, "created", previous, "skipped", topic)
+			}
+			continue
+		}
+		names[name] = topic
+		spec := map[string]interface{}{"topicName": topic}
+		if kafka.Topics.Partitions != nil {
+			spec["partitions"] = int64(*kafka.Topics.Partitions)
+		}
+		if kafka.Topics.Replicas != nil {
+			spec["replicas"] = int64(*kafka.Topics.Replicas)
+		}
+		kafkaTopic := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		kafkaTopic.SetGroupVersionKind(kafkaTopicGVK)
+		kafkaTopic.SetName(name)
+		kafkaTopic.SetNamespace(workflow.Namespace)
+		kafkaTopic.SetLabels(map[string]string{kafkaTopicClusterLabel: kafka.Topics.Cluster})
+		kafkaTopics = append(kafkaTopics, kafkaTopic)
+	}
+	return kafkaTopics
+}
+
+// ensureKafkaTopics creates the missing KafkaTopics. The topics can be shared by many workflows and deleting a
+// KafkaTopic deletes the topic data, so they are neither owned by the workflow nor updated.
+func ensureKafkaTopics(ctx context.Context, c client.Client, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform, kafka *operatorapi.KafkaEventingSpec) error {
+	for _, kafkaTopic := range KafkaTopicsCreator(workflow, pl, kafka) {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(kafkaTopicGVK)
+		err := c.Get(ctx, client.ObjectKeyFromObject(kafkaTopic), current)
+		if meta.IsNoMatchError(err) {
+			klog.V(log.I).InfoS("Strimzi KafkaTopic API is not installed, skipping the creation of the topics")
+			return nil
+		} else if err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+		if err = c.Create(ctx, kafkaTopic); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		klog.V(log.I).InfoS("KafkaTopic created", "name", kafkaTopic.GetName(), "namespace", kafkaTopic.GetNamespace())
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+)
+
+func TestKafkaTopicsCreator(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	workflow.Spec.Eventing = &operatorapi.EventingSpec{
+		Events: []operatorapi.ConsumedEventSpec{{Name: "VetAppointmentRequestReceived", Topic: "Vet_Requests"}},
+	}
+	workflow.Status.Services = &operatorapi.PlatformServicesStatus{DataIndexRef: &operatorapi.PlatformServiceRefStatus{Url: "http://data-index"}}
+	kafka := &operatorapi.KafkaEventingSpec{BootstrapServers: "my-cluster-kafka-bootstrap:9092"}
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	platform.Spec.Eventing = &operatorapi.PlatformEventingSpec{
+		EventingBackendSpec: operatorapi.EventingBackendSpec{Mode: operatorapi.KafkaEventingMode, Kafka: kafka},
+	}
+
+	assert.Empty(t, KafkaTopicsCreator(workflow, platform, kafka))
+
+	kafka.Topics = &operatorapi.KafkaTopicsSpec{Cluster: "my-cluster", Partitions: utils.Pint(3)}
+	topics := KafkaTopicsCreator(workflow, platform, kafka)
+	var names []string
+	for _, topic := range topics {
+		names = append(names, topic.GetName())
+		assert.Equal(t, map[string]string{"strimzi.io/cluster": "my-cluster"}, topic.GetLabels())
+		assert.Equal(t, "KafkaTopic", topic.GetKind())
+	}
+	// the topics of the produced and consumed events of the same type are created once
+	assert.Equal(t, []string{"events.vet.appointments", "vet-requests", constants.KogitoProcessInstancesTopicName, constants.KogitoProcessDefinitionsTopicName}, names)
+	topicName, _, _ := unstructured.NestedString(topics[1].Object, "spec", "topicName")
+	assert.Equal(t, "Vet_Requests", topicName)
+	partitions, _, _ := unstructured.NestedInt64(topics[1].Object, "spec", "partitions")
+	assert.Equal(t, int64(3), partitions)
+
+	// the Jobs Service sends its requests and the job status changes through Kafka too
+	workflow.Status.Services.JobServiceRef = &operatorapi.PlatformServiceRefStatus{Url: "http://jobs-service"}
+	names = nil
+	for _, topic := range KafkaTopicsCreator(workflow, platform, kafka) {
+		names = append(names, topic.GetName())
+	}
+	assert.Equal(t, []string{"events.vet.appointments", "vet-requests", constants.KogitoProcessInstancesTopicName, constants.KogitoProcessDefinitionsTopicName,
+		constants.JobServiceRequestEventsTopicName, constants.KogitoJobsTopicName}, names)
+}
+
+func TestEnsureKafkaTopics(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	kafka := &operatorapi.KafkaEventingSpec{BootstrapServers: "my-cluster-kafka-bootstrap:9092", Topics: &operatorapi.KafkaTopicsSpec{Cluster: "my-cluster"}}
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"topicName": "events.vet.appointments", "partitions": int64(10)}}}
+	existing.SetGroupVersionKind(kafkaTopicGVK)
+	existing.SetName("events.vet.appointments")
+	existing.SetNamespace(workflow.Namespace)
+	cli := test.NewSonataFlowClientBuilder().WithObjects(workflow, existing).Build()
+
+	assert.NoError(t, ensureKafkaTopics(context.TODO(), cli, workflow, nil, kafka))
+
+	created := &unstructured.Unstructured{}
+	created.SetGroupVersionKind(kafkaTopicGVK)
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Namespace: workflow.Namespace, Name: "events.vet.appointments.request"}, created))
+	assert.Empty(t, created.GetOwnerReferences())
+	// existing topics are never updated
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(existing), created))
+	partitions, _, _ := unstructured.NestedInt64(created.Object, "spec", "partitions")
+	assert.Equal(t, int64(10), partitions)
+}
@@ -17,7 +17,15 @@ package common
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/knative"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
@@ -28,49 +36,135 @@ import (
 var _ KnativeEventingHandler = &knativeObjectManager{}
 
 type knativeObjectManager struct {
-	sinkBinding ObjectEnsurer
-	trigger     ObjectsEnsurer
+	sinkBinding  ObjectEnsurer
+	trigger      ObjectsEnsurerWithPlatform
+	subscription ObjectsEnsurerWithPlatform
 	*StateSupport
 }
 
 func NewKnativeEventingHandler(support *StateSupport) KnativeEventingHandler {
 	return &knativeObjectManager{
 		sinkBinding:  NewObjectEnsurer(support.C, SinkBindingCreator),
-		trigger:      NewObjectsEnsurer(support.C, TriggersCreator),
+		trigger:      NewObjectsEnsurerWithPlatform(support.C, TriggersCreator),
+		subscription: NewObjectsEnsurerWithPlatform(support.C, SubscriptionsCreator),
 		StateSupport: support,
 	}
 }
 
+// KnativeEventingHandler ensures the objects delivering the events of the workflow: the SinkBinding, Triggers and
+// Subscriptions with the Knative eventing mode, or the Strimzi KafkaTopics with the Kafka eventing mode.
 type KnativeEventingHandler interface {
-	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow) ([]client.Object, error)
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform) ([]client.Object, error)
 }
 
-func (k knativeObjectManager) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow) ([]client.Object, error) {
+func (k knativeObjectManager) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
 	var objs []client.Object
 
+	if backend := operatorapi.GetWorkflowEventingBackend(workflow, pl); backend.IsKafka() {
+		// the events are produced and consumed from Kafka, Knative isn't involved
+		return objs, ensureKafkaTopics(ctx, k.C, workflow, pl, backend.Kafka)
+	}
 	if workflow.Spec.Flow.Events == nil {
 		// skip if no event is found
 		klog.V(log.I).InfoS("skip knative resource creation as no event is found")
-	} else if workflow.Spec.Sink == nil {
-		klog.V(log.I).InfoS("Spec.Sink is not provided")
+	} else if workflow.Spec.Sink == nil && workflow.Spec.Eventing == nil && (pl == nil || pl.Spec.Eventing == nil) {
+		klog.V(log.I).InfoS("Spec.Sink and Spec.Eventing are not provided")
 	} else if knativeAvail, err := knative.GetKnativeAvailability(k.Cfg); err != nil || knativeAvail == nil || !knativeAvail.Eventing {
 		klog.V(log.I).InfoS("Knative Eventing is not installed")
 	} else {
-		// create sinkBinding and trigger
-		sinkBinding, _, err := k.sinkBinding.Ensure(ctx, workflow)
+		// create sinkBinding, triggers and subscriptions
+		sinkBinding, _, err := k.sinkBinding.Ensure(ctx, workflow, pl)
 		if err != nil {
 			return objs, err
 		} else if sinkBinding != nil {
 			objs = append(objs, sinkBinding)
 		}
 
-		triggers := k.trigger.Ensure(ctx, workflow)
-		for _, trigger := range triggers {
-			if trigger.Error != nil {
-				return objs, trigger.Error
+		if err := k.removeMovedConsumers(ctx, workflow, pl); err != nil {
+			return objs, err
+		}
+		consumers := append(k.trigger.Ensure(ctx, workflow, pl), k.subscription.Ensure(ctx, workflow, pl)...)
+		current := map[string]bool{}
+		for _, consumer := range consumers {
+			if consumer.Error != nil {
+				return objs, consumer.Error
 			}
-			objs = append(objs, trigger.Object)
+			objs = append(objs, consumer.Object)
+			current[consumer.GetName()] = true
+		}
+		if err := k.removeStaleConsumers(ctx, workflow, current); err != nil {
+			return objs, err
 		}
 	}
 	return objs, nil
 }
+
+// removeMovedConsumers deletes the Triggers whose Broker changed and the Subscriptions whose Channel changed, to be
+// created again since the Broker of a Trigger and the Channel of a Subscription are immutable.
+func (k knativeObjectManager) removeMovedConsumers(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform) error {
+	triggers, err := TriggersCreator(workflow, pl)
+	if err != nil {
+		return err
+	}
+	subscriptions, err := SubscriptionsCreator(workflow, pl)
+	if err != nil {
+		return err
+	}
+	for _, desired := range append(triggers, subscriptions...) {
+		current := desired.DeepCopyObject().(client.Object)
+		if err = k.C.Get(ctx, client.ObjectKeyFromObject(desired), current); err != nil {
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(current, workflow) || !hasMoved(current, desired) {
+			continue
+		}
+		klog.V(log.I).InfoS("Removing the eventing object to recreate it with its new source", "name", current.GetName())
+		if err = k.C.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasMoved tells if the Broker of the Trigger, or the Channel of the Subscription, differs from the desired one.
+func hasMoved(current, desired client.Object) bool {
+	switch c := current.(type) {
+	case *eventingv1.Trigger:
+		return c.Spec.Broker != desired.(*eventingv1.Trigger).Spec.Broker
+	case *messagingv1.Subscription:
+		d := desired.(*messagingv1.Subscription).Spec.Channel
+		return c.Spec.Channel.APIVersion != d.APIVersion || c.Spec.Channel.Kind != d.Kind || c.Spec.Channel.Name != d.Name
+	}
+	return false
+}
+
+// removeStaleConsumers deletes the Triggers and Subscriptions of the workflow not in current, for example the Trigger
+// of an event now read from a Channel.
+func (k knativeObjectManager) removeStaleConsumers(ctx context.Context, workflow *operatorapi.SonataFlow, current map[string]bool) error {
+	for _, list := range []client.ObjectList{&eventingv1.TriggerList{}, &messagingv1.SubscriptionList{}} {
+		if err := k.C.List(ctx, list, client.InNamespace(workflow.Namespace), client.MatchingLabels{workflowproj.LabelWorkflow: workflow.Name}); err != nil {
+			if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			object := item.(client.Object)
+			if current[object.GetName()] || !metav1.IsControlledBy(object, workflow) {
+				continue
+			}
+			klog.V(log.I).InfoS("Removing stale eventing object", "kind", object.GetObjectKind().GroupVersionKind().Kind, "name", object.GetName())
+			if err := k.C.Delete(ctx, object); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/monitoring"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+var _ MonitoringHandler = &monitoringObjectManager{}
+
+// MonitoringHandler creates the Prometheus Operator monitor scraping the workflow metrics.
+type MonitoringHandler interface {
+	// Ensure creates the ServiceMonitor or the PodMonitor of the workflow when its monitoring is enabled and the
+	// Prometheus Operator is installed, and removes the monitors no longer required.
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) ([]client.Object, error)
+}
+
+type monitoringObjectManager struct {
+	serviceMonitor         ObjectEnsurerWithPlatform
+	podMonitor             ObjectEnsurerWithPlatform
+	prometheusAvailability func(cfg *rest.Config) (bool, error)
+	*StateSupport
+}
+
+func NewMonitoringHandler(support *StateSupport) MonitoringHandler {
+	return &monitoringObjectManager{
+		serviceMonitor:         NewObjectEnsurerWithPlatform(support.C, ServiceMonitorCreator),
+		podMonitor:             NewObjectEnsurerWithPlatform(support.C, PodMonitorCreator),
+		prometheusAvailability: monitoring.GetPrometheusAvailability,
+		StateSupport:           support,
+	}
+}
+
+func (m *monitoringObjectManager) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
+	if !operatorapi.GetWorkflowMonitoring(workflow, plf).IsEnabled() {
+		return nil, m.removeMonitors(ctx, workflow, &monitoringv1.ServiceMonitor{}, &monitoringv1.PodMonitor{})
+	}
+	if avail, err := m.prometheusAvailability(m.Cfg); err != nil || !avail {
+		klog.V(log.I).InfoS("Prometheus Operator is not installed, skipping the workflow monitor", "workflow", workflow.Name, "namespace", workflow.Namespace)
+		return nil, nil
+	}
+
+	// Knative Serving doesn't create a Service selecting the workflow pods, so they are scraped directly
+	if workflow.IsKnativeDeployment() || operatorapi.GetWorkflowMonitoring(workflow, plf).GetType() == operatorapi.PodMonitorType {
+		if err := m.removeMonitors(ctx, workflow, &monitoringv1.ServiceMonitor{}); err != nil {
+			return nil, err
+		}
+		podMonitor, _, err := m.podMonitor.Ensure(ctx, workflow, plf, PodMonitorMutateVisitor(workflow, plf))
+		if err != nil {
+			return nil, err
+		}
+		return []client.Object{podMonitor}, nil
+	}
+
+	if err := m.removeMonitors(ctx, workflow, &monitoringv1.PodMonitor{}); err != nil {
+		return nil, err
+	}
+	serviceMonitor, _, err := m.serviceMonitor.Ensure(ctx, workflow, plf, ServiceMonitorMutateVisitor(workflow, plf))
+	if err != nil {
+		return nil, err
+	}
+	return []client.Object{serviceMonitor}, nil
+}
+
+func (m *monitoringObjectManager) removeMonitors(ctx context.Context, workflow *operatorapi.SonataFlow, monitors ...client.Object) error {
+	for _, monitor := range monitors {
+		if _, err := removeControlledObject(ctx, m.C, workflow, monitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -21,15 +21,22 @@ package common
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/discovery"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/properties"
 	"github.com/imdario/mergo"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	knautoscaling "knative.dev/serving/pkg/apis/autoscaling"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	kubeutil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
@@ -86,7 +93,16 @@ func DeploymentMutateVisitor(workflow *operatorapi.SonataFlow, plf *operatorapi.
 			if err != nil {
 				return err
 			}
-			return EnsureDeployment(original.(*appsv1.Deployment), object.(*appsv1.Deployment))
+			deployment := object.(*appsv1.Deployment)
+			replicas := deployment.Spec.Replicas
+			if err = EnsureDeployment(original.(*appsv1.Deployment), deployment); err != nil {
+				return err
+			}
+			if workflow.Spec.PodTemplate.Autoscaling != nil && replicas != nil {
+				// the HorizontalPodAutoscaler owns the replicas
+				deployment.Spec.Replicas = replicas
+			}
+			return nil
 		}
 	}
 }
@@ -127,6 +143,19 @@ func KServiceMutateVisitor(workflow *operatorapi.SonataFlow, plf *operatorapi.So
 func EnsureKService(original *servingv1.Service, object *servingv1.Service) error {
 	object.Labels = original.GetLabels()
 
+	// the autoscaling annotations are owned by the workflow autoscaling settings
+	for key := range object.Spec.Template.Annotations {
+		if strings.HasPrefix(key, knautoscaling.GroupName+"/") {
+			delete(object.Spec.Template.Annotations, key)
+		}
+	}
+	if len(original.Spec.Template.Annotations) > 0 && object.Spec.Template.Annotations == nil {
+		object.Spec.Template.Annotations = map[string]string{}
+	}
+	for key, value := range original.Spec.Template.Annotations {
+		object.Spec.Template.Annotations[key] = value
+	}
+
 	// Clean up the volumes, they are inherited from original, additional are added by other visitors
 	object.Spec.Template.Spec.Volumes = nil
 	for i := range object.Spec.Template.Spec.Containers {
@@ -137,13 +166,110 @@ func EnsureKService(original *servingv1.Service, object *servingv1.Service) erro
 	return mergo.Merge(&object.Spec.Template.Spec.PodSpec, original.Spec.Template.Spec.PodSpec, mergo.WithOverride)
 }
 
-func ServiceMutateVisitor(workflow *operatorapi.SonataFlow) MutateVisitor {
+// HorizontalPodAutoscalerMutateVisitor guarantees the state of the workflow HorizontalPodAutoscaler
+func HorizontalPodAutoscalerMutateVisitor(workflow *operatorapi.SonataFlow) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := HorizontalPodAutoscalerCreator(workflow)
+			if err != nil {
+				return err
+			}
+			hpa := object.(*autoscalingv2.HorizontalPodAutoscaler)
+			hpa.Labels = original.GetLabels()
+			hpa.Spec = original.(*autoscalingv2.HorizontalPodAutoscaler).Spec
+			return nil
+		}
+	}
+}
+
+// IngressMutateVisitor keeps the workflow Ingress aligned with the exposure configuration.
+func IngressMutateVisitor(workflow *operatorapi.SonataFlow) MutateVisitor {
 	return func(object client.Object) controllerutil.MutateFn {
 		return func() error {
 			if kubeutil.IsObjectNew(object) {
 				return nil
 			}
-			original, err := ServiceCreator(workflow)
+			original, err := IngressCreator(workflow)
+			if err != nil {
+				return err
+			}
+			ingress := object.(*networkingv1.Ingress)
+			ingress.Labels = original.GetLabels()
+			ingress.Annotations = original.GetAnnotations()
+			ingress.Spec = original.(*networkingv1.Ingress).Spec
+			return nil
+		}
+	}
+}
+
+// HTTPRouteMutateVisitor keeps the workflow HTTPRoute aligned with the exposure configuration.
+func HTTPRouteMutateVisitor(workflow *operatorapi.SonataFlow) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := HTTPRouteCreator(workflow)
+			if err != nil {
+				return err
+			}
+			route := object.(*gatewayv1beta1.HTTPRoute)
+			route.Labels = original.GetLabels()
+			route.Annotations = original.GetAnnotations()
+			route.Spec = original.(*gatewayv1beta1.HTTPRoute).Spec
+			return nil
+		}
+	}
+}
+
+// ServiceMonitorMutateVisitor keeps the workflow ServiceMonitor aligned with the monitoring configuration.
+func ServiceMonitorMutateVisitor(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := ServiceMonitorCreator(workflow, plf)
+			if err != nil {
+				return err
+			}
+			serviceMonitor := object.(*monitoringv1.ServiceMonitor)
+			serviceMonitor.Labels = original.GetLabels()
+			serviceMonitor.Spec = original.(*monitoringv1.ServiceMonitor).Spec
+			return nil
+		}
+	}
+}
+
+// PodMonitorMutateVisitor keeps the workflow PodMonitor aligned with the monitoring configuration.
+func PodMonitorMutateVisitor(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := PodMonitorCreator(workflow, plf)
+			if err != nil {
+				return err
+			}
+			podMonitor := object.(*monitoringv1.PodMonitor)
+			podMonitor.Labels = original.GetLabels()
+			podMonitor.Spec = original.(*monitoringv1.PodMonitor).Spec
+			return nil
+		}
+	}
+}
+
+func ServiceMutateVisitor(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := ServiceCreator(workflow, plf)
 			if err != nil {
 				return err
 			}
@@ -177,6 +303,51 @@ func ManagedPropertiesMutateVisitor(ctx context.Context, catalog discovery.Servi
 			managedProps.Data[workflowproj.GetManagedPropertiesFileName(workflow)] = propertyHandler.WithUserProperties(userProperties).
 				WithServiceDiscovery(ctx, catalog).
 				Build()
+			workflow.Status.ServiceDiscovery = propertyHandler.GetServiceDiscoveryResults()
+			workflow.Status.Properties = propertyHandler.GetPropertiesStatus()
+			return nil
+		}
+	}
+}
+
+// SecretPropertiesMutateVisitor delivers the Secret-backed properties of the workflow to the workflow container of a
+// Deployment or a Knative Service, as environment variables or as files mounted in the SecretPropertiesMountPath.
+// A variable explicitly set in the podTemplate takes precedence over the one of the property.
+func SecretPropertiesMutateVisitor(workflow *operatorapi.SonataFlow) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			secretProps := workflow.Spec.Properties.GetSecretProperties()
+			if len(secretProps) == 0 {
+				return nil
+			}
+			var podSpec *corev1.PodSpec
+			if ksvc, ok := object.(*servingv1.Service); ok {
+				podSpec = &ksvc.Spec.Template.Spec.PodSpec
+			} else {
+				podSpec = &object.(*appsv1.Deployment).Spec.Template.Spec
+			}
+			_, idx := kubeutil.GetContainerByName(operatorapi.DefaultContainerName, podSpec)
+			if idx < 0 {
+				return nil
+			}
+
+			if workflow.Spec.Properties.GetSecretsDelivery() == operatorapi.EnvSecretPropertiesDelivery {
+				for _, propVar := range secretProps {
+					kubeutil.AddEnvIfNotPresent(&podSpec.Containers[idx], corev1.EnvVar{
+						Name:      properties.GetPropertyEnvVarName(propVar.Name),
+						ValueFrom: &corev1.EnvVarSource{SecretKeyRef: propVar.ValueFrom.SecretKeyRef},
+					})
+				}
+				return nil
+			}
+
+			secretsVolume := corev1.Volume{Name: constants.SecretPropertiesVolumeName, VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{}}}
+			for _, propVar := range secretProps {
+				ref := propVar.ValueFrom.SecretKeyRef
+				kubeutil.VolumeProjectionAddSecretItem(secretsVolume.Projected, ref.Name, ref.Optional, corev1.KeyToPath{Key: ref.Key, Path: propVar.Name})
+			}
+			kubeutil.AddOrReplaceVolume(podSpec, secretsVolume)
+			kubeutil.AddOrReplaceVolumeMount(idx, podSpec, kubeutil.VolumeMount(constants.SecretPropertiesVolumeName, true, constants.SecretPropertiesMountPath))
 			return nil
 		}
 	}
@@ -185,12 +356,15 @@ func ManagedPropertiesMutateVisitor(ctx context.Context, catalog discovery.Servi
 // RolloutDeploymentIfCMChangedMutateVisitor forces a pod refresh if the workflow definition suffered any changes.
 // This method can be used as an alternative to the Kubernetes ConfigMap refresher.
 //
+// The referencesVersions are the versions of the Secrets and the ConfigMaps referenced by the workflow properties,
+// see properties.GetPropertiesReferencesVersions.
+//
 // See: https://kubernetes.io/docs/concepts/configuration/configmap/#mounted-configmaps-are-updated-automatically
-func RolloutDeploymentIfCMChangedMutateVisitor(workflow *operatorapi.SonataFlow, userPropsCM *corev1.ConfigMap, managedPropsCM *corev1.ConfigMap) MutateVisitor {
+func RolloutDeploymentIfCMChangedMutateVisitor(workflow *operatorapi.SonataFlow, userPropsCM *corev1.ConfigMap, managedPropsCM *corev1.ConfigMap, referencesVersions map[string]string) MutateVisitor {
 	return func(object client.Object) controllerutil.MutateFn {
 		return func() error {
 			deployment := object.(*appsv1.Deployment)
-			err := kubeutil.AnnotateDeploymentConfigChecksum(workflow, deployment, userPropsCM, managedPropsCM)
+			err := kubeutil.AnnotateDeploymentConfigChecksum(workflow, deployment, userPropsCM, managedPropsCM, referencesVersions)
 			return err
 		}
 	}
@@ -0,0 +1,133 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+
+	cncfmodel "github.com/serverlessworkflow/sdk-go/v2/model"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/networkpolicy"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/persistence"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/properties"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/workflowdef"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+var _ NetworkPolicyHandler = &networkPolicyObjectManager{}
+
+// NetworkPolicyHandler creates the NetworkPolicy isolating the workflow pods.
+type NetworkPolicyHandler interface {
+	// Ensure creates the NetworkPolicy of the workflow when the network isolation of the platform is enabled, and
+	// removes it otherwise.
+	Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) ([]client.Object, error)
+}
+
+type networkPolicyObjectManager struct {
+	*StateSupport
+}
+
+func NewNetworkPolicyHandler(support *StateSupport) NetworkPolicyHandler {
+	return &networkPolicyObjectManager{StateSupport: support}
+}
+
+func (n *networkPolicyObjectManager) Ensure(ctx context.Context, workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
+	if plf == nil || !plf.Spec.NetworkIsolation.IsEnabled() {
+		_, err := removeControlledObject(ctx, n.C, workflow, &networkingv1.NetworkPolicy{})
+		return nil, err
+	}
+	desired, err := n.workflowNetworkPolicy(ctx, workflow, plf)
+	if err != nil {
+		return nil, err
+	}
+	policy, _, err := ensureObject(ctx, workflow, plf, []MutateVisitor{networkPolicyMutateVisitor(desired)}, n.C, desired.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	return []client.Object{policy}, nil
+}
+
+// workflowNetworkPolicy allows the traffic of the workflow with the platform services, Knative, its database, the
+// services resolved by the service discovery, and the peers configured in the platform.
+func (n *networkPolicyObjectManager) workflowNetworkPolicy(ctx context.Context, workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) (*networkingv1.NetworkPolicy, error) {
+	isolation := plf.Spec.NetworkIsolation
+	builder := networkpolicy.NewBuilder(n.C, workflow.Namespace)
+
+	if services := workflow.Status.Services; services != nil {
+		// the Jobs Service calls the workflows back when their timers fire, the Data Index to manage their instances
+		for _, ref := range []*operatorapi.PlatformServiceRefStatus{services.DataIndexRef, services.JobServiceRef} {
+			if ref != nil {
+				builder.AllowEgressToAddress(ctx, ref.Url).AllowIngressFromAddress(ctx, ref.Url)
+			}
+		}
+	}
+	if workflow.IsKnativeDeployment() {
+		builder.AllowIngressFrom(networkpolicy.KnativeServingPeer())
+	}
+	backend := operatorapi.GetWorkflowEventingBackend(workflow, plf)
+	if backend.IsKafka() {
+		builder.AllowEgressToAddresses(ctx, backend.Kafka.BootstrapServers)
+	} else {
+		if workflowdef.ContainsEventKind(workflow, cncfmodel.EventKindConsumed) {
+			builder.AllowIngressFrom(networkpolicy.KnativeEventingPeer())
+		}
+		if workflow.Spec.Sink != nil || workflowdef.ContainsEventKind(workflow, cncfmodel.EventKindProduced) {
+			builder.AllowEgressTo(networkpolicy.KnativeEventingPeer())
+		}
+	}
+	if workflow.Spec.PodTemplate.IdlePolicy != nil && len(platform.GetOperatorNamespace()) > 0 {
+		// the operator reads the workflow activity from its metrics
+		builder.AllowIngressFrom(networkpolicy.NamespacePeer(platform.GetOperatorNamespace()))
+	}
+	builder.AllowEgressToPersistence(ctx, persistence.RetrieveWorkflowConfiguration(workflow, plf))
+
+	addresses, err := n.discoveredAddresses(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		builder.AllowEgressToAddress(ctx, address)
+	}
+
+	builder.AllowIngressFrom(isolation.IngressPeers...).AllowEgressTo(isolation.EgressPeers...)
+	return builder.Build(workflow.Name, workflowproj.GetMergedLabels(workflow), map[string]string{workflowproj.LabelWorkflow: workflow.Name}), nil
+}
+
+// discoveredAddresses returns the addresses resolved by the service discovery in the managed properties of the workflow.
+func (n *networkPolicyObjectManager) discoveredAddresses(ctx context.Context, workflow *operatorapi.SonataFlow) ([]string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := n.C.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow)}, cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return properties.GetDiscoveredAddresses(cm.Data[workflowproj.GetManagedPropertiesFileName(workflow)])
+}
+
+func networkPolicyMutateVisitor(desired *networkingv1.NetworkPolicy) MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			policy := object.(*networkingv1.NetworkPolicy)
+			policy.Labels = desired.Labels
+			policy.Spec = desired.Spec
+			return nil
+		}
+	}
+}
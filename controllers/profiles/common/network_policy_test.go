@@ -0,0 +1,88 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/networkpolicy"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+func TestNetworkPolicyHandler_Ensure(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	workflow.Status.Services = &operatorapi.PlatformServicesStatus{DataIndexRef: &operatorapi.PlatformServiceRefStatus{Url: "http://data-index." + workflow.Namespace}}
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	platform.Spec.NetworkIsolation = &operatorapi.NetworkIsolationSpec{Enabled: true}
+	dataIndex := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-index", Namespace: workflow.Namespace},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{workflowproj.LabelService: "data-index"},
+			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+	petstore := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: "shop"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "petstore"},
+			Ports:    []corev1.ServicePort{{Port: 8080}},
+		},
+	}
+	managedProps := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow), Namespace: workflow.Namespace},
+		Data: map[string]string{
+			workflowproj.GetManagedPropertiesFileName(workflow): "org.kie.kogito.addons.discovery.kubernetes\\:services.v1\\/shop\\/petstore = http://petstore.shop:8080\n",
+		},
+	}
+	cli := test.NewSonataFlowClientBuilder().WithObjects(workflow, dataIndex, petstore, managedProps).Build()
+	handler := NewNetworkPolicyHandler(&StateSupport{C: cli})
+
+	objs, err := handler.Ensure(context.TODO(), workflow, platform)
+	assert.NoError(t, err)
+	assert.Len(t, objs, 1)
+	policy := &networkingv1.NetworkPolicy{}
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(objs[0]), policy))
+	assert.Equal(t, map[string]string{workflowproj.LabelWorkflow: workflow.Name}, policy.Spec.PodSelector.MatchLabels)
+	// the workflow consumes and produces events through the Knative broker
+	assert.Contains(t, policy.Spec.Ingress[0].From, networkpolicy.KnativeEventingPeer())
+	var egressSelectors []map[string]string
+	for _, rule := range policy.Spec.Egress {
+		for _, peer := range rule.To {
+			if peer.PodSelector != nil {
+				egressSelectors = append(egressSelectors, peer.PodSelector.MatchLabels)
+			}
+		}
+	}
+	assert.Contains(t, egressSelectors, dataIndex.Spec.Selector)
+	assert.Contains(t, egressSelectors, petstore.Spec.Selector)
+
+	// disabling the isolation removes the policy
+	platform.Spec.NetworkIsolation.Enabled = false
+	objs, err = handler.Ensure(context.TODO(), workflow, platform)
+	assert.NoError(t, err)
+	assert.Empty(t, objs)
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), client.ObjectKeyFromObject(policy), &networkingv1.NetworkPolicy{})))
+}
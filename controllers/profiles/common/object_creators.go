@@ -21,22 +21,30 @@ package common
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/workflowdef"
+	knautoscaling "knative.dev/serving/pkg/apis/autoscaling"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	cncfmodel "github.com/serverlessworkflow/sdk-go/v2/model"
 
 	"github.com/imdario/mergo"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/tracker"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
@@ -60,8 +68,13 @@ type ObjectCreatorWithPlatform func(workflow *operatorapi.SonataFlow, platform *
 // ObjectsCreator creates multiple resources
 type ObjectsCreator func(workflow *operatorapi.SonataFlow) ([]client.Object, error)
 
+// ObjectsCreatorWithPlatform is the func equivalent to ObjectsCreator to use when the resources being created need a reference to the
+// SonataFlowPlatform
+type ObjectsCreatorWithPlatform func(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) ([]client.Object, error)
+
 const (
-	defaultHTTPServicePort = 80
+	defaultHTTPServicePort     = 80
+	defaultHTTPServicePortName = "http"
 
 	// Default deployment health check configuration
 	// See: https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
@@ -140,12 +153,114 @@ func KServiceCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFl
 		return nil, err
 	}
 	kubeutil.AddOrReplaceContainer(operatorapi.DefaultContainerName, *flowContainer, &ksvc.Spec.Template.Spec.PodSpec)
+	ksvc.Spec.Template.Annotations = knativeAutoscalingAnnotations(workflow.Spec.PodTemplate.Autoscaling, flowContainer)
 
 	return ksvc, nil
 }
 
+// knativeAutoscalingAnnotations maps the workflow autoscaling settings to the Knative autoscaling annotations.
+// Knative scales on a single metric, picked in this order: the first custom metric, the CPU target, the memory target.
+func knativeAutoscalingAnnotations(autoscaling *operatorapi.AutoscalingSpec, flowContainer *corev1.Container) map[string]string {
+	if autoscaling == nil {
+		return nil
+	}
+	annotations := map[string]string{
+		knautoscaling.MinScaleAnnotationKey: strconv.Itoa(int(autoscaling.GetMinReplicas())),
+		knautoscaling.MaxScaleAnnotationKey: strconv.Itoa(int(autoscaling.MaxReplicas)),
+	}
+	switch {
+	case len(autoscaling.Metrics) > 0:
+		metric := autoscaling.Metrics[0]
+		annotations[knautoscaling.ClassAnnotationKey] = knautoscaling.HPA
+		if metric.Name == operatorapi.ConcurrencyAutoscalingMetric || metric.Name == operatorapi.RPSAutoscalingMetric {
+			annotations[knautoscaling.ClassAnnotationKey] = knautoscaling.KPA
+		}
+		annotations[knautoscaling.MetricAnnotationKey] = metric.Name
+		annotations[knautoscaling.TargetAnnotationKey] = strconv.FormatFloat(metric.TargetAverageValue.AsApproximateFloat64(), 'f', -1, 64)
+	case autoscaling.TargetCPUUtilizationPercentage != nil:
+		annotations[knautoscaling.ClassAnnotationKey] = knautoscaling.HPA
+		annotations[knautoscaling.MetricAnnotationKey] = knautoscaling.CPU
+		annotations[knautoscaling.TargetAnnotationKey] = strconv.Itoa(int(*autoscaling.TargetCPUUtilizationPercentage))
+	case autoscaling.TargetMemoryUtilizationPercentage != nil:
+		// the Knative memory target is the average memory per pod in Mi, computed from the container request
+		request, ok := flowContainer.Resources.Requests[corev1.ResourceMemory]
+		if !ok {
+			break
+		}
+		annotations[knautoscaling.ClassAnnotationKey] = knautoscaling.HPA
+		annotations[knautoscaling.MetricAnnotationKey] = knautoscaling.Memory
+		target := request.Value() * int64(*autoscaling.TargetMemoryUtilizationPercentage) / 100 / (1024 * 1024)
+		annotations[knautoscaling.TargetAnnotationKey] = strconv.FormatInt(max(target, 1), 10)
+	}
+	return annotations
+}
+
+// HorizontalPodAutoscalerCreator is an objectCreator for the autoscaling/v2 HorizontalPodAutoscaler scaling the workflow
+// Deployment in the kubernetes deployment model.
+func HorizontalPodAutoscalerCreator(workflow *operatorapi.SonataFlow) (client.Object, error) {
+	autoscaling := workflow.Spec.PodTemplate.Autoscaling
+	if autoscaling == nil {
+		return nil, fmt.Errorf("workflow %s has no autoscaling configuration", workflow.Name)
+	}
+	minReplicas := autoscaling.GetMinReplicas()
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    workflowproj.GetMergedLabels(workflow),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       workflow.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+		},
+	}
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, resourceUtilizationMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, resourceUtilizationMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	for i := range autoscaling.Metrics {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: autoscaling.Metrics[i].Name},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &autoscaling.Metrics[i].TargetAverageValue,
+				},
+			},
+		})
+	}
+	return hpa, nil
+}
+
+func resourceUtilizationMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+// getReplicasOrDefault returns the number of replicas of the workflow Deployment. When autoscaling is configured, it's
+// the lower limit of the autoscaler, which owns the replicas from then on.
 func getReplicasOrDefault(workflow *operatorapi.SonataFlow) *int32 {
 	var dReplicas int32 = 1
+	if workflow.Spec.PodTemplate.Autoscaling != nil {
+		dReplicas = max(workflow.Spec.PodTemplate.Autoscaling.GetMinReplicas(), 1)
+		return &dReplicas
+	}
 	if workflow.Spec.PodTemplate.Replicas == nil {
 		return &dReplicas
 	}
@@ -200,11 +315,7 @@ func defaultContainer(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataF
 	if err := mergo.Merge(defaultFlowContainer, workflow.Spec.PodTemplate.Container.ToContainer(), mergo.WithOverride); err != nil {
 		return nil, err
 	}
-	var pper *operatorapi.PlatformPersistenceOptionsSpec
-	if plf != nil && plf.Spec.Persistence != nil {
-		pper = plf.Spec.Persistence
-	}
-	if p := persistence.RetrieveConfiguration(workflow.Spec.Persistence, pper, workflow.Name); p != nil {
+	if p := persistence.RetrieveWorkflowConfiguration(workflow, plf); p != nil {
 		defaultFlowContainer = persistence.ConfigurePersistence(defaultFlowContainer, p, workflow.Name, workflow.Namespace)
 	}
 	// immutable
@@ -226,9 +337,11 @@ func defaultContainer(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataF
 	return defaultFlowContainer, nil
 }
 
-// ServiceCreator is an objectCreator for a basic Service aiming a vanilla Kubernetes Deployment.
-// It maps the default HTTP port (80) to the target Java application webserver on port 8080.
-func ServiceCreator(workflow *operatorapi.SonataFlow) (client.Object, error) {
+// ServiceCreator is an ObjectCreatorWithPlatform for a basic Service aiming a vanilla Kubernetes Deployment.
+// It maps the default HTTP port (80) to the target Java application webserver on port 8080. When the workflow monitoring
+// is enabled, it exposes the same webserver port as the named metrics port scraped by the Prometheus Operator
+// ServiceMonitor.
+func ServiceCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) (client.Object, error) {
 	lbl := workflowproj.GetMergedLabels(workflow)
 
 	service := &corev1.Service{
@@ -240,12 +353,22 @@ func ServiceCreator(workflow *operatorapi.SonataFlow) (client.Object, error) {
 		Spec: corev1.ServiceSpec{
 			Selector: lbl,
 			Ports: []corev1.ServicePort{{
+				Name:       defaultHTTPServicePortName,
 				Protocol:   corev1.ProtocolTCP,
 				Port:       defaultHTTPServicePort,
 				TargetPort: variables.DefaultHTTPWorkflowPortIntStr,
 			}},
 		},
 	}
+	if operatorapi.GetWorkflowMonitoring(workflow, plf).IsEnabled() {
+		// the Prometheus Operator ServiceMonitor scrapes the workflow metrics through this named port
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       constants.MetricsPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       variables.DefaultHTTPWorkflowPortIntStr.IntVal,
+			TargetPort: variables.DefaultHTTPWorkflowPortIntStr,
+		})
+	}
 
 	return service, nil
 }
@@ -262,7 +385,17 @@ func SinkBindingCreator(workflow *operatorapi.SonataFlow) (client.Object, error)
 
 	sink := workflow.Spec.Sink
 
-	// subject must be deployment to inject K_SINK, service won't work
+	// subject must be the podspecable resource running the workflow to inject K_SINK, the core Service won't work
+	subject := tracker.Reference{
+		Name:       workflow.Name,
+		Namespace:  workflow.Namespace,
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+	}
+	if workflow.IsKnativeDeployment() {
+		subject.APIVersion = servingv1.SchemeGroupVersion.String()
+		subject.Kind = "Service"
+	}
 	sinkBinding := &sourcesv1.SinkBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      strings.ToLower(fmt.Sprintf("%s-sb", workflow.Name)),
@@ -274,21 +407,16 @@ func SinkBindingCreator(workflow *operatorapi.SonataFlow) (client.Object, error)
 				Sink: *sink,
 			},
 			BindingSpec: duckv1.BindingSpec{
-				Subject: tracker.Reference{
-					Name:       workflow.Name,
-					Namespace:  workflow.Namespace,
-					APIVersion: "apps/v1",
-					Kind:       "Deployment",
-				},
+				Subject: subject,
 			},
 		},
 	}
 	return sinkBinding, nil
 }
 
-// TriggersCreator is an ObjectsCreator for Triggers.
-// It will create a list of eventingv1.Trigger based on events defined in workflow.
-func TriggersCreator(workflow *operatorapi.SonataFlow) ([]client.Object, error) {
+// TriggersCreator is an ObjectsCreatorWithPlatform for Triggers.
+// It will create a list of eventingv1.Trigger based on the consumed events defined in workflow that aren't read from a Channel.
+func TriggersCreator(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
 	var resultObjects []client.Object
 	lbl := workflowproj.GetMergedLabels(workflow)
 
@@ -299,6 +427,14 @@ func TriggersCreator(workflow *operatorapi.SonataFlow) ([]client.Object, error)
 		if event.Kind == cncfmodel.EventKindProduced {
 			continue
 		}
+		options := operatorapi.GetConsumedEventOptions(workflow, platform, event.Name)
+		if options.Channel != nil {
+			continue
+		}
+		broker := options.Broker
+		if len(broker) == 0 {
+			broker = constants.KnativeEventingBrokerDefault
+		}
 
 		// construct eventingv1.Trigger
 		trigger := &eventingv1.Trigger{
@@ -308,20 +444,12 @@ func TriggersCreator(workflow *operatorapi.SonataFlow) ([]client.Object, error)
 				Labels:    lbl,
 			},
 			Spec: eventingv1.TriggerSpec{
-				Broker: constants.KnativeEventingBrokerDefault,
+				Broker: broker,
 				Filter: &eventingv1.TriggerFilter{
-					Attributes: eventingv1.TriggerFilterAttributes{
-						"type": event.Type,
-					},
-				},
-				Subscriber: duckv1.Destination{
-					Ref: &duckv1.KReference{
-						Name:       workflow.Name,
-						Namespace:  workflow.Namespace,
-						APIVersion: "v1",
-						Kind:       "Service",
-					},
+					Attributes: triggerFilterAttributes(workflow, event),
 				},
+				Subscriber: workflowSubscriber(workflow),
+				Delivery:   deliverySpec(options.Delivery),
 			},
 		}
 		resultObjects = append(resultObjects, trigger)
@@ -329,6 +457,100 @@ func TriggersCreator(workflow *operatorapi.SonataFlow) ([]client.Object, error)
 	return resultObjects, nil
 }
 
+// SubscriptionsCreator is an ObjectsCreatorWithPlatform for Subscriptions.
+// It will create one messagingv1.Subscription for every Channel the consumed events of the workflow are read from,
+// named after the kind and the name of the Channel.
+// The delivery of the Subscription is the one of the first event read from the Channel.
+func SubscriptionsCreator(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
+	var resultObjects []client.Object
+	lbl := workflowproj.GetMergedLabels(workflow)
+
+	subscribed := map[string]bool{}
+	for _, event := range workflow.Spec.Flow.Events {
+		if event.Kind == cncfmodel.EventKindProduced {
+			continue
+		}
+		options := operatorapi.GetConsumedEventOptions(workflow, platform, event.Name)
+		if options.Channel == nil {
+			continue
+		}
+		channel := fmt.Sprintf("%s/%s/%s", options.Channel.APIVersion, options.Channel.Kind, options.Channel.Name)
+		if subscribed[channel] {
+			continue
+		}
+		subscribed[channel] = true
+		subscription := &messagingv1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      strings.ToLower(fmt.Sprintf("%s-%s-%s-subscription", workflow.Name, options.Channel.Kind, options.Channel.Name)),
+				Namespace: workflow.Namespace,
+				Labels:    lbl,
+			},
+			Spec: messagingv1.SubscriptionSpec{
+				Channel:  *options.Channel,
+				Delivery: deliverySpec(options.Delivery),
+			},
+		}
+		subscriber := workflowSubscriber(workflow)
+		subscription.Spec.Subscriber = &subscriber
+		resultObjects = append(resultObjects, subscription)
+	}
+	return resultObjects, nil
+}
+
+// triggerFilterAttributes filters the event type and source, the correlation attributes with a fixed value and the
+// attributes added to the event in the workflow eventing.
+func triggerFilterAttributes(workflow *operatorapi.SonataFlow, event cncfmodel.Event) eventingv1.TriggerFilterAttributes {
+	attributes := eventingv1.TriggerFilterAttributes{
+		"type": event.Type,
+	}
+	if len(event.Source) > 0 {
+		attributes["source"] = event.Source
+	}
+	for _, correlation := range event.Correlation {
+		// values evaluated by the workflow at runtime can't be filtered by the broker
+		if len(correlation.ContextAttributeValue) > 0 && !strings.HasPrefix(correlation.ContextAttributeValue, "${") {
+			attributes[correlation.ContextAttributeName] = correlation.ContextAttributeValue
+		}
+	}
+	if consumed := workflow.Spec.Eventing.GetEvent(event.Name); consumed != nil {
+		for name, value := range consumed.Filter {
+			attributes[name] = value
+		}
+	}
+	return attributes
+}
+
+// workflowSubscriber is the destination of the consumed events, the Knative Service or the Service of the workflow.
+func workflowSubscriber(workflow *operatorapi.SonataFlow) duckv1.Destination {
+	ref := &duckv1.KReference{
+		Name:       workflow.Name,
+		Namespace:  workflow.Namespace,
+		APIVersion: "v1",
+		Kind:       "Service",
+	}
+	if workflow.IsKnativeDeployment() {
+		ref.APIVersion = servingv1.SchemeGroupVersion.String()
+	}
+	return duckv1.Destination{Ref: ref}
+}
+
+func deliverySpec(delivery *operatorapi.EventDeliverySpec) *eventingduckv1.DeliverySpec {
+	if delivery == nil {
+		return nil
+	}
+	spec := &eventingduckv1.DeliverySpec{
+		DeadLetterSink: delivery.DeadLetterSink,
+		Retry:          delivery.Retry,
+		BackoffDelay:   delivery.BackoffDelay,
+		Timeout:        delivery.Timeout,
+	}
+	if delivery.BackoffPolicy != nil {
+		policy := eventingduckv1.BackoffPolicyType(*delivery.BackoffPolicy)
+		spec.BackoffPolicy = &policy
+	}
+	return spec
+}
+
 // OpenShiftRouteCreator is an ObjectCreator for a basic Route for a workflow running on OpenShift.
 // It enables the exposition of the service using an OpenShift Route.
 // See: https://github.com/openshift/api/blob/d170fcdc0fa638b664e4f35f2daf753cb4afe36b/route/v1/route.crd.yaml
@@ -350,3 +572,155 @@ func ManagedPropsConfigMapCreator(workflow *operatorapi.SonataFlow, platform *op
 	}
 	return workflowproj.CreateNewManagedPropsConfigMap(workflow, props), nil
 }
+
+// IngressCreator is an ObjectCreator for the networking.k8s.io/v1 Ingress exposing the workflow Service outside the cluster.
+func IngressCreator(workflow *operatorapi.SonataFlow) (client.Object, error) {
+	exposure := workflow.Spec.Exposure
+	if exposure == nil {
+		return nil, fmt.Errorf("workflow %s has no exposure configured", workflow.Name)
+	}
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        workflow.Name,
+			Namespace:   workflow.Namespace,
+			Labels:      workflowproj.GetMergedLabels(workflow),
+			Annotations: exposure.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: exposure.IngressClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: exposure.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     exposure.GetPath(),
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: workflow.Name,
+									Port: networkingv1.ServiceBackendPort{Number: defaultHTTPServicePort},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if len(exposure.TLSSecretName) > 0 {
+		tls := networkingv1.IngressTLS{SecretName: exposure.TLSSecretName}
+		if len(exposure.Host) > 0 {
+			tls.Hosts = []string{exposure.Host}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+	return ingress, nil
+}
+
+// HTTPRouteCreator is an ObjectCreator for the Gateway API HTTPRoute attaching the workflow Service to an existing Gateway.
+func HTTPRouteCreator(workflow *operatorapi.SonataFlow) (client.Object, error) {
+	exposure := workflow.Spec.Exposure
+	if exposure == nil || exposure.Gateway == nil {
+		return nil, fmt.Errorf("workflow %s has no gateway configured to expose it", workflow.Name)
+	}
+	parentRef := gatewayv1beta1.ParentReference{Name: gatewayv1beta1.ObjectName(exposure.Gateway.Name)}
+	if len(exposure.Gateway.Namespace) > 0 {
+		namespace := gatewayv1beta1.Namespace(exposure.Gateway.Namespace)
+		parentRef.Namespace = &namespace
+	}
+	if len(exposure.Gateway.SectionName) > 0 {
+		sectionName := gatewayv1beta1.SectionName(exposure.Gateway.SectionName)
+		parentRef.SectionName = &sectionName
+	}
+	pathType := gatewayv1beta1.PathMatchPathPrefix
+	path := exposure.GetPath()
+	port := gatewayv1beta1.PortNumber(defaultHTTPServicePort)
+	route := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        workflow.Name,
+			Namespace:   workflow.Namespace,
+			Labels:      workflowproj.GetMergedLabels(workflow),
+			Annotations: exposure.Annotations,
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{ParentRefs: []gatewayv1beta1.ParentReference{parentRef}},
+			Rules: []gatewayv1beta1.HTTPRouteRule{{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{
+					Path: &gatewayv1beta1.HTTPPathMatch{Type: &pathType, Value: &path},
+				}},
+				BackendRefs: []gatewayv1beta1.HTTPBackendRef{{
+					BackendRef: gatewayv1beta1.BackendRef{
+						BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+							Name: gatewayv1beta1.ObjectName(workflow.Name),
+							Port: &port,
+						},
+					},
+				}},
+			}},
+		},
+	}
+	if len(exposure.Host) > 0 {
+		route.Spec.Hostnames = []gatewayv1beta1.Hostname{gatewayv1beta1.Hostname(exposure.Host)}
+	}
+	return route, nil
+}
+
+// ServiceMonitorCreator is an ObjectCreatorWithPlatform for the Prometheus Operator ServiceMonitor scraping the workflow
+// metrics through the metrics port of the workflow Service.
+func ServiceMonitorCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) (client.Object, error) {
+	monitoring := operatorapi.GetWorkflowMonitoring(workflow, plf)
+	if !monitoring.IsEnabled() {
+		return nil, fmt.Errorf("workflow %s has no monitoring enabled", workflow.Name)
+	}
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    monitorLabels(workflow, monitoring),
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{MatchLabels: workflowproj.GetDefaultLabels(workflow)},
+			Endpoints: []monitoringv1.Endpoint{{
+				Port:     constants.MetricsPortName,
+				Path:     constants.QuarkusMetricsPath,
+				Interval: monitoring.Interval,
+			}},
+		},
+	}
+	return serviceMonitor, nil
+}
+
+// PodMonitorCreator is an ObjectCreatorWithPlatform for the Prometheus Operator PodMonitor scraping the workflow
+// metrics directly from the workflow container port.
+func PodMonitorCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) (client.Object, error) {
+	monitoring := operatorapi.GetWorkflowMonitoring(workflow, plf)
+	if !monitoring.IsEnabled() {
+		return nil, fmt.Errorf("workflow %s has no monitoring enabled", workflow.Name)
+	}
+	podMonitor := &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    monitorLabels(workflow, monitoring),
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			Selector: metav1.LabelSelector{MatchLabels: workflowproj.GetDefaultLabels(workflow)},
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{
+				Port:     utils.DefaultServicePortName,
+				Path:     constants.QuarkusMetricsPath,
+				Interval: monitoring.Interval,
+			}},
+		},
+	}
+	return podMonitor, nil
+}
+
+// monitorLabels returns the workflow labels merged with the labels required by the Prometheus instance monitor selector.
+func monitorLabels(workflow *operatorapi.SonataFlow, monitoring *operatorapi.MonitoringSpec) map[string]string {
+	lbl := workflowproj.GetMergedLabels(workflow)
+	for k, v := range monitoring.Labels {
+		lbl[k] = v
+	}
+	return lbl
+}
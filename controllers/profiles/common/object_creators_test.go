@@ -23,14 +23,30 @@ import (
 	"context"
 	"testing"
 
+	cncfmodel "github.com/serverlessworkflow/sdk-go/v2/model"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/magiconair/properties"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	knautoscaling "knative.dev/serving/pkg/apis/autoscaling"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
 	kubeutil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
 
@@ -199,7 +215,7 @@ func Test_ensureWorkflowTriggersAreCreated(t *testing.T) {
 	workflow := test.GetVetEventSonataFlow(t.Name())
 
 	//On Kubernetes we want the service exposed in Dev with NodePort
-	triggers, _ := TriggersCreator(workflow)
+	triggers, _ := TriggersCreator(workflow, nil)
 
 	assert.NotEmpty(t, triggers)
 	assert.Len(t, triggers, 2)
@@ -209,6 +225,126 @@ func Test_ensureWorkflowTriggersAreCreated(t *testing.T) {
 		assert.Equal(t, trigger.GetLabels(), map[string]string{"app": "vet", "sonataflow.org/workflow-app": "vet"})
 	}
 }
+func Test_ensureWorkflowSinkBindingBindsKnativeService(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	workflow.Spec.PodTemplate.DeploymentModel = v1alpha08.KnativeDeploymentModel
+
+	sinkBinding, err := SinkBindingCreator(workflow)
+	assert.NoError(t, err)
+
+	subject := sinkBinding.(*sourcesv1.SinkBinding).Spec.Subject
+	assert.Equal(t, "serving.knative.dev/v1", subject.APIVersion)
+	assert.Equal(t, "Service", subject.Kind)
+	assert.Equal(t, workflow.Name, subject.Name)
+}
+
+func Test_ensureWorkflowTriggersWithEventing(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	workflow.Spec.PodTemplate.DeploymentModel = v1alpha08.KnativeDeploymentModel
+	for i := range workflow.Spec.Flow.Events {
+		if workflow.Spec.Flow.Events[i].Name == "VetAppointmentInfo" {
+			workflow.Spec.Flow.Events[i].Correlation = []cncfmodel.Correlation{
+				{ContextAttributeName: "clinic", ContextAttributeValue: "north"},
+				{ContextAttributeName: "appointmentid"},
+			}
+		}
+	}
+	workflow.Spec.Eventing = &v1alpha08.EventingSpec{
+		Events: []v1alpha08.ConsumedEventSpec{{
+			Name:                "VetAppointmentRequestReceived",
+			EventingOptionsSpec: v1alpha08.EventingOptionsSpec{Broker: "requests"},
+			Filter:              map[string]string{"tenant": "acme"},
+		}},
+	}
+	retry := int32(5)
+	plf := test.GetBasePlatformInReadyPhase(t.Name())
+	plf.Spec.Eventing = &v1alpha08.PlatformEventingSpec{EventingOptionsSpec: v1alpha08.EventingOptionsSpec{
+		Broker: "platform",
+		Delivery: &v1alpha08.EventDeliverySpec{
+			Retry:          &retry,
+			DeadLetterSink: &duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "dls"}},
+		},
+	}}
+
+	triggers, err := TriggersCreator(workflow, plf)
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 2)
+
+	info := triggers[0].(*eventingv1.Trigger)
+	assert.Equal(t, "vet-vetappointmentinfo-trigger", info.Name)
+	assert.Equal(t, "platform", info.Spec.Broker)
+	assert.Equal(t, eventingv1.TriggerFilterAttributes{"type": "events.vet.appointments", "source": "VetServiceSource", "clinic": "north"}, info.Spec.Filter.Attributes)
+	assert.Equal(t, "serving.knative.dev/v1", info.Spec.Subscriber.Ref.APIVersion)
+	assert.Equal(t, int32(5), *info.Spec.Delivery.Retry)
+	assert.Equal(t, "dls", info.Spec.Delivery.DeadLetterSink.Ref.Name)
+
+	request := triggers[1].(*eventingv1.Trigger)
+	assert.Equal(t, "requests", request.Spec.Broker)
+	assert.Equal(t, "acme", request.Spec.Filter.Attributes["tenant"])
+	assert.Equal(t, "checkAccountInfo", request.Spec.Filter.Attributes["source"])
+}
+
+func Test_ensureWorkflowSubscriptionsAreCreated(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	backoff := v1alpha08.ExponentialEventBackoffPolicy
+	workflow.Spec.Eventing = &v1alpha08.EventingSpec{
+		EventingOptionsSpec: v1alpha08.EventingOptionsSpec{
+			Channel:  &duckv1.KReference{APIVersion: "messaging.knative.dev/v1", Kind: "InMemoryChannel", Name: "vet"},
+			Delivery: &v1alpha08.EventDeliverySpec{BackoffPolicy: &backoff},
+		},
+		Events: []v1alpha08.ConsumedEventSpec{{
+			Name:                "VetAppointmentInfo",
+			EventingOptionsSpec: v1alpha08.EventingOptionsSpec{Broker: "default"},
+		}},
+	}
+
+	triggers, err := TriggersCreator(workflow, nil)
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, "vet-vetappointmentinfo-trigger", triggers[0].GetName())
+
+	subscriptions, err := SubscriptionsCreator(workflow, nil)
+	assert.NoError(t, err)
+	assert.Len(t, subscriptions, 1)
+	subscription := subscriptions[0].(*messagingv1.Subscription)
+	assert.Equal(t, "vet-inmemorychannel-vet-subscription", subscription.Name)
+	assert.Equal(t, "InMemoryChannel", subscription.Spec.Channel.Kind)
+	assert.Equal(t, "Service", subscription.Spec.Subscriber.Ref.Kind)
+	assert.Equal(t, "v1", subscription.Spec.Subscriber.Ref.APIVersion)
+	assert.Equal(t, eventingduckv1.BackoffPolicyExponential, *subscription.Spec.Delivery.BackoffPolicy)
+	assert.Equal(t, map[string]string{"app": "vet", "sonataflow.org/workflow-app": "vet"}, subscription.Labels)
+}
+
+func Test_removeMovedConsumers(t *testing.T) {
+	workflow := test.GetVetEventSonataFlow(t.Name())
+	workflow.Spec.Eventing = &v1alpha08.EventingSpec{
+		EventingOptionsSpec: v1alpha08.EventingOptionsSpec{
+			Channel: &duckv1.KReference{APIVersion: "messaging.knative.dev/v1", Kind: "InMemoryChannel", Name: "vet"},
+		},
+		Events: []v1alpha08.ConsumedEventSpec{{
+			Name:                "VetAppointmentInfo",
+			EventingOptionsSpec: v1alpha08.EventingOptionsSpec{Broker: "moved"},
+		}},
+	}
+	triggers, err := TriggersCreator(workflow, nil)
+	assert.NoError(t, err)
+	subscriptions, err := SubscriptionsCreator(workflow, nil)
+	assert.NoError(t, err)
+	trigger := triggers[0].(*eventingv1.Trigger)
+	trigger.Spec.Broker = "default"
+	subscription := subscriptions[0].(*messagingv1.Subscription)
+	cl := test.NewSonataFlowClientBuilderWithKnative().WithRuntimeObjects(workflow).Build()
+	for _, object := range []client.Object{trigger, subscription} {
+		assert.NoError(t, controllerutil.SetControllerReference(workflow, object, cl.Scheme()))
+		assert.NoError(t, cl.Create(context.TODO(), object))
+	}
+	handler := NewKnativeEventingHandler(&StateSupport{C: cl}).(*knativeObjectManager)
+
+	// the Broker of the Trigger is immutable, it's recreated
+	assert.NoError(t, handler.removeMovedConsumers(context.TODO(), workflow, nil))
+	assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), client.ObjectKeyFromObject(trigger), &eventingv1.Trigger{})))
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(subscription), &messagingv1.Subscription{}))
+}
 
 func TestMergePodSpec_WithPostgreSQL_and_JDBC_URL_field(t *testing.T) {
 	workflow := test.GetBaseSonataFlow(t.Name())
@@ -597,3 +733,223 @@ func TestMergePodSpec_WithServicedPostgreSQL_In_Platform_But_Workflow_CR_Not_Req
 	assert.Equal(t, int32(8080), flowContainer.Ports[0].ContainerPort)
 	assert.Nil(t, flowContainer.Env)
 }
+
+func Test_HorizontalPodAutoscalerCreator(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	cpu, memory := int32(70), int32(80)
+	workflow.Spec.PodTemplate.Autoscaling = &v1alpha08.AutoscalingSpec{
+		MaxReplicas:                       5,
+		TargetCPUUtilizationPercentage:    &cpu,
+		TargetMemoryUtilizationPercentage: &memory,
+		Metrics:                           []v1alpha08.AutoscalingMetric{{Name: "http_requests", TargetAverageValue: resource.MustParse("100")}},
+	}
+
+	object, err := HorizontalPodAutoscalerCreator(workflow)
+	assert.NoError(t, err)
+	hpa := object.(*autoscalingv2.HorizontalPodAutoscaler)
+	assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, workflow.Name, hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 3)
+	assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, cpu, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name)
+	assert.Equal(t, "http_requests", hpa.Spec.Metrics[2].Pods.Metric.Name)
+
+	// the autoscaler owns the replicas from now on
+	workflow.Spec.PodTemplate.Replicas = utils.Pint(3)
+	object, err = DeploymentCreator(workflow, nil)
+	assert.NoError(t, err)
+	deployment := object.(*appsv1.Deployment)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	deployment.SetUID("1")
+	deployment.SetResourceVersion("1")
+	deployment.Spec.Replicas = utils.Pint(4)
+	assert.NoError(t, DeploymentMutateVisitor(workflow, nil)(deployment)())
+	assert.Equal(t, int32(4), *deployment.Spec.Replicas)
+
+	workflow.Spec.PodTemplate.Autoscaling = nil
+	assert.NoError(t, DeploymentMutateVisitor(workflow, nil)(deployment)())
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+}
+
+func Test_KServiceCreator_Autoscaling(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.PodTemplate.DeploymentModel = v1alpha08.KnativeDeploymentModel
+	minReplicas, memory := int32(0), int32(50)
+	workflow.Spec.PodTemplate.Container.Resources.Requests = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}
+	workflow.Spec.PodTemplate.Autoscaling = &v1alpha08.AutoscalingSpec{
+		MinReplicas:                       &minReplicas,
+		MaxReplicas:                       10,
+		TargetMemoryUtilizationPercentage: &memory,
+	}
+
+	object, err := KServiceCreator(workflow, nil)
+	assert.NoError(t, err)
+	ksvc := object.(*servingv1.Service)
+	assert.Equal(t, map[string]string{
+		knautoscaling.MinScaleAnnotationKey: "0",
+		knautoscaling.MaxScaleAnnotationKey: "10",
+		knautoscaling.ClassAnnotationKey:    knautoscaling.HPA,
+		knautoscaling.MetricAnnotationKey:   knautoscaling.Memory,
+		knautoscaling.TargetAnnotationKey:   "256",
+	}, ksvc.Spec.Template.Annotations)
+
+	// custom metrics take precedence, concurrency is handled by the Knative Pod Autoscaler
+	workflow.Spec.PodTemplate.Autoscaling.Metrics = []v1alpha08.AutoscalingMetric{
+		{Name: v1alpha08.ConcurrencyAutoscalingMetric, TargetAverageValue: resource.MustParse("20")},
+	}
+	ksvc.SetUID("1")
+	ksvc.SetResourceVersion("1")
+	ksvc.Spec.Template.Annotations["client.knative.dev/user-image"] = "greeting"
+	assert.NoError(t, KServiceMutateVisitor(workflow, nil)(ksvc)())
+	assert.Equal(t, knautoscaling.KPA, ksvc.Spec.Template.Annotations[knautoscaling.ClassAnnotationKey])
+	assert.Equal(t, v1alpha08.ConcurrencyAutoscalingMetric, ksvc.Spec.Template.Annotations[knautoscaling.MetricAnnotationKey])
+	assert.Equal(t, "20", ksvc.Spec.Template.Annotations[knautoscaling.TargetAnnotationKey])
+	assert.Equal(t, "greeting", ksvc.Spec.Template.Annotations["client.knative.dev/user-image"])
+
+	workflow.Spec.PodTemplate.Autoscaling = nil
+	assert.NoError(t, KServiceMutateVisitor(workflow, nil)(ksvc)())
+	assert.Equal(t, map[string]string{"client.knative.dev/user-image": "greeting"}, ksvc.Spec.Template.Annotations)
+}
+
+func Test_IngressCreator(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	ingressClass := "nginx"
+	workflow.Spec.Exposure = &v1alpha08.ExposureSpec{
+		IngressClassName: &ingressClass,
+		TLSSecretName:    "greeting-tls",
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "true"},
+	}
+
+	object, err := IngressCreator(workflow)
+	assert.NoError(t, err)
+	ingress := object.(*networkingv1.Ingress)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "true", ingress.Annotations["nginx.ingress.kubernetes.io/ssl-redirect"])
+	assert.Empty(t, ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, int32(defaultHTTPServicePort), ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+	assert.Equal(t, "greeting-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Empty(t, ingress.Spec.TLS[0].Hosts)
+
+	// without a host, the endpoint is the address published by the ingress controller
+	assert.Nil(t, ingressEndpoint(workflow, ingress))
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}}
+	assert.Equal(t, "https://10.0.0.1/", ingressEndpoint(workflow, ingress).String())
+}
+
+func Test_ServiceMonitorCreator(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	platform := test.GetBasePlatform()
+	platform.Spec.Monitoring = &v1alpha08.MonitoringSpec{Enabled: true, Interval: "30s", Labels: map[string]string{"release": "prometheus"}}
+
+	// the workflow inherits the platform monitoring
+	object, err := ServiceMonitorCreator(workflow, platform)
+	assert.NoError(t, err)
+	serviceMonitor := object.(*monitoringv1.ServiceMonitor)
+	assert.Equal(t, "prometheus", serviceMonitor.Labels["release"])
+	assert.Equal(t, workflowproj.GetDefaultLabels(workflow), serviceMonitor.Spec.Selector.MatchLabels)
+	assert.Equal(t, constants.MetricsPortName, serviceMonitor.Spec.Endpoints[0].Port)
+	assert.Equal(t, constants.QuarkusMetricsPath, serviceMonitor.Spec.Endpoints[0].Path)
+	assert.Equal(t, "30s", serviceMonitor.Spec.Endpoints[0].Interval)
+
+	// the ServiceMonitor endpoint port is exposed by the workflow service
+	service, err := ServiceCreator(workflow, platform)
+	assert.NoError(t, err)
+	metricsPort, _ := kubeutil.GetServicePortByName(constants.MetricsPortName, service.(*corev1.Service))
+	assert.NotNil(t, metricsPort)
+	assert.Equal(t, int32(8080), metricsPort.TargetPort.IntVal)
+
+	// the workflow monitoring overrides the platform one
+	workflow.Spec.Monitoring = &v1alpha08.MonitoringSpec{Enabled: false}
+	_, err = ServiceMonitorCreator(workflow, platform)
+	assert.Error(t, err)
+
+	// the metrics port is exposed only when the monitoring is enabled
+	service, err = ServiceCreator(workflow, platform)
+	assert.NoError(t, err)
+	assert.Len(t, service.(*corev1.Service).Spec.Ports, 1)
+	metricsPort, _ = kubeutil.GetServicePortByName(constants.MetricsPortName, service.(*corev1.Service))
+	assert.Nil(t, metricsPort)
+}
+
+func Test_MonitoringHandler(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.Monitoring = &v1alpha08.MonitoringSpec{Enabled: true}
+	cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow).Build()
+	handler := NewMonitoringHandler(&StateSupport{C: cli}).(*monitoringObjectManager)
+	handler.prometheusAvailability = func(cfg *rest.Config) (bool, error) {
+		return true, nil
+	}
+
+	objs, err := handler.Ensure(context.TODO(), workflow, nil)
+	assert.NoError(t, err)
+	assert.Len(t, objs, 1)
+	assert.IsType(t, &monitoringv1.ServiceMonitor{}, objs[0])
+
+	// switching the monitor type replaces the ServiceMonitor
+	workflow.Spec.Monitoring.Type = v1alpha08.PodMonitorType
+	objs, err = handler.Ensure(context.TODO(), workflow, nil)
+	assert.NoError(t, err)
+	assert.Len(t, objs, 1)
+	podMonitor := objs[0].(*monitoringv1.PodMonitor)
+	assert.Equal(t, utils.DefaultServicePortName, podMonitor.Spec.PodMetricsEndpoints[0].Port)
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), &monitoringv1.ServiceMonitor{})))
+
+	// disabling the monitoring removes the monitors
+	workflow.Spec.Monitoring.Enabled = false
+	objs, err = handler.Ensure(context.TODO(), workflow, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, objs)
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), &monitoringv1.PodMonitor{})))
+}
+
+func Test_SecretPropertiesMutateVisitor(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.PodTemplate.Container.Env = []corev1.EnvVar{{Name: "MY_OVERRIDDEN_KEY", Value: "explicit"}}
+	workflow.Spec.Properties = &v1alpha08.PropertyFlowSpec{
+		Flow: []v1alpha08.PropertyVar{
+			{Name: "my.literal", Value: "value"},
+			{Name: "my.api-key", ValueFrom: &v1alpha08.PropertyVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "key"}}},
+			{Name: "my.api-token", ValueFrom: &v1alpha08.PropertyVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "token"}}},
+			{Name: "my.overridden.key", ValueFrom: &v1alpha08.PropertyVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "other"}, Key: "key"}}},
+		},
+	}
+
+	object, err := DeploymentCreator(workflow, nil)
+	assert.NoError(t, err)
+	deployment := object.(*appsv1.Deployment)
+	assert.NoError(t, SecretPropertiesMutateVisitor(workflow)(deployment)())
+	flowContainer, _ := kubeutil.GetContainerByName(v1alpha08.DefaultContainerName, &deployment.Spec.Template.Spec)
+	assert.Len(t, flowContainer.Env, 3)
+	assert.Equal(t, "explicit", flowContainer.Env[0].Value)
+	assert.Equal(t, "MY_API_KEY", flowContainer.Env[1].Name)
+	assert.Equal(t, "key", flowContainer.Env[1].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "MY_API_TOKEN", flowContainer.Env[2].Name)
+	assert.Empty(t, deployment.Spec.Template.Spec.Volumes)
+
+	// the same properties delivered as files of a single volume
+	workflow.Spec.Properties.SecretsDelivery = v1alpha08.FileSecretPropertiesDelivery
+	workflow.Spec.PodTemplate.DeploymentModel = v1alpha08.KnativeDeploymentModel
+	object, err = KServiceCreator(workflow, nil)
+	assert.NoError(t, err)
+	ksvc := object.(*servingv1.Service)
+	assert.NoError(t, SecretPropertiesMutateVisitor(workflow)(ksvc)())
+	podSpec := &ksvc.Spec.Template.Spec.PodSpec
+	flowContainer, _ = kubeutil.GetContainerByName(v1alpha08.DefaultContainerName, podSpec)
+	assert.Len(t, flowContainer.Env, 1)
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, constants.SecretPropertiesVolumeName, podSpec.Volumes[0].Name)
+	sources := podSpec.Volumes[0].Projected.Sources
+	assert.Len(t, sources, 2)
+	assert.Equal(t, "api", sources[0].Secret.Name)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "key", Path: "my.api-key"}, {Key: "token", Path: "my.api-token"}}, sources[0].Secret.Items)
+	assert.Equal(t, "other", sources[1].Secret.Name)
+	assert.Equal(t, []corev1.VolumeMount{{Name: constants.SecretPropertiesVolumeName, ReadOnly: true, MountPath: constants.SecretPropertiesMountPath}},
+		flowContainer.VolumeMounts)
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/magiconair/properties"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/cfg"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+)
+
+// ConfigureInfinispanEnv returns the common env variables required for the DataIndex, the JobsService or a workflow when
+// infinispan persistence is used.
+func ConfigureInfinispanEnv(infinispan *operatorapi.PersistenceInfinispan, serverNamespace string) []corev1.EnvVar {
+	hosts := infinispan.Hosts
+	if infinispan.ServiceRef != nil {
+		if len(infinispan.ServiceRef.Namespace) > 0 {
+			serverNamespace = infinispan.ServiceRef.Namespace
+		}
+		port := constants.DefaultInfinispanPort
+		if infinispan.ServiceRef.Port != nil {
+			port = *infinispan.ServiceRef.Port
+		}
+		hosts = fmt.Sprintf("%s.%s:%d", infinispan.ServiceRef.Name, serverNamespace, port)
+	}
+	secretRef := corev1.LocalObjectReference{
+		Name: infinispan.SecretRef.Name,
+	}
+	userKey := "INFINISPAN_USER"
+	if len(infinispan.SecretRef.UserKey) > 0 {
+		userKey = infinispan.SecretRef.UserKey
+	}
+	passwordKey := "INFINISPAN_PASSWORD"
+	if len(infinispan.SecretRef.PasswordKey) > 0 {
+		passwordKey = infinispan.SecretRef.PasswordKey
+	}
+	return []corev1.EnvVar{
+		{
+			Name: "QUARKUS_INFINISPAN_CLIENT_USERNAME",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key:                  userKey,
+					LocalObjectReference: secretRef,
+				},
+			},
+		},
+		{
+			Name: "QUARKUS_INFINISPAN_CLIENT_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key:                  passwordKey,
+					LocalObjectReference: secretRef,
+				},
+			},
+		},
+		{
+			Name:  "QUARKUS_INFINISPAN_CLIENT_HOSTS",
+			Value: hosts,
+		},
+		{
+			Name:  "KOGITO_PERSISTENCE_TYPE",
+			Value: constants.PersistenceTypeInfinispan.String(),
+		},
+	}
+}
+
+// GetInfinispanExtensions returns the Quarkus extensions required for infinispan persistence.
+func GetInfinispanExtensions() []cfg.GAV {
+	return cfg.GetCfg().InfinispanPersistenceExtensions
+}
+
+// GetInfinispanWorkflowProperties returns the set of application properties required for infinispan persistence.
+// Never nil.
+func GetInfinispanWorkflowProperties(workflow *operatorapi.SonataFlow) *properties.Properties {
+	props := properties.NewProperties()
+	if !profiles.IsDevProfile(workflow) && !profiles.IsGitOpsProfile(workflow) {
+		// build-time properties for kogito-runtimes to use infinispan and generate the protobuf marshallers
+		props.Set(KogitoPersistenceType, constants.PersistenceTypeInfinispan.String())
+		props.Set(KogitoPersistenceProtoMarshaller, "true")
+	}
+	return props
+}
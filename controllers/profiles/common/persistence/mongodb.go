@@ -0,0 +1,106 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/magiconair/properties"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/cfg"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+)
+
+// ConfigureMongoDBEnv returns the common env variables required for the DataIndex, the JobsService or a workflow when
+// mongodb persistence is used.
+func ConfigureMongoDBEnv(mongodb *operatorapi.PersistenceMongoDB, databaseNamespace string) []corev1.EnvVar {
+	connectionString := mongodb.ConnectionString
+	if mongodb.ServiceRef != nil {
+		if len(mongodb.ServiceRef.Namespace) > 0 {
+			databaseNamespace = mongodb.ServiceRef.Namespace
+		}
+		port := constants.DefaultMongoDBPort
+		if mongodb.ServiceRef.Port != nil {
+			port = *mongodb.ServiceRef.Port
+		}
+		connectionString = fmt.Sprintf("%s://%s.%s:%d", constants.PersistenceTypeMongoDB, mongodb.ServiceRef.Name, databaseNamespace, port)
+	}
+	databaseName := defaultDatabaseName
+	if len(mongodb.DatabaseName) > 0 {
+		databaseName = mongodb.DatabaseName
+	}
+	secretRef := corev1.LocalObjectReference{
+		Name: mongodb.SecretRef.Name,
+	}
+	userKey := "MONGODB_USER"
+	if len(mongodb.SecretRef.UserKey) > 0 {
+		userKey = mongodb.SecretRef.UserKey
+	}
+	passwordKey := "MONGODB_PASSWORD"
+	if len(mongodb.SecretRef.PasswordKey) > 0 {
+		passwordKey = mongodb.SecretRef.PasswordKey
+	}
+	return []corev1.EnvVar{
+		{
+			Name: "QUARKUS_MONGODB_CREDENTIALS_USERNAME",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key:                  userKey,
+					LocalObjectReference: secretRef,
+				},
+			},
+		},
+		{
+			Name: "QUARKUS_MONGODB_CREDENTIALS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key:                  passwordKey,
+					LocalObjectReference: secretRef,
+				},
+			},
+		},
+		{
+			Name:  "QUARKUS_MONGODB_CONNECTION_STRING",
+			Value: connectionString,
+		},
+		{
+			Name:  "QUARKUS_MONGODB_DATABASE",
+			Value: databaseName,
+		},
+		{
+			Name:  "KOGITO_PERSISTENCE_TYPE",
+			Value: constants.PersistenceTypeMongoDB.String(),
+		},
+	}
+}
+
+// GetMongoDBExtensions returns the Quarkus extensions required for mongodb persistence.
+func GetMongoDBExtensions() []cfg.GAV {
+	return cfg.GetCfg().MongoDBPersistenceExtensions
+}
+
+// GetMongoDBWorkflowProperties returns the set of application properties required for mongodb persistence.
+// Never nil.
+func GetMongoDBWorkflowProperties(workflow *operatorapi.SonataFlow) *properties.Properties {
+	props := properties.NewProperties()
+	if !profiles.IsDevProfile(workflow) && !profiles.IsGitOpsProfile(workflow) {
+		// build-time property for kogito-runtimes to use mongodb
+		props.Set(KogitoPersistenceType, constants.PersistenceTypeMongoDB.String())
+	}
+	return props
+}
@@ -16,6 +16,8 @@ package persistence
 
 import (
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/cfg"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
 	"github.com/magiconair/properties"
 )
 
@@ -33,8 +35,75 @@ const (
 // ResolveWorkflowPersistenceProperties returns the set of application properties required for the workflow persistence.
 // Never nil.
 func ResolveWorkflowPersistenceProperties(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) (*properties.Properties, error) {
-	if UsesPostgreSQLPersistence(workflow, platform) {
+	switch GetWorkflowPersistenceType(workflow, platform) {
+	case constants.PersistenceTypePostgreSQL:
 		return GetPostgreSQLWorkflowProperties(workflow), nil
+	case constants.PersistenceTypeMongoDB:
+		return GetMongoDBWorkflowProperties(workflow), nil
+	case constants.PersistenceTypeInfinispan:
+		return GetInfinispanWorkflowProperties(workflow), nil
 	}
 	return properties.NewProperties(), nil
 }
+
+// GetPersistenceType returns the database manager configured in the given PersistenceOptionsSpec, or an empty
+// PersistenceType when none is configured.
+func GetPersistenceType(spec *operatorapi.PersistenceOptionsSpec) constants.PersistenceType {
+	switch {
+	case spec == nil:
+		return ""
+	case spec.PostgreSQL != nil:
+		return constants.PersistenceTypePostgreSQL
+	case spec.MongoDB != nil:
+		return constants.PersistenceTypeMongoDB
+	case spec.Infinispan != nil:
+		return constants.PersistenceTypeInfinispan
+	}
+	return ""
+}
+
+// GetPlatformPersistenceType same as GetPersistenceType for the PlatformPersistenceOptionsSpec.
+func GetPlatformPersistenceType(spec *operatorapi.PlatformPersistenceOptionsSpec) constants.PersistenceType {
+	switch {
+	case spec == nil:
+		return ""
+	case spec.PostgreSQL != nil, spec.ProvisionedPostgreSQL != nil:
+		return constants.PersistenceTypePostgreSQL
+	case spec.MongoDB != nil:
+		return constants.PersistenceTypeMongoDB
+	case spec.Infinispan != nil:
+		return constants.PersistenceTypeInfinispan
+	}
+	return ""
+}
+
+// GetWorkflowPersistenceType returns the database manager used by the workflow. A workflow with a persistence
+// configuration, even an empty one, never inherits the platform's persistence.
+func GetWorkflowPersistenceType(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) constants.PersistenceType {
+	if workflow.Spec.Persistence != nil {
+		return GetPersistenceType(workflow.Spec.Persistence)
+	}
+	return GetPlatformPersistenceType(platform.GetPersistence())
+}
+
+// GetPersistenceExtensions returns the Quarkus extensions required to build a workflow using the given persistence type.
+func GetPersistenceExtensions(persistenceType constants.PersistenceType) []cfg.GAV {
+	switch persistenceType {
+	case constants.PersistenceTypePostgreSQL:
+		return GetPostgreSQLExtensions()
+	case constants.PersistenceTypeMongoDB:
+		return GetMongoDBExtensions()
+	case constants.PersistenceTypeInfinispan:
+		return GetInfinispanExtensions()
+	}
+	return nil
+}
+
+// RetrieveWorkflowConfiguration returns the PersistenceOptionsSpec used by the workflow. A workflow with an empty
+// persistence configuration opts out of the platform's persistence, see GetWorkflowPersistenceType.
+func RetrieveWorkflowConfiguration(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) *operatorapi.PersistenceOptionsSpec {
+	if workflow.Spec.Persistence != nil && len(GetPersistenceType(workflow.Spec.Persistence)) == 0 {
+		return nil
+	}
+	return RetrieveConfiguration(workflow.Spec.Persistence, platform.GetPersistence(), workflow.Name)
+}
@@ -19,6 +19,7 @@ import (
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestResolveWorkflowPersistenceProperties_WithWorkflowPersistence(t *testing.T) {
@@ -70,6 +71,60 @@ func TestResolveWorkflowPersistenceProperties_WithNoPersistence(t *testing.T) {
 	assert.Equal(t, 0, props.Len())
 }
 
+func TestResolveWorkflowPersistenceProperties_WithMongoDBAndInfinispan(t *testing.T) {
+	workflow := operatorapi.SonataFlow{}
+	platform := operatorapi.SonataFlowPlatform{
+		Spec: operatorapi.SonataFlowPlatformSpec{
+			Persistence: &operatorapi.PlatformPersistenceOptionsSpec{
+				MongoDB: &operatorapi.PersistenceMongoDB{},
+			},
+		},
+	}
+	props, err := ResolveWorkflowPersistenceProperties(&workflow, &platform)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, props.Len())
+	assert.Equal(t, "mongodb", props.GetString("kogito.persistence.type", ""))
+
+	workflow.Spec.Persistence = &operatorapi.PersistenceOptionsSpec{Infinispan: &operatorapi.PersistenceInfinispan{}}
+	props, err = ResolveWorkflowPersistenceProperties(&workflow, &platform)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, props.Len())
+	assert.Equal(t, "infinispan", props.GetString("kogito.persistence.type", ""))
+	assert.Equal(t, "true", props.GetString("kogito.persistence.proto.marshaller", ""))
+}
+
+func TestConfigurePersistence_MongoDBServiceRef(t *testing.T) {
+	port := 27018
+	config := &operatorapi.PersistenceOptionsSpec{
+		MongoDB: &operatorapi.PersistenceMongoDB{
+			SecretRef:    operatorapi.MongoDBSecretOptions{Name: "mongodb-secret", UserKey: "user"},
+			ServiceRef:   &operatorapi.NoSQLServiceOptions{Name: "mongodb", Port: &port},
+			DatabaseName: "workflows",
+		},
+	}
+	c := ConfigurePersistence(&corev1.Container{}, config, "my-workflow", "my-namespace")
+	assert.Len(t, c.Env, 5)
+	assert.Equal(t, "user", c.Env[0].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "MONGODB_PASSWORD", c.Env[1].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, corev1.EnvVar{Name: "QUARKUS_MONGODB_CONNECTION_STRING", Value: "mongodb://mongodb.my-namespace:27018"}, c.Env[2])
+	assert.Equal(t, corev1.EnvVar{Name: "QUARKUS_MONGODB_DATABASE", Value: "workflows"}, c.Env[3])
+	assert.Equal(t, corev1.EnvVar{Name: "KOGITO_PERSISTENCE_TYPE", Value: "mongodb"}, c.Env[4])
+}
+
+func TestConfigurePersistence_InfinispanHosts(t *testing.T) {
+	config := &operatorapi.PersistenceOptionsSpec{
+		Infinispan: &operatorapi.PersistenceInfinispan{
+			SecretRef: operatorapi.InfinispanSecretOptions{Name: "infinispan-secret"},
+			Hosts:     "infinispan-0:11222,infinispan-1:11222",
+		},
+	}
+	c := ConfigurePersistence(&corev1.Container{}, config, "my-workflow", "my-namespace")
+	assert.Len(t, c.Env, 4)
+	assert.Equal(t, "INFINISPAN_USER", c.Env[0].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, corev1.EnvVar{Name: "QUARKUS_INFINISPAN_CLIENT_HOSTS", Value: "infinispan-0:11222,infinispan-1:11222"}, c.Env[2])
+	assert.Equal(t, corev1.EnvVar{Name: "KOGITO_PERSISTENCE_TYPE", Value: "infinispan"}, c.Env[3])
+}
+
 func testResolveWorkflowPersistencePropertiesWithPersistence(t *testing.T, workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) {
 	props, err := ResolveWorkflowPersistenceProperties(workflow, platform)
 	assert.Nil(t, err)
@@ -82,3 +137,22 @@ func testResolveWorkflowPersistencePropertiesWithPersistence(t *testing.T, workf
 	value, _ = props.Get("kogito.persistence.proto.marshaller")
 	assert.Equal(t, "false", value)
 }
+
+func TestResolveWorkflowPersistenceProperties_WithProvisionedPlatformPersistence(t *testing.T) {
+	workflow := operatorapi.SonataFlow{}
+	platform := operatorapi.SonataFlowPlatform{
+		Spec: operatorapi.SonataFlowPlatformSpec{
+			Persistence: &operatorapi.PlatformPersistenceOptionsSpec{
+				ProvisionedPostgreSQL: &operatorapi.ProvisionedPostgreSQLOptions{},
+			},
+		},
+	}
+	platform.Name = "sonataflow-platform"
+	platform.Namespace = "default"
+	testResolveWorkflowPersistencePropertiesWithPersistence(t, &workflow, &platform)
+
+	p := RetrieveConfiguration(nil, platform.GetPersistence(), "greeting")
+	assert.Equal(t, "sonataflow-platform-postgresql", p.PostgreSQL.ServiceRef.Name)
+	assert.Equal(t, "greeting", p.PostgreSQL.ServiceRef.DatabaseSchema)
+	assert.Equal(t, "sonataflow-platform-postgresql", p.PostgreSQL.SecretRef.Name)
+}
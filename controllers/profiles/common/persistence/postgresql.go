@@ -96,17 +96,30 @@ func ConfigurePostgreSQLEnv(postgresql *operatorapi.PersistencePostgreSQL, datab
 	}
 }
 
+// ConfigurePersistence returns a copy of the given container with the env variables required by the database manager
+// configured in the PersistenceOptionsSpec. The container is returned as is when no database manager is configured.
 func ConfigurePersistence(serviceContainer *corev1.Container, config *operatorapi.PersistenceOptionsSpec, defaultSchema, namespace string) *corev1.Container {
-	if config.PostgreSQL == nil {
+	var env []corev1.EnvVar
+	switch GetPersistenceType(config) {
+	case constants.PersistenceTypePostgreSQL:
+		env = ConfigurePostgreSQLEnv(config.PostgreSQL, defaultSchema, namespace)
+	case constants.PersistenceTypeMongoDB:
+		env = ConfigureMongoDBEnv(config.MongoDB, namespace)
+	case constants.PersistenceTypeInfinispan:
+		env = ConfigureInfinispanEnv(config.Infinispan, namespace)
+	default:
 		return serviceContainer
 	}
 	c := serviceContainer.DeepCopy()
-	c.Env = append(c.Env, ConfigurePostgreSQLEnv(config.PostgreSQL, defaultSchema, namespace)...)
+	c.Env = append(c.Env, env...)
 	return c
 }
 
+// RetrieveConfiguration returns the PersistenceOptionsSpec of a workflow or a platform service. Gives priority to the
+// primary configuration when it configures any database manager, otherwise the platform's persistence is used with
+// the given schema.
 func RetrieveConfiguration(primary *v1alpha08.PersistenceOptionsSpec, platformPersistence *v1alpha08.PlatformPersistenceOptionsSpec, schema string) *v1alpha08.PersistenceOptionsSpec {
-	if primary != nil {
+	if len(GetPersistenceType(primary)) > 0 {
 		return primary
 	}
 	if platformPersistence == nil {
@@ -115,15 +128,8 @@ func RetrieveConfiguration(primary *v1alpha08.PersistenceOptionsSpec, platformPe
 	return buildPersistenceOptionsSpec(platformPersistence, schema)
 }
 
-// RetrievePostgreSQLConfiguration return the PersistenceOptionsSpec considering that postgresql is the database manager
-// to look for. Gives priority to the primary configuration.
-func RetrievePostgreSQLConfiguration(primary *v1alpha08.PersistenceOptionsSpec, platformPersistence *v1alpha08.PlatformPersistenceOptionsSpec, schema string) *v1alpha08.PersistenceOptionsSpec {
-	if primary != nil && primary.PostgreSQL != nil {
-		return primary
-	}
-	return buildPersistenceOptionsSpec(platformPersistence, schema)
-}
-
+// buildPersistenceOptionsSpec returns the persistence of a service or a workflow sharing the platform's database
+// manager. Every one of them gets its own PostgreSQL schema, or its own MongoDB database, named after the given schema.
 func buildPersistenceOptionsSpec(platformPersistence *v1alpha08.PlatformPersistenceOptionsSpec, schema string) *v1alpha08.PersistenceOptionsSpec {
 	c := &v1alpha08.PersistenceOptionsSpec{}
 	if platformPersistence.PostgreSQL != nil {
@@ -139,14 +145,19 @@ func buildPersistenceOptionsSpec(platformPersistence *v1alpha08.PlatformPersiste
 			c.PostgreSQL.JdbcUrl = platformPersistence.PostgreSQL.JdbcUrl
 		}
 	}
+	if platformPersistence.MongoDB != nil {
+		c.MongoDB = platformPersistence.MongoDB.DeepCopy()
+		c.MongoDB.DatabaseName = schema
+		if len(platformPersistence.MongoDB.DatabaseName) > 0 {
+			c.MongoDB.DatabaseName = platformPersistence.MongoDB.DatabaseName + "-" + schema
+		}
+	}
+	if platformPersistence.Infinispan != nil {
+		c.Infinispan = platformPersistence.Infinispan.DeepCopy()
+	}
 	return c
 }
 
-func UsesPostgreSQLPersistence(workflow *operatorapi.SonataFlow, platform *operatorapi.SonataFlowPlatform) bool {
-	return (workflow.Spec.Persistence != nil && workflow.Spec.Persistence.PostgreSQL != nil) ||
-		(workflow.Spec.Persistence == nil && platform.Spec.Persistence != nil && platform.Spec.Persistence.PostgreSQL != nil)
-}
-
 // GetPostgreSQLExtensions returns the Quarkus extensions required for postgresql persistence.
 func GetPostgreSQLExtensions() []cfg.GAV {
 	return cfg.GetCfg().PostgreSQLPersistenceExtensions
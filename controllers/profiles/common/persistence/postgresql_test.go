@@ -41,15 +41,19 @@ var (
 	platformPostreSQLService = operatorapi.SQLServiceOptions{
 		Name: "platform-service",
 	}
+	platformMongoDB = operatorapi.PersistenceMongoDB{
+		SecretRef:        operatorapi.MongoDBSecretOptions{Name: "platform-secret"},
+		ConnectionString: "mongodb://mongodb:27017",
+	}
 )
 
-var _ = Describe("RetrievePostgreSQLConfiguration", func() {
+var _ = Describe("RetrieveConfiguration", func() {
 	DescribeTable("calculation",
 		func(primary *operatorapi.PersistenceOptionsSpec,
 			platformPersistence *operatorapi.PlatformPersistenceOptionsSpec,
 			schema string,
 			expectedConfig *operatorapi.PersistenceOptionsSpec) {
-			result := RetrievePostgreSQLConfiguration(primary, platformPersistence, schema)
+			result := RetrieveConfiguration(primary, platformPersistence, schema)
 			Expect(expectedConfig).To(Equal(result))
 		},
 		Entry("primary is postgresql with JdbcUrl", buildPrimaryIsPostgreSQLWithJdbcUrl(),
@@ -60,6 +64,11 @@ var _ = Describe("RetrievePostgreSQLConfiguration", func() {
 			buildPlatformIsPostgreSQLWithJdbcUrl(),
 			schemaName,
 			buildPrimaryIsPostgreSQLWithServiceRef()),
+		Entry("primary and platform are nil",
+			nil,
+			nil,
+			schemaName,
+			nil),
 		Entry("primary is nil, platform with JdbcUrl",
 			nil,
 			buildPlatformIsPostgreSQLWithJdbcUrl(),
@@ -106,6 +115,32 @@ var _ = Describe("RetrievePostgreSQLConfiguration", func() {
 					SecretRef: plaformPostgreSQLSecret,
 				},
 			}),
+		Entry("primary is nil, platform with MongoDB",
+			nil,
+			&operatorapi.PlatformPersistenceOptionsSpec{MongoDB: &platformMongoDB},
+			schemaName,
+			&operatorapi.PersistenceOptionsSpec{
+				MongoDB: &operatorapi.PersistenceMongoDB{
+					SecretRef:        platformMongoDB.SecretRef,
+					ConnectionString: platformMongoDB.ConnectionString,
+					DatabaseName:     schemaName,
+				},
+			}),
+		Entry("primary is nil, platform with MongoDB database name",
+			nil,
+			&operatorapi.PlatformPersistenceOptionsSpec{MongoDB: &operatorapi.PersistenceMongoDB{
+				SecretRef:        platformMongoDB.SecretRef,
+				ConnectionString: platformMongoDB.ConnectionString,
+				DatabaseName:     "platform",
+			}},
+			schemaName,
+			&operatorapi.PersistenceOptionsSpec{
+				MongoDB: &operatorapi.PersistenceMongoDB{
+					SecretRef:        platformMongoDB.SecretRef,
+					ConnectionString: platformMongoDB.ConnectionString,
+					DatabaseName:     "platform-" + schemaName,
+				},
+			}),
 	)
 })
 
@@ -23,10 +23,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/discovery"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/metrics"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
 	"github.com/magiconair/properties"
 	"k8s.io/klog/v2"
@@ -34,7 +36,7 @@ import (
 
 const (
 	microprofileServiceCatalogPropertyPrefix = "org.kie.kogito.addons.discovery."
-	discoveryLikePropertyPattern             = "^\\${(kubernetes|knative|openshift):(.*)}$"
+	discoveryLikePropertyPattern             = "^\\${(kubernetes|knative|openshift|istio):(.*)}$"
 	knativeServiceOperationPrefix            = "knative:services.v1.serving.knative.dev"
 )
 
@@ -48,6 +50,56 @@ func removeDiscoveryProperties(props *properties.Properties) {
 	}
 }
 
+// GetDiscoveredAddresses returns the addresses resolved by the service discovery in the given managed properties,
+// sorted and without duplicates.
+func GetDiscoveredAddresses(managedProperties string) ([]string, error) {
+	props, err := properties.LoadString(managedProperties)
+	if err != nil {
+		return nil, err
+	}
+	props.DisableExpansion = true
+	var addresses []string
+	for _, k := range props.Keys() {
+		if !strings.HasPrefix(k, microprofileServiceCatalogPropertyPrefix) {
+			continue
+		}
+		if address, ok := props.Get(k); ok && !slices.Contains(addresses, address) {
+			addresses = append(addresses, address)
+		}
+	}
+	slices.Sort(addresses)
+	return addresses, nil
+}
+
+// GetDiscoveryResources returns the resources the service discovery resolves for the given workflow, either from the
+// user properties or from the Knative functions.
+func GetDiscoveryResources(workflow *operatorapi.SonataFlow, userProperties string) []discovery.ResourceUri {
+	var uris []string
+	if props, err := properties.LoadString(userProperties); err == nil {
+		props.DisableExpansion = true
+		for _, k := range props.Keys() {
+			if value, _ := props.Get(k); discoveryLikePropertyExpr.MatchString(value) {
+				uris = append(uris, value[2:len(value)-1])
+			}
+		}
+	}
+	for _, function := range workflow.Spec.Flow.Functions {
+		if strings.HasPrefix(function.Operation, knativeServiceOperationPrefix) {
+			uris = append(uris, function.Operation)
+		}
+	}
+	var resources []discovery.ResourceUri
+	for _, plainUri := range uris {
+		if uri, err := discovery.ParseUri(plainUri); err == nil {
+			if len(uri.Namespace) == 0 {
+				uri.Namespace = workflow.Namespace
+			}
+			resources = append(resources, *uri)
+		}
+	}
+	return resources
+}
+
 func generateMicroprofileServiceCatalogProperty(serviceUri string) string {
 	escapedServiceUri := escapeValue(serviceUri, ":")
 	escapedServiceUri = escapeValue(escapedServiceUri, "/")
@@ -72,10 +124,12 @@ func escapeValue(unescaped string, value string) string {
 //	org.kie.kogito.addons.discovery.kubernetes\:services.v1\/usecase1\/financial-service?port\=http-port=http://10.5.9.1:8080
 //
 // where http://10.5.9.1:8080 is the corresponding k8s cloud address for the service financial-service in the namespace usecase1.
+// The result of the discovery of every property and function is returned too, with the address or the resolution error.
 func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceCatalog, props *properties.Properties,
-	workflow *operatorapi.SonataFlow) *properties.Properties {
+	workflow *operatorapi.SonataFlow) (*properties.Properties, []operatorapi.DiscoveredPropertyStatus) {
 	klog.V(log.I).Infof("Generating service discovery properties for workflow: %s, and namespace: %s.", workflow.Name, workflow.Namespace)
 	result := properties.NewProperties()
+	var statuses []operatorapi.DiscoveredPropertyStatus
 	props.DisableExpansion = true
 	for _, k := range props.Keys() {
 		value, _ := props.Get(k)
@@ -85,8 +139,11 @@ func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceC
 		} else {
 			klog.V(log.I).Infof("Property %s=%s looks like a service discovery configuration.", k, value)
 			plainUri := value[2 : len(value)-1]
+			status := operatorapi.DiscoveredPropertyStatus{Property: k, Uri: plainUri}
 			if uri, err := discovery.ParseUri(plainUri); err != nil {
 				klog.V(log.I).Infof("Property %s=%s not correspond to a valid service discovery configuration, it will be excluded from service discovery.", k, value)
+				metrics.IncDiscoveryFailures(workflow)
+				status.Error = err.Error()
 			} else {
 				if len(uri.Namespace) == 0 {
 					klog.V(log.I).Infof("Current service discovery configuration has no configured namespace, workflow namespace: %s will be used instead.", workflow.Namespace)
@@ -94,6 +151,8 @@ func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceC
 				}
 				if address, err := catalog.Query(ctx, *uri, discovery.KubernetesDNSAddress); err != nil {
 					klog.V(log.E).ErrorS(err, "An error was produced during service address resolution.", "serviceUri", plainUri)
+					metrics.IncDiscoveryFailures(workflow)
+					status.Error = err.Error()
 				} else {
 					klog.V(log.I).Infof("Service: %s was resolved into the following address: %s.", plainUri, address)
 					mpProperty := generateMicroprofileServiceCatalogProperty(plainUri)
@@ -101,8 +160,10 @@ func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceC
 					result.MustSet(mpProperty, address)
 					klog.V(log.I).Infof("Overriding the discoverable value as the managed property %s=%s.", k, address)
 					result.MustSet(k, address)
+					status.Address = address
 				}
 			}
+			statuses = append(statuses, status)
 		}
 	}
 
@@ -110,8 +171,11 @@ func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceC
 		klog.V(log.I).Infof("Scanning function: %s for service discovery configuration.", function.Name)
 		if strings.HasPrefix(function.Operation, knativeServiceOperationPrefix) {
 			klog.V(log.I).Infof("Function %s looks to be a knative service invocation on service: %s.", function.Name, function.Operation)
+			status := operatorapi.DiscoveredPropertyStatus{Function: function.Name, Uri: function.Operation}
 			if uri, err := discovery.ParseUri(function.Operation); err != nil {
 				klog.V(log.I).Infof("Operation: %s not correspond to a valid service discovery configuration, it will be excluded from service discovery.", function.Operation)
+				metrics.IncDiscoveryFailures(workflow)
+				status.Error = err.Error()
 			} else {
 				if len(uri.Namespace) == 0 {
 					klog.V(log.I).Infof("Current operation has no configured namespace, workflow namespace: %s will be used instead.", workflow.Namespace)
@@ -119,6 +183,8 @@ func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceC
 				}
 				if address, err := catalog.Query(ctx, *uri, ""); err != nil {
 					klog.V(log.E).ErrorS(err, "An error was produced during service address resolution.", "serviceUri", function.Operation)
+					metrics.IncDiscoveryFailures(workflow)
+					status.Error = err.Error()
 				} else {
 					// when the knative service is invoked from the workflow as an Operation, the query params are not
 					// used for the microprofile property generation.
@@ -130,9 +196,11 @@ func generateDiscoveryProperties(ctx context.Context, catalog discovery.ServiceC
 					mpProperty := generateMicroprofileServiceCatalogProperty(trimmedUri)
 					klog.V(log.I).Infof("Generating microprofile service catalog property %s=%s.", mpProperty, address)
 					result.MustSet(mpProperty, address)
+					status.Address = address
 				}
 			}
+			statuses = append(statuses, status)
 		}
 	}
-	return result
+	return result, statuses
 }
@@ -57,7 +57,7 @@ func Test_generateDiscoveryProperties(t *testing.T) {
 	}
 
 	props := properties.MustLoadString(propertiesContent)
-	result := generateDiscoveryProperties(context.TODO(), catalogService, props, &operatorapi.SonataFlow{
+	result, statuses := generateDiscoveryProperties(context.TODO(), catalogService, props, &operatorapi.SonataFlow{
 		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: defaultNamespace},
 		Spec:       v1alpha08.SonataFlowSpec{Flow: workflow},
 	})
@@ -73,6 +73,42 @@ func Test_generateDiscoveryProperties(t *testing.T) {
 	assertHasProperty(t, result, "org.kie.kogito.addons.discovery.kubernetes\\:services.v1\\/my-service3?port\\=http-port", myService3Address)
 	assertHasProperty(t, result, "org.kie.kogito.addons.discovery.knative\\:services.v1.serving.knative.dev\\/namespace1\\/my-kn-service1", myKnService1Address)
 	assertHasProperty(t, result, "org.kie.kogito.addons.discovery.knative\\:services.v1.serving.knative.dev\\/my-kn-service3", myKnService3Address)
+
+	assert.Len(t, statuses, 6)
+	for _, status := range statuses {
+		if status.Property == "non_service4" {
+			assert.False(t, status.IsResolved())
+			assert.Empty(t, status.Address)
+		} else {
+			assert.True(t, status.IsResolved(), status.GetName())
+		}
+	}
+	assert.Equal(t, operatorapi.DiscoveredPropertyStatus{Property: "service1", Uri: "kubernetes:services.v1/namespace1/my-service1", Address: myService1Address}, statuses[0])
+}
+
+func TestGetDiscoveryResources(t *testing.T) {
+	propertiesContent := "property1=value1\n"
+	propertiesContent = propertiesContent + "service1=${kubernetes:services.v1/namespace1/my-service1}\n"
+	propertiesContent = propertiesContent + "service2=${kubernetes:deployments.v1.apps/my-deployment2?port=http-port}\n"
+	propertiesContent = propertiesContent + "non_service3=${kubernetes:--kaka}"
+
+	workflow := &operatorapi.SonataFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: defaultNamespace},
+		Spec: v1alpha08.SonataFlowSpec{Flow: v1alpha08.Flow{
+			Functions: []model.Function{{Name: "knServiceInvocation1", Operation: "knative:services.v1.serving.knative.dev/my-kn-service1?path=/knative-function1"}},
+		}},
+	}
+
+	resources := GetDiscoveryResources(workflow, propertiesContent)
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.Namespace+"/"+resource.GVK.Kind+"/"+resource.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"namespace1/services/my-service1",
+		defaultNamespace + "/deployments/my-deployment2",
+		defaultNamespace + "/services/my-kn-service1",
+	}, names)
 }
 
 func Test_generateMicroprofileServiceCatalogProperty(t *testing.T) {
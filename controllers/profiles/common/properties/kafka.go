@@ -0,0 +1,50 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"fmt"
+
+	"github.com/magiconair/properties"
+	cncfmodel "github.com/serverlessworkflow/sdk-go/v2/model"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+)
+
+// generateKafkaEventingWorkflowProperties returns the set of application properties required for the workflow to produce or consume
+// the events of every type declared in the workflow from the Kafka topics, with the SmallRye Kafka connector.
+// Never nil.
+func generateKafkaEventingWorkflowProperties(workflow *operatorapi.SonataFlow, kafka *operatorapi.KafkaEventingSpec) *properties.Properties {
+	props := properties.NewProperties()
+	props.Set(constants.KnativeHealthEnabled, "false")
+	if workflow == nil || len(workflow.Spec.Flow.Events) == 0 {
+		return props
+	}
+	props.Set(constants.KafkaBootstrapServersProperty, kafka.BootstrapServers)
+	for _, event := range workflow.Spec.Flow.Events {
+		topic := operatorapi.GetEventTopic(workflow, event.Name, event.Type)
+		// the workflow reads and writes the events of a given type from the channel named after the type
+		if event.Kind == cncfmodel.EventKindProduced {
+			props.Set(fmt.Sprintf(constants.KafkaOutgoingEventsConnector, event.Type), constants.SmallRyeKafka)
+			props.Set(fmt.Sprintf(constants.KafkaOutgoingEventsTopic, event.Type), topic)
+		} else {
+			props.Set(fmt.Sprintf(constants.KafkaIncomingEventsConnector, event.Type), constants.SmallRyeKafka)
+			props.Set(fmt.Sprintf(constants.KafkaIncomingEventsTopic, event.Type), topic)
+			props.Set(fmt.Sprintf(constants.KafkaIncomingEventsGroupID, event.Type), workflow.Name)
+		}
+	}
+	return props
+}
@@ -51,6 +51,11 @@ type ManagedPropertyHandler interface {
 	WithUserProperties(userProperties string) ManagedPropertyHandler
 	WithServiceDiscovery(ctx context.Context, catalog discovery.ServiceCatalog) ManagedPropertyHandler
 	Build() string
+	// GetServiceDiscoveryResults returns the result of the service discovery of every property and function resolved
+	// by the last Build.
+	GetServiceDiscoveryResults() []operatorapi.DiscoveredPropertyStatus
+	// GetPropertiesStatus returns the effective properties produced by the last Build with the source of their values.
+	GetPropertiesStatus() []operatorapi.PropertyStatus
 }
 
 type managedPropertyHandler struct {
@@ -60,6 +65,10 @@ type managedPropertyHandler struct {
 	ctx                      context.Context
 	userProperties           string
 	defaultManagedProperties *properties.Properties
+	discoveryResults         []operatorapi.DiscoveredPropertyStatus
+	// sources of the defaultManagedProperties, the ones not listed are managed by the operator
+	sources          map[string]operatorapi.PropertySource
+	propertiesStatus []operatorapi.PropertyStatus
 }
 
 func (a *managedPropertyHandler) WithUserProperties(properties string) ManagedPropertyHandler {
@@ -89,22 +98,40 @@ func (a *managedPropertyHandler) Build() string {
 	// Property expansion means resolving ${} within the properties and environment context. Quarkus will do that in runtime.
 	userProps.DisableExpansion = true
 
+	// kept to flag the discovery properties of the user, always replaced by the managed ones
+	definedUserProps := properties.NewProperties()
+	definedUserProps.Merge(userProps)
+
 	// Update discovery properties
 	removeDiscoveryProperties(userProps)
 	discoveryProps := properties.NewProperties()
+	a.discoveryResults = nil
 	if a.requireServiceDiscovery() {
 		// produce the MicroProfileConfigServiceCatalog properties for the service discovery property values if any.
-		discoveryProps.Merge(generateDiscoveryProperties(a.ctx, a.catalog, userProps, a.workflow))
+		var resolvedProps *properties.Properties
+		resolvedProps, a.discoveryResults = generateDiscoveryProperties(a.ctx, a.catalog, userProps, a.workflow)
+		discoveryProps.Merge(resolvedProps)
 	}
+	immutableProps := properties.MustLoadString(immutableApplicationProperties)
+	// resolved before building, since the builder drops the default managed properties overridden by the discovery
+	a.propertiesStatus = a.resolvePropertiesStatus(userProps, definedUserProps, immutableProps, discoveryProps)
 	userProps = utils.NewApplicationPropertiesBuilder().
 		WithInitialProperties(discoveryProps).
-		WithImmutableProperties(properties.MustLoadString(immutableApplicationProperties)).
+		WithImmutableProperties(immutableProps).
 		WithDefaultManagedProperties(a.defaultManagedProperties).
 		Build()
 
 	return userProps.String()
 }
 
+func (a *managedPropertyHandler) GetServiceDiscoveryResults() []operatorapi.DiscoveredPropertyStatus {
+	return a.discoveryResults
+}
+
+func (a *managedPropertyHandler) GetPropertiesStatus() []operatorapi.PropertyStatus {
+	return a.propertiesStatus
+}
+
 // withKogitoServiceUrl adds the property kogitoServiceUrlProperty to the application properties.
 // See Service Discovery https://kubernetes.io/docs/concepts/services-networking/service/#dns
 func (a *managedPropertyHandler) withKogitoServiceUrl() ManagedPropertyHandler {
@@ -129,6 +156,7 @@ func (a *managedPropertyHandler) withKafkaHealthCheckDisabled() ManagedPropertyH
 
 func (a *managedPropertyHandler) addDefaultManagedProperty(name string, value string) ManagedPropertyHandler {
 	a.defaultManagedProperties.Set(name, value)
+	delete(a.sources, name)
 	return a
 }
 
@@ -154,29 +182,48 @@ func NewManagedPropertyHandler(workflow *operatorapi.SonataFlow, platform *opera
 		if err != nil {
 			return nil, err
 		}
-		props.Merge(p)
+		handler.mergeManagedProperties(props, p, operatorapi.PlatformPropertySource)
+	}
+	// the workflow properties take precedence over the platform ones, but not over the properties required by the
+	// platform services
+	p, err := resolveWorkflowProperties(workflow)
+	if err != nil {
+		return nil, err
+	}
+	handler.mergeManagedProperties(props, p, operatorapi.WorkflowPropertySource)
+	if platform != nil {
 		p, err = persistence.ResolveWorkflowPersistenceProperties(workflow, platform)
 		if err != nil {
 			return nil, err
 		}
-		props.Merge(p)
+		handler.mergeManagedProperties(props, p, operatorapi.PersistencePropertySource)
 		p, err = services.GenerateDataIndexWorkflowProperties(workflow, platform)
 		if err != nil {
 			return nil, err
 		}
-		props.Merge(p)
+		handler.mergeManagedProperties(props, p, operatorapi.ServicePropertySource)
 		p, err = services.GenerateJobServiceWorkflowProperties(workflow, platform)
 		if err != nil {
 			return nil, err
 		}
-		props.Merge(p)
+		handler.mergeManagedProperties(props, p, operatorapi.ServicePropertySource)
 	}
 
-	p, err := generateKnativeEventingWorkflowProperties(workflow)
-	if err != nil {
-		return nil, err
+	if backend := operatorapi.GetWorkflowEventingBackend(workflow, platform); backend.IsKafka() {
+		handler.mergeManagedProperties(props, generateKafkaEventingWorkflowProperties(workflow, backend.Kafka), operatorapi.EventingPropertySource)
+	} else {
+		p, err := generateKnativeEventingWorkflowProperties(workflow)
+		if err != nil {
+			return nil, err
+		}
+		handler.mergeManagedProperties(props, p, operatorapi.EventingPropertySource)
+	}
+	monitoring := operatorapi.GetWorkflowMonitoring(workflow, platform)
+	if workflow.Spec.PodTemplate.IdlePolicy != nil && !workflow.IsKnativeDeployment() {
+		// the idle policy reads the workflow activity from its metrics
+		monitoring = &operatorapi.MonitoringSpec{Enabled: true}
 	}
-	props.Merge(p)
+	handler.mergeManagedProperties(props, services.GenerateMonitoringProperties(monitoring), operatorapi.OperatorPropertySource)
 	props.Sort()
 
 	handler.defaultManagedProperties = props
@@ -36,6 +36,7 @@ import (
 	"github.com/magiconair/properties"
 
 	"github.com/stretchr/testify/assert"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
 )
@@ -136,6 +137,37 @@ func Test_appPropertyHandler_WithUserPropertiesWithNoUserOverrides(t *testing.T)
 	assert.Equal(t, "false", generatedProps.GetString(constants.KogitoUserTasksEventsEnabled, ""))
 }
 
+func Test_appPropertyHandler_WithMonitoring(t *testing.T) {
+	workflow := test.GetBaseSonataFlow("default")
+	platform := test.GetBasePlatform()
+	platform.Spec.Monitoring = &operatorapi.MonitoringSpec{Enabled: true}
+	// users can't disable the metrics endpoint scraped by the monitors
+	userProperties := constants.QuarkusMicrometerPrometheusEnabled + "=false"
+	props, err := NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	generatedProps, propsErr := properties.LoadString(props.WithUserProperties(userProperties).Build())
+	assert.NoError(t, propsErr)
+	assert.Equal(t, "true", generatedProps.GetString(constants.QuarkusMicrometerEnabled, ""))
+	assert.Equal(t, "true", generatedProps.GetString(constants.QuarkusMicrometerPrometheusEnabled, ""))
+	assert.Equal(t, constants.QuarkusMetricsPath, generatedProps.GetString(constants.QuarkusMicrometerPrometheusPath, ""))
+
+	// the workflow monitoring overrides the platform one
+	workflow.Spec.Monitoring = &operatorapi.MonitoringSpec{Enabled: false}
+	props, err = NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	generatedProps, propsErr = properties.LoadString(props.Build())
+	assert.NoError(t, propsErr)
+	assert.NotContains(t, generatedProps.Keys(), constants.QuarkusMicrometerEnabled)
+
+	// the idle policy needs the metrics to read the workflow activity
+	workflow.Spec.PodTemplate.IdlePolicy = &operatorapi.IdlePolicySpec{}
+	props, err = NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	generatedProps, propsErr = properties.LoadString(props.Build())
+	assert.NoError(t, propsErr)
+	assert.Equal(t, "true", generatedProps.GetString(constants.QuarkusMicrometerPrometheusEnabled, ""))
+}
+
 func Test_appPropertyHandler_WithUserPropertiesWithServiceDiscovery(t *testing.T) {
 	//just add some user provided properties, no overrides.
 	userProperties := "property1=value1\nproperty2=value2\n"
@@ -683,3 +715,63 @@ func setJobServiceJDBC(jdbc string) plfmOptionFn {
 		p.Spec.Services.JobService.Persistence.PostgreSQL.JdbcUrl = jdbc
 	}
 }
+
+func Test_appPropertyHandler_WithKafkaEventing(t *testing.T) {
+	ns := "default"
+	workflow := test.GetVetEventSonataFlow(ns)
+	workflow.SetAnnotations(map[string]string{metadata.Profile: string(metadata.PreviewProfile)})
+	workflow.Spec.Eventing = &operatorapi.EventingSpec{
+		Events: []operatorapi.ConsumedEventSpec{{Name: "VetAppointmentRequestReceived", Topic: "requests"}},
+	}
+	enabled := true
+	platform := test.GetBasePlatform()
+	platform.Namespace = ns
+	platform.Spec = operatorapi.SonataFlowPlatformSpec{
+		Services: &operatorapi.ServicesPlatformSpec{
+			DataIndex:  &operatorapi.ServiceSpec{Enabled: &enabled},
+			JobService: &operatorapi.ServiceSpec{Enabled: &enabled},
+		},
+		Eventing: &operatorapi.PlatformEventingSpec{
+			EventingBackendSpec: operatorapi.EventingBackendSpec{
+				Mode:  operatorapi.KafkaEventingMode,
+				Kafka: &operatorapi.KafkaEventingSpec{BootstrapServers: "my-cluster-kafka-bootstrap:9092"},
+			},
+		},
+	}
+	services.SetServiceUrlsInWorkflowStatus(platform, workflow)
+
+	props, err := NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	generatedProps, propsErr := properties.LoadString(props.Build())
+	assert.NoError(t, propsErr)
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", generatedProps.GetString(constants.KafkaBootstrapServersProperty, ""))
+	assert.Equal(t, "false", generatedProps.GetString(constants.KnativeHealthEnabled, ""))
+	assert.NotContains(t, generatedProps.Keys(), constants.KogitoOutgoingEventsConnector)
+	assert.NotContains(t, generatedProps.Keys(), constants.KogitoIncomingEventsConnector)
+
+	assert.Equal(t, constants.SmallRyeKafka, generatedProps.GetString("mp.messaging.outgoing.events.vet.appointments.connector", ""))
+	assert.Equal(t, "events.vet.appointments", generatedProps.GetString("mp.messaging.outgoing.events.vet.appointments.topic", ""))
+	assert.Equal(t, constants.SmallRyeKafka, generatedProps.GetString("mp.messaging.incoming.events.vet.appointments.request.connector", ""))
+	assert.Equal(t, "requests", generatedProps.GetString("mp.messaging.incoming.events.vet.appointments.request.topic", ""))
+	assert.Equal(t, "vet", generatedProps.GetString("mp.messaging.incoming.events.vet.appointments.request.group.id", ""))
+
+	// the events of the platform services go through Kafka too
+	assert.Equal(t, constants.SmallRyeKafka, generatedProps.GetString(constants.KogitoProcessInstancesEventsConnector, ""))
+	assert.Equal(t, constants.KogitoProcessInstancesTopicName, generatedProps.GetString(constants.KogitoProcessInstancesEventsTopic, ""))
+	assert.Equal(t, constants.KogitoProcessDefinitionsTopicName, generatedProps.GetString(constants.KogitoProcessDefinitionsEventsTopic, ""))
+	assert.NotContains(t, generatedProps.Keys(), constants.KogitoProcessInstancesEventsURL)
+	assert.Equal(t, constants.SmallRyeKafka, generatedProps.GetString(constants.JobServiceRequestEventsConnector, ""))
+	assert.Equal(t, constants.JobServiceRequestEventsTopicName, generatedProps.GetString(constants.JobServiceRequestEventsTopic, ""))
+	assert.NotContains(t, generatedProps.Keys(), constants.JobServiceRequestEventsURL)
+
+	// a workflow can keep using Knative while the platform services use Kafka
+	workflow.Spec.Eventing.Mode = operatorapi.KnativeEventingMode
+	workflow.Spec.Sink = &duckv1.Destination{Ref: &duckv1.KReference{Kind: "Broker", Name: "default", APIVersion: "eventing.knative.dev/v1"}}
+	props, err = NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	generatedProps, propsErr = properties.LoadString(props.Build())
+	assert.NoError(t, propsErr)
+	assert.Equal(t, constants.QuarkusHTTP, generatedProps.GetString(constants.KogitoOutgoingEventsConnector, ""))
+	assert.NotContains(t, generatedProps.Keys(), "mp.messaging.outgoing.events.vet.appointments.connector")
+	assert.Equal(t, constants.SmallRyeKafka, generatedProps.GetString(constants.KogitoProcessInstancesEventsConnector, ""))
+}
@@ -39,7 +39,7 @@ func resolvePlatformWorkflowProperties(platform *operatorapi.SonataFlowPlatform)
 		if len(propVar.Value) > 0 {
 			props.Set(propVar.Name, propVar.Value)
 		} else if propVar.ValueFrom != nil {
-			val, err := getPropVarRefValue(propVar.ValueFrom, platform.Namespace)
+			val, err := getPropVarRefValue(propVar.ValueFrom, getPropVarRefNamespace(platform, propVar.Name))
 			if err != nil {
 				return nil, err
 			}
@@ -50,6 +50,15 @@ func resolvePlatformWorkflowProperties(platform *operatorapi.SonataFlowPlatform)
 	return props, nil
 }
 
+// getPropVarRefNamespace returns the namespace where the source of the given property is looked up. The properties
+// inherited from the SonataFlowClusterPlatform refer to the namespace of the platform sharing them.
+func getPropVarRefNamespace(platform *operatorapi.SonataFlowPlatform, name string) string {
+	if platform.Status.ClusterPlatformRef != nil && platform.Status.EffectiveConfig.IsInherited(operatorapi.InheritedPropertyField(name)) {
+		return platform.Status.ClusterPlatformRef.PlatformRef.Namespace
+	}
+	return platform.Namespace
+}
+
 func getPropVarRefValue(from *operatorapi.PropertyVarSource, namespace string) (string, error) {
 	// same order as k8s api (we try to fetch first a secret)
 	if from.SecretKeyRef != nil {
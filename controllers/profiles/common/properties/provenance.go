@@ -0,0 +1,90 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"sort"
+
+	"github.com/magiconair/properties"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+)
+
+// mergeManagedProperties merges the given default managed properties into props, recording their source.
+func (a *managedPropertyHandler) mergeManagedProperties(props, p *properties.Properties, source operatorapi.PropertySource) {
+	if a.sources == nil {
+		a.sources = map[string]operatorapi.PropertySource{}
+	}
+	for _, k := range p.Keys() {
+		a.sources[k] = source
+	}
+	props.Merge(p)
+}
+
+// resolvePropertiesStatus lists the effective properties of the workflow, sorted by name, in the order of precedence
+// they have at runtime: the Secret-backed properties delivered as environment variables, then the immutable, the
+// discovery and the default managed properties, then the user properties and, at last, the Secret-backed properties
+// delivered as files. definedUserProps holds every property of the user, including the discovery ones dropped from
+// userProps. The discovery properties are resolved from the user ones, so they are never flagged as overridden.
+func (a *managedPropertyHandler) resolvePropertiesStatus(userProps, definedUserProps, immutableProps, discoveryProps *properties.Properties) []operatorapi.PropertyStatus {
+	effective := map[string]operatorapi.PropertySource{}
+	setIfAbsent := func(name string, source operatorapi.PropertySource) {
+		if _, ok := effective[name]; !ok {
+			effective[name] = source
+		}
+	}
+	secretProps := a.workflow.Spec.Properties.GetSecretProperties()
+	fileDelivery := a.workflow.Spec.Properties.GetSecretsDelivery() == operatorapi.FileSecretPropertiesDelivery
+	if !fileDelivery {
+		for _, propVar := range secretProps {
+			setIfAbsent(propVar.Name, operatorapi.WorkflowPropertySource)
+		}
+	}
+	for _, k := range immutableProps.Keys() {
+		setIfAbsent(k, operatorapi.ImmutablePropertySource)
+	}
+	for _, k := range discoveryProps.Keys() {
+		setIfAbsent(k, operatorapi.DiscoveryPropertySource)
+	}
+	for _, k := range a.defaultManagedProperties.Keys() {
+		source, ok := a.sources[k]
+		if !ok {
+			source = operatorapi.OperatorPropertySource
+		}
+		setIfAbsent(k, source)
+	}
+	for _, k := range userProps.Keys() {
+		setIfAbsent(k, operatorapi.UserPropertySource)
+	}
+	if fileDelivery {
+		for _, propVar := range secretProps {
+			setIfAbsent(propVar.Name, operatorapi.WorkflowPropertySource)
+		}
+	}
+
+	status := make([]operatorapi.PropertyStatus, 0, len(effective))
+	for name, source := range effective {
+		_, inUserProps := definedUserProps.Get(name)
+		status = append(status, operatorapi.PropertyStatus{
+			Name:           name,
+			Source:         source,
+			UserOverridden: inUserProps && source != operatorapi.UserPropertySource && source != operatorapi.DiscoveryPropertySource,
+		})
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Name < status[j].Name
+	})
+	return status
+}
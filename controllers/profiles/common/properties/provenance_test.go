@@ -0,0 +1,108 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func getPropertyStatus(t *testing.T, status []v1alpha08.PropertyStatus, name string) v1alpha08.PropertyStatus {
+	for _, s := range status {
+		if s.Name == name {
+			return s
+		}
+	}
+	assert.Failf(t, "property not reported", "property %s is not in the status", name)
+	return v1alpha08.PropertyStatus{}
+}
+
+func Test_GetPropertiesStatus(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.Properties = &v1alpha08.PropertyFlowSpec{
+		Flow: []v1alpha08.PropertyVar{
+			{Name: "quarkus.log.category", Value: "DEBUG"},
+			{Name: "quarkus.custom.secret", ValueFrom: &v1alpha08.PropertyVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "secretWorkflowTest"}}}},
+		},
+	}
+	platform := test.GetBasePlatform()
+	utils.SetClient(test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow).Build())
+
+	handler, err := NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	handler.WithUserProperties("quarkus.http.port=9090\n" +
+		"quarkus.log.level=DEBUG\n" +
+		"kogito.service.url=http://my-workflow\n" +
+		"quarkus.custom.secret=my-value\n" +
+		"my.user.property=value\n" +
+		"org.kie.kogito.addons.discovery.kubernetes\\:services.v1\\/namespace1\\/my-service1=http://10.110.90.1:80\n").
+		Build()
+	status := handler.GetPropertiesStatus()
+
+	assert.True(t, sort.SliceIsSorted(status, func(i, j int) bool { return status[i].Name < status[j].Name }))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "quarkus.http.port", Source: v1alpha08.ImmutablePropertySource, UserOverridden: true},
+		getPropertyStatus(t, status, "quarkus.http.port"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "quarkus.http.host", Source: v1alpha08.ImmutablePropertySource},
+		getPropertyStatus(t, status, "quarkus.http.host"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "kogito.service.url", Source: v1alpha08.OperatorPropertySource, UserOverridden: true},
+		getPropertyStatus(t, status, "kogito.service.url"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "quarkus.log.level", Source: v1alpha08.PlatformPropertySource, UserOverridden: true},
+		getPropertyStatus(t, status, "quarkus.log.level"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "quarkus.log.category", Source: v1alpha08.WorkflowPropertySource},
+		getPropertyStatus(t, status, "quarkus.log.category"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "kogito.events.processinstances.enabled", Source: v1alpha08.ServicePropertySource},
+		getPropertyStatus(t, status, "kogito.events.processinstances.enabled"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: constants.KnativeHealthEnabled, Source: v1alpha08.EventingPropertySource},
+		getPropertyStatus(t, status, constants.KnativeHealthEnabled))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "my.user.property", Source: v1alpha08.UserPropertySource},
+		getPropertyStatus(t, status, "my.user.property"))
+	// the environment variables take precedence over the user properties
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "quarkus.custom.secret", Source: v1alpha08.WorkflowPropertySource, UserOverridden: true},
+		getPropertyStatus(t, status, "quarkus.custom.secret"))
+	// the discovery properties of the user are always dropped
+	for _, s := range status {
+		assert.NotContains(t, s.Name, microprofileServiceCatalogPropertyPrefix)
+	}
+
+	// the properties resolved by the discovery are not overridden by the user ones they are resolved from
+	handler, err = NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	handler.WithUserProperties("service1=${kubernetes:services.v1/namespace1/my-service1}\n").
+		WithServiceDiscovery(context.TODO(), &mockCatalogService{}).
+		Build()
+	status = handler.GetPropertiesStatus()
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "service1", Source: v1alpha08.DiscoveryPropertySource},
+		getPropertyStatus(t, status, "service1"))
+
+	// the Secret files have the lowest precedence
+	workflow.Spec.Properties.SecretsDelivery = v1alpha08.FileSecretPropertiesDelivery
+	handler, err = NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	handler.WithUserProperties("quarkus.custom.secret=my-value\n").Build()
+	status = handler.GetPropertiesStatus()
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: "quarkus.custom.secret", Source: v1alpha08.UserPropertySource},
+		getPropertyStatus(t, status, "quarkus.custom.secret"))
+	assert.Equal(t, v1alpha08.PropertyStatus{Name: constants.SecretPropertiesFileLocations, Source: v1alpha08.WorkflowPropertySource},
+		getPropertyStatus(t, status, constants.SecretPropertiesFileLocations))
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/discovery"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/magiconair/properties"
+)
+
+var nonEnvVarNameCharsExpr = regexp.MustCompile("[^a-zA-Z0-9_]")
+
+// resolveWorkflowProperties returns the literal and ConfigMap-backed properties of the workflow. The Secret-backed
+// properties are left out, only the location of their files is added when they are delivered as files.
+func resolveWorkflowProperties(workflow *operatorapi.SonataFlow) (*properties.Properties, error) {
+	props := properties.NewProperties()
+	if workflow.Spec.Properties == nil {
+		return props, nil
+	}
+	for _, propVar := range workflow.Spec.Properties.Flow {
+		if len(propVar.Value) > 0 {
+			props.Set(propVar.Name, propVar.Value)
+		} else if propVar.ValueFrom != nil && propVar.ValueFrom.SecretKeyRef == nil {
+			val, err := getPropVarRefValue(propVar.ValueFrom, workflow.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			props.Set(propVar.Name, val)
+		}
+	}
+	if len(workflow.Spec.Properties.GetSecretProperties()) > 0 &&
+		workflow.Spec.Properties.GetSecretsDelivery() == operatorapi.FileSecretPropertiesDelivery {
+		props.Set(constants.SecretPropertiesFileLocations, constants.SecretPropertiesMountPath)
+	}
+	return props, nil
+}
+
+// GetPropertiesReferences returns the Secrets and the ConfigMaps the workflow properties are read from, a change in any
+// of them must roll the workflow out.
+func GetPropertiesReferences(workflow *operatorapi.SonataFlow) []discovery.ResourceUri {
+	if workflow.Spec.Properties == nil {
+		return nil
+	}
+	var uris []discovery.ResourceUri
+	for _, propVar := range workflow.Spec.Properties.Flow {
+		if len(propVar.Value) > 0 || propVar.ValueFrom == nil {
+			continue
+		}
+		if propVar.ValueFrom.SecretKeyRef != nil {
+			uris = append(uris, propertyReference("secrets", workflow.Namespace, propVar.ValueFrom.SecretKeyRef.Name))
+		} else if propVar.ValueFrom.ConfigMapKeyRef != nil {
+			uris = append(uris, propertyReference("configmaps", workflow.Namespace, propVar.ValueFrom.ConfigMapKeyRef.Name))
+		}
+	}
+	return uris
+}
+
+// GetPropertiesReferencesVersions returns the resourceVersion of the Secret or the ConfigMap referenced by each workflow
+// property, keyed by property name. The version is empty when the object doesn't exist.
+func GetPropertiesReferencesVersions(ctx context.Context, c client.Client, workflow *operatorapi.SonataFlow) (map[string]string, error) {
+	versions := map[string]string{}
+	if workflow.Spec.Properties == nil {
+		return versions, nil
+	}
+	for _, propVar := range workflow.Spec.Properties.Flow {
+		if len(propVar.Value) > 0 || propVar.ValueFrom == nil {
+			continue
+		}
+		var object client.Object
+		var name string
+		if propVar.ValueFrom.SecretKeyRef != nil {
+			object, name = &corev1.Secret{}, propVar.ValueFrom.SecretKeyRef.Name
+		} else if propVar.ValueFrom.ConfigMapKeyRef != nil {
+			object, name = &corev1.ConfigMap{}, propVar.ValueFrom.ConfigMapKeyRef.Name
+		} else {
+			continue
+		}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: name}, object); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		versions[propVar.Name] = object.GetResourceVersion()
+	}
+	return versions, nil
+}
+
+func propertyReference(kind, namespace, name string) discovery.ResourceUri {
+	return *discovery.NewResourceUriBuilder(discovery.KubernetesScheme).Kind(kind).Version("v1").Namespace(namespace).Name(name).Build()
+}
+
+// GetPropertyEnvVarName returns the name of the environment variable MicroProfile Config reads a property from,
+// every non-alphanumeric character replaced by an underscore and converted to uppercase.
+func GetPropertyEnvVarName(name string) string {
+	return strings.ToUpper(nonEnvVarNameCharsExpr.ReplaceAllString(name, "_"))
+}
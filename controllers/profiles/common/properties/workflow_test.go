@@ -0,0 +1,118 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_resolveWorkflowProperties(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secretWorkflowTest", Namespace: t.Name()},
+		Data:       map[string][]byte{"my-key": []byte("secret")},
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "configMapWorkflowTest", Namespace: t.Name()},
+		Data:       map[string]string{"my-key": "value"},
+	}
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.Properties = &v1alpha08.PropertyFlowSpec{
+		Flow: []v1alpha08.PropertyVar{
+			{Name: "quarkus.log.category", Value: "DEBUG"},
+			{Name: "quarkus.custom.property", ValueFrom: &v1alpha08.PropertyVarSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "configMapWorkflowTest"}}}},
+			{Name: "quarkus.custom.secret", ValueFrom: &v1alpha08.PropertyVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "secretWorkflowTest"}}}},
+		},
+	}
+	utils.SetClient(test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, secret, cm).Build())
+
+	props, err := resolveWorkflowProperties(workflow)
+	assert.NoError(t, err)
+	assertHasProperty(t, props, "quarkus.log.category", "DEBUG")
+	assertHasProperty(t, props, "quarkus.custom.property", "value")
+	_, hasSecret := props.Get("quarkus.custom.secret")
+	assert.False(t, hasSecret, "the secret-backed properties must never be written to the managed properties")
+	assert.Len(t, props.Keys(), 2)
+
+	workflow.Spec.Properties.SecretsDelivery = v1alpha08.FileSecretPropertiesDelivery
+	props, err = resolveWorkflowProperties(workflow)
+	assert.NoError(t, err)
+	assertHasProperty(t, props, constants.SecretPropertiesFileLocations, constants.SecretPropertiesMountPath)
+
+	// the workflow properties take precedence over the platform ones
+	platform := test.GetBasePlatform()
+	platform.Spec.Properties = &v1alpha08.PropertyPlatformSpec{Flow: []v1alpha08.PropertyVar{{Name: "quarkus.log.category", Value: "INFO"}}}
+	handler, err := NewManagedPropertyHandler(workflow, platform)
+	assert.NoError(t, err)
+	assert.Contains(t, handler.Build(), "quarkus.log.category = DEBUG")
+	assert.NotContains(t, handler.Build(), "quarkus.custom.secret")
+}
+
+func Test_GetPropertyEnvVarName(t *testing.T) {
+	assert.Equal(t, "QUARKUS_REST_CLIENT_MY_API_API_KEY", GetPropertyEnvVarName("quarkus.rest-client.my_api.api-key"))
+	assert.Equal(t, "MY_PROPERTY__QUOTED_", GetPropertyEnvVarName(`my.property."quoted"`))
+}
+
+func Test_GetPropertiesReferences(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	assert.Empty(t, GetPropertiesReferences(workflow))
+
+	workflow.Spec.Properties = &v1alpha08.PropertyFlowSpec{
+		Flow: []v1alpha08.PropertyVar{
+			{Name: "quarkus.log.category", Value: "DEBUG"},
+			{Name: "quarkus.custom.property", ValueFrom: &v1alpha08.PropertyVarSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "configMapWorkflowTest"}}}},
+			{Name: "quarkus.custom.secret", ValueFrom: &v1alpha08.PropertyVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "secretWorkflowTest"}}}},
+		},
+	}
+	uris := GetPropertiesReferences(workflow)
+	assert.Len(t, uris, 2)
+	assert.Equal(t, "configmaps", uris[0].GVK.Kind)
+	assert.Equal(t, "configMapWorkflowTest", uris[0].Name)
+	assert.Equal(t, "secrets", uris[1].GVK.Kind)
+	assert.Equal(t, "secretWorkflowTest", uris[1].Name)
+	assert.Equal(t, t.Name(), uris[1].Namespace)
+}
+
+func Test_GetPropertiesReferencesVersions(t *testing.T) {
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secretWorkflowTest", Namespace: t.Name(), ResourceVersion: "7"}}
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.Properties = &v1alpha08.PropertyFlowSpec{
+		Flow: []v1alpha08.PropertyVar{
+			{Name: "quarkus.log.category", Value: "DEBUG"},
+			{Name: "quarkus.custom.property", ValueFrom: &v1alpha08.PropertyVarSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "configMapWorkflowTest"}}}},
+			{Name: "quarkus.custom.secret", ValueFrom: &v1alpha08.PropertyVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{Key: "my-key", LocalObjectReference: v1.LocalObjectReference{Name: "secretWorkflowTest"}}}},
+		},
+	}
+	client := test.NewSonataFlowClientBuilder().WithRuntimeObjects(secret).Build()
+
+	versions, err := GetPropertiesReferencesVersions(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	// the missing ConfigMap has an empty version
+	assert.Equal(t, map[string]string{"quarkus.custom.property": "", "quarkus.custom.secret": "7"}, versions)
+}
@@ -22,7 +22,10 @@ package common
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/discovery"
@@ -35,8 +38,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
 )
 
@@ -51,7 +56,7 @@ type StateSupport struct {
 // PerformStatusUpdate updates the SonataFlow Status conditions
 func (s *StateSupport) PerformStatusUpdate(ctx context.Context, workflow *operatorapi.SonataFlow) (bool, error) {
 	var err error
-	pl, err := platform.GetActivePlatform(ctx, s.C, workflow.Namespace)
+	pl, err := platform.GetEffectivePlatform(ctx, s.C, workflow.Namespace)
 	if err != nil {
 		return false, err
 	}
@@ -88,7 +93,7 @@ func NewReconciler(support *StateSupport, stateMachine *ReconciliationStateMachi
 // Reconcile does the actual reconciliation algorithm based on a set of ReconciliationState
 func (b *Reconciler) Reconcile(ctx context.Context, workflow *operatorapi.SonataFlow) (ctrl.Result, error) {
 	workflow.Status.Manager().InitializeConditions()
-	result, objects, err := b.reconciliationStateMachine.do(ctx, workflow)
+	result, objects, err := b.reconciliationStateMachine.do(ctx, b.StateSupport, workflow)
 	if err != nil {
 		return result, err
 	}
@@ -98,34 +103,160 @@ func (b *Reconciler) Reconcile(ctx context.Context, workflow *operatorapi.Sonata
 	return result, err
 }
 
-// NewReconciliationStateMachine builder for the ReconciliationStateMachine
+// NewReconciliationStateMachine builder for the ReconciliationStateMachine. The states are evaluated in the given
+// order, use AddTransition and AddGuardedTransition to declare how the workflow moves between them.
 func NewReconciliationStateMachine(states ...profiles.ReconciliationState) *ReconciliationStateMachine {
 	return &ReconciliationStateMachine{
-		states: states,
+		states:      states,
+		transitions: make(map[string]map[string]TransitionGuard),
 	}
 }
 
-// ReconciliationStateMachine implements (sort of) the command pattern and delegate to a chain of ReconciliationState
-// the actual task to reconcile in a given workflow condition
+// TransitionGuard tells if the workflow can take a declared transition, on top of the CanReconcile guard of the target
+// state.
+type TransitionGuard func(workflow *operatorapi.SonataFlow) bool
+
+// ReconciliationStateMachine delegates to a ReconciliationState the actual task to reconcile in a given workflow
+// condition.
 //
-// TODO: implement state transition, so based on a given condition we do the status update which actively transition the object state
+// The state handling the workflow is recorded in its status. From there, the machine only moves to the states declared
+// as transitions whose guards, and the CanReconcile guard of the target state, accept the workflow. Every transition is kept in the status history and
+// reported as an Event. Workflows that no state can handle are reported with the Reconciled condition.
 type ReconciliationStateMachine struct {
-	states []profiles.ReconciliationState
+	states      []profiles.ReconciliationState
+	transitions map[string]map[string]TransitionGuard
+}
+
+// AddTransition declares that the workflows handled by the state from can move to the states to.
+func (r *ReconciliationStateMachine) AddTransition(from profiles.ReconciliationState, to ...profiles.ReconciliationState) *ReconciliationStateMachine {
+	return r.AddGuardedTransition(from, nil, to...)
+}
+
+// AddGuardedTransition declares that the workflows handled by the state from can move to the states to when the
+// guard accepts them. A nil guard accepts every workflow.
+func (r *ReconciliationStateMachine) AddGuardedTransition(from profiles.ReconciliationState, guard TransitionGuard, to ...profiles.ReconciliationState) *ReconciliationStateMachine {
+	targets, ok := r.transitions[from.Name()]
+	if !ok {
+		targets = make(map[string]TransitionGuard)
+		r.transitions[from.Name()] = targets
+	}
+	for _, state := range to {
+		targets[state.Name()] = guard
+	}
+	return r
+}
+
+// canMove tells if the workflow handled by the state from can be handled by the state to.
+func (r *ReconciliationStateMachine) canMove(workflow *operatorapi.SonataFlow, from, to string) bool {
+	if from == to || !r.hasState(from) {
+		// first reconciliation, or the workflow comes from another profile
+		return true
+	}
+	guard, declared := r.transitions[from][to]
+	return declared && (guard == nil || guard(workflow))
 }
 
-func (r *ReconciliationStateMachine) do(ctx context.Context, workflow *operatorapi.SonataFlow) (ctrl.Result, []client.Object, error) {
+func (r *ReconciliationStateMachine) hasState(name string) bool {
+	for _, state := range r.states {
+		if state.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconciliationStateMachine) do(ctx context.Context, support *StateSupport, workflow *operatorapi.SonataFlow) (ctrl.Result, []client.Object, error) {
+	current := workflow.Status.ReconciliationState
+	var undeclared []string
 	for _, h := range r.states {
-		if h.CanReconcile(workflow) {
-			klog.V(log.I).InfoS("Found a condition to reconcile.", "Conditions", workflow.Status.Conditions)
-			result, objs, err := h.Do(ctx, workflow)
-			if err != nil {
-				return result, objs, err
-			}
-			if err = h.PostReconcile(ctx, workflow); err != nil {
-				klog.V(log.E).ErrorS(err, "Error in Post Reconcile actions.", "Workflow", workflow.Name, "Conditions", workflow.Status.Conditions)
+		if !h.CanReconcile(workflow) {
+			continue
+		}
+		if !r.canMove(workflow, current, h.Name()) {
+			undeclared = append(undeclared, h.Name())
+			continue
+		}
+		klog.V(log.I).InfoS("Found a state to reconcile.", "State", h.Name(), "Conditions", workflow.Status.Conditions)
+		previous := workflow.Status.DeepCopy()
+		transition := workflow.Status.RecordStateTransition(h.Name())
+		if transition != nil {
+			support.Recorder.Eventf(workflow, corev1.EventTypeNormal, "StateTransition", "Workflow %s moved from state %s to state %s.",
+				workflow.Name, stateOrNone(transition.From), transition.To)
+		}
+		unknownState := workflow.Status.GetCondition(api.ReconciledConditionType) != nil
+		if unknownState {
+			_ = workflow.Status.Manager().ClearCondition(api.ReconciledConditionType)
+		}
+		// the field conflicts are reported again by the ensurers while they last
+		_ = workflow.Status.Manager().ClearCondition(api.FieldsAppliedConditionType)
+
+		result, objs, err := h.Do(ctx, workflow)
+		conflictsChanged := !sameCondition(previous.GetCondition(api.FieldsAppliedConditionType), workflow.Status.GetCondition(api.FieldsAppliedConditionType))
+		if transition != nil || unknownState || conflictsChanged {
+			// the state might not update the status, the state machine bookkeeping is persisted on its own
+			base := workflow.DeepCopy()
+			base.Status.ReconciliationState = previous.ReconciliationState
+			base.Status.StateTransitions = previous.StateTransitions
+			if unknownState || conflictsChanged {
+				base.Status.Conditions = previous.Conditions
 			}
+			patchReconciliationState(ctx, support.C, workflow, base)
+		}
+		if err != nil {
 			return result, objs, err
 		}
+		if err = h.PostReconcile(ctx, workflow); err != nil {
+			klog.V(log.E).ErrorS(err, "Error in Post Reconcile actions.", "Workflow", workflow.Name, "Conditions", workflow.Status.Conditions)
+		}
+		return result, objs, err
+	}
+	return r.markUnknownState(ctx, support, workflow, undeclared)
+}
+
+// markUnknownState reports that no state can handle the workflow with the Reconciled condition, then waits for the
+// workflow conditions to change.
+func (r *ReconciliationStateMachine) markUnknownState(ctx context.Context, support *StateSupport, workflow *operatorapi.SonataFlow, undeclared []string) (ctrl.Result, []client.Object, error) {
+	var message string
+	if len(undeclared) > 0 {
+		message = fmt.Sprintf("The workflow can't move from state %s to state %s, the transition isn't declared.",
+			stateOrNone(workflow.Status.ReconciliationState), strings.Join(undeclared, ", "))
+	} else {
+		var conditions []string
+		for _, cond := range workflow.Status.Conditions {
+			conditions = append(conditions, fmt.Sprintf("%s=%s(%s)", cond.Type, cond.Status, cond.Reason))
+		}
+		message = fmt.Sprintf("No state can reconcile the workflow conditions %s.", strings.Join(conditions, ", "))
+	}
+	klog.V(log.W).InfoS("The workflow is in an unknown state.", "Workflow", workflow.Name, "Namespace", workflow.Namespace, "Message", message)
+
+	cond := workflow.Status.GetCondition(api.ReconciledConditionType)
+	if !cond.IsFalse() || cond.GetMessage() != message {
+		base := workflow.DeepCopy()
+		workflow.Status.Manager().MarkFalse(api.ReconciledConditionType, api.UnknownStateReason, "%s", message)
+		support.Recorder.Event(workflow, corev1.EventTypeWarning, api.UnknownStateReason, message)
+		patchReconciliationState(ctx, support.C, workflow, base)
+	}
+	return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, nil, nil
+}
+
+// patchReconciliationState persists the status changes made by the state machine on top of base.
+func patchReconciliationState(ctx context.Context, c client.Client, workflow, base *operatorapi.SonataFlow) {
+	if err := c.Status().Patch(ctx, workflow, client.MergeFrom(base)); err != nil && !errors.IsNotFound(err) {
+		klog.V(log.E).ErrorS(err, "Failed to record the reconciliation state", "Workflow", workflow.Name, "Namespace", workflow.Namespace)
+	}
+}
+
+// sameCondition compares the given conditions regardless of their update time.
+func sameCondition(a, b *api.Condition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Status == b.Status && a.Reason == b.Reason && a.Message == b.Message
+}
+
+func stateOrNone(state string) string {
+	if len(state) == 0 {
+		return "<none>"
 	}
-	return ctrl.Result{}, nil, fmt.Errorf("the workflow %s in the namespace %s is in an unknown state condition. Can't reconcilie. Status is: %v", workflow.Name, workflow.Namespace, workflow.Status)
+	return state
 }
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+type fakeState struct {
+	name         string
+	canReconcile func(workflow *v1alpha08.SonataFlow) bool
+	calls        int
+}
+
+func (f *fakeState) Name() string {
+	return f.name
+}
+
+func (f *fakeState) CanReconcile(workflow *v1alpha08.SonataFlow) bool {
+	return f.canReconcile(workflow)
+}
+
+func (f *fakeState) Do(ctx context.Context, workflow *v1alpha08.SonataFlow) (ctrl.Result, []client.Object, error) {
+	f.calls++
+	return ctrl.Result{}, nil, nil
+}
+
+func (f *fakeState) PostReconcile(ctx context.Context, workflow *v1alpha08.SonataFlow) error {
+	return nil
+}
+
+func builtIs(status bool) func(workflow *v1alpha08.SonataFlow) bool {
+	return func(workflow *v1alpha08.SonataFlow) bool {
+		return workflow.Status.GetCondition(api.BuiltConditionType).IsTrue() == status
+	}
+}
+
+func TestReconciliationStateMachine(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Status.Manager().InitializeConditions()
+	cli := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow).WithStatusSubresource(workflow).Build()
+	recorder := record.NewFakeRecorder(10)
+	support := &StateSupport{C: cli, Recorder: recorder}
+
+	build := &fakeState{name: "Build", canReconcile: builtIs(false)}
+	deploy := &fakeState{name: "Deploy", canReconcile: builtIs(true)}
+	stateMachine := NewReconciliationStateMachine(build, deploy).AddTransition(build, deploy)
+
+	_, _, err := stateMachine.do(context.TODO(), support, workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, build.calls)
+	assert.Equal(t, "Normal StateTransition Workflow greeting moved from state <none> to state Build.", <-recorder.Events)
+
+	workflow.Status.Manager().MarkTrue(api.BuiltConditionType)
+	_, _, err = stateMachine.do(context.TODO(), support, workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deploy.calls)
+	assert.Equal(t, "Normal StateTransition Workflow greeting moved from state Build to state Deploy.", <-recorder.Events)
+
+	// the transitions are persisted even if the states don't update the status
+	stored := &v1alpha08.SonataFlow{}
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), stored))
+	assert.Equal(t, "Deploy", stored.Status.ReconciliationState)
+	assert.Len(t, stored.Status.StateTransitions, 2)
+	assert.Equal(t, "Build", stored.Status.StateTransitions[0].From)
+	assert.Equal(t, "Deploy", stored.Status.StateTransitions[0].To)
+
+	// Deploy -> Build isn't declared
+	workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.BuildFailedReason, "")
+	result, _, err := stateMachine.do(context.TODO(), support, workflow)
+	assert.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter)
+	assert.Equal(t, 1, build.calls)
+	cond := workflow.Status.GetCondition(api.ReconciledConditionType)
+	assert.True(t, cond.IsFalse())
+	assert.Equal(t, api.UnknownStateReason, cond.Reason)
+	assert.Contains(t, cond.Message, "from state Deploy to state Build")
+	assert.Contains(t, <-recorder.Events, "Warning UnknownState")
+
+	// the condition is gone once a state handles the workflow again
+	stateMachine.AddTransition(deploy, build)
+	_, _, err = stateMachine.do(context.TODO(), support, workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, build.calls)
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), stored))
+	assert.Nil(t, stored.Status.GetCondition(api.ReconciledConditionType))
+	assert.Equal(t, "Build", stored.Status.ReconciliationState)
+}
+
+func TestRecordStateTransition(t *testing.T) {
+	status := &v1alpha08.SonataFlowStatus{}
+	assert.Nil(t, status.RecordStateTransition(""))
+	for i := 0; i < v1alpha08.MaxStateTransitions+5; i++ {
+		state := "A"
+		if i%2 == 1 {
+			state = "B"
+		}
+		assert.NotNil(t, status.RecordStateTransition(state))
+		assert.Nil(t, status.RecordStateTransition(state))
+	}
+	assert.Len(t, status.StateTransitions, v1alpha08.MaxStateTransitions)
+	assert.Equal(t, "A", status.StateTransitions[0].To)
+	assert.Equal(t, "B", status.StateTransitions[0].From)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+)
+
+// ReportServiceDiscovery reports the service discovery results, set in the workflow status while its managed properties
+// are built, in the ServiceDiscovery condition. A Warning Event is raised for every unresolved uri whenever the results
+// change. Returns true if the workflow must not be deployed, because its policy blocks the unresolved uris.
+func ReportServiceDiscovery(support *StateSupport, workflow *operatorapi.SonataFlow) bool {
+	results := workflow.Status.ServiceDiscovery
+	if len(results) == 0 {
+		_ = workflow.Status.Manager().ClearCondition(api.ServiceDiscoveryConditionType)
+		return false
+	}
+
+	var resolved, unresolved []string
+	for _, result := range results {
+		if result.IsResolved() {
+			resolved = append(resolved, fmt.Sprintf("%s=%s", result.GetName(), result.Address))
+		} else {
+			unresolved = append(unresolved, fmt.Sprintf("%s: %s", result.GetName(), result.Error))
+		}
+	}
+	if len(unresolved) == 0 {
+		workflow.Status.Manager().MarkTrueWithReason(api.ServiceDiscoveryConditionType, api.DiscoveryResolvedReason, "%s", strings.Join(resolved, "; "))
+		return false
+	}
+
+	previous := workflow.Status.GetCondition(api.ServiceDiscoveryConditionType).GetMessage()
+	message := fmt.Sprintf("Unresolved: %s", strings.Join(unresolved, "; "))
+	if len(resolved) > 0 {
+		message += fmt.Sprintf(". Resolved: %s", strings.Join(resolved, "; "))
+	}
+	workflow.Status.Manager().MarkFalse(api.ServiceDiscoveryConditionType, api.DiscoveryFailedReason, "%s", message)
+	if message != previous {
+		for _, result := range results {
+			if !result.IsResolved() {
+				support.Recorder.Eventf(workflow, corev1.EventTypeWarning, api.DiscoveryFailedReason,
+					"Unable to resolve %s of workflow %s from %s: %s", result.GetName(), workflow.Name, result.Uri, result.Error)
+			}
+		}
+	}
+
+	if !workflow.Spec.ServiceDiscovery.IsBlocking() {
+		return false
+	}
+	workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DiscoveryFailedReason,
+		"Waiting for the service discovery of %s to be resolved", strings.Join(unresolvedNames(results), ", "))
+	return true
+}
+
+func unresolvedNames(results []operatorapi.DiscoveredPropertyStatus) []string {
+	var names []string
+	for _, result := range results {
+		if !result.IsResolved() {
+			names = append(names, result.GetName())
+		}
+	}
+	return names
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func TestReportServiceDiscovery(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	recorder := record.NewFakeRecorder(10)
+	support := &StateSupport{Recorder: recorder}
+
+	assert.False(t, ReportServiceDiscovery(support, workflow))
+	assert.Nil(t, workflow.Status.GetCondition(api.ServiceDiscoveryConditionType))
+
+	resolved := operatorapi.DiscoveredPropertyStatus{Property: "service1", Uri: "kubernetes:services.v1/my-service1", Address: "http://my-service1.default.svc:80"}
+	unresolved := operatorapi.DiscoveredPropertyStatus{Function: "knFunction", Uri: "knative:services.v1.serving.knative.dev/my-kn-service", Error: "services.serving.knative.dev \"my-kn-service\" not found"}
+	workflow.Status.ServiceDiscovery = []operatorapi.DiscoveredPropertyStatus{resolved}
+	assert.False(t, ReportServiceDiscovery(support, workflow))
+	assert.True(t, workflow.Status.GetCondition(api.ServiceDiscoveryConditionType).IsTrue())
+	assert.Equal(t, "service1=http://my-service1.default.svc:80", workflow.Status.GetCondition(api.ServiceDiscoveryConditionType).Message)
+
+	// the unresolved uris are only reported by default
+	workflow.Status.ServiceDiscovery = []operatorapi.DiscoveredPropertyStatus{resolved, unresolved}
+	assert.False(t, ReportServiceDiscovery(support, workflow))
+	cond := workflow.Status.GetCondition(api.ServiceDiscoveryConditionType)
+	assert.True(t, cond.IsFalse())
+	assert.Equal(t, api.DiscoveryFailedReason, cond.Reason)
+	assert.Contains(t, cond.Message, "function knFunction: services.serving.knative.dev \"my-kn-service\" not found")
+	assert.Contains(t, cond.Message, "service1=http://my-service1.default.svc:80")
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning DiscoveryFailed Unable to resolve function knFunction")
+
+	// the same failures raise no new Event, the blocking policy keeps the workflow from running
+	workflow.Spec.ServiceDiscovery = &operatorapi.ServiceDiscoverySpec{UnresolvedPolicy: operatorapi.BlockUnresolvedDiscoveryPolicy}
+	assert.True(t, ReportServiceDiscovery(support, workflow))
+	assert.Empty(t, recorder.Events)
+	running := workflow.Status.GetCondition(api.RunningConditionType)
+	assert.True(t, running.IsFalse())
+	assert.Equal(t, api.DiscoveryFailedReason, running.Reason)
+	assert.Contains(t, running.Message, "function knFunction")
+}
@@ -36,12 +36,12 @@ import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
 )
 
-// serviceCreator is an objectCreator for a basic Service for a workflow using dev profile
+// serviceCreator is an ObjectCreatorWithPlatform for a basic Service for a workflow using dev profile
 // aiming a vanilla Kubernetes Deployment.
 // It maps the default HTTP port (80) to the target Java application webserver on port 8080.
 // It configures the Service as a NodePort type service, in this way it will be easier for a developer access the service
-func serviceCreator(workflow *operatorapi.SonataFlow) (client.Object, error) {
-	object, _ := common.ServiceCreator(workflow)
+func serviceCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) (client.Object, error) {
+	object, _ := common.ServiceCreator(workflow, plf)
 	service := object.(*corev1.Service)
 	// Let's double-check that the workflow is using the Dev Profile we would like to expose it via NodePort
 	if profiles.IsDevProfile(workflow) {
@@ -56,6 +56,12 @@ func deploymentCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.Sonata
 		return nil, err
 	}
 	deployment := obj.(*appsv1.Deployment)
+	// autoscaling is ignored in dev profile
+	var replicas int32 = 1
+	if workflow.Spec.PodTemplate.Replicas != nil {
+		replicas = *workflow.Spec.PodTemplate.Replicas
+	}
+	deployment.Spec.Replicas = &replicas
 	_, idx := kubeutil.GetContainerByName(operatorapi.DefaultContainerName, &deployment.Spec.Template.Spec)
 	healthThreshold := cfg.GetCfg().HealthFailureThresholdDevMode
 	if workflow.Spec.PodTemplate.Container.StartupProbe == nil {
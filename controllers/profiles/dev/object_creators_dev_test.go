@@ -31,7 +31,7 @@ import (
 func Test_ensureWorkflowDevServiceIsExposed(t *testing.T) {
 	workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
 	//On Kubernetes we want the service exposed in Dev with NodePort
-	service, _ := serviceCreator(workflow)
+	service, _ := serviceCreator(workflow, nil)
 	service.SetUID("1")
 	service.SetResourceVersion("1")
 
@@ -61,10 +61,13 @@ func NewProfileReconciler(client client.Client, cfg *rest.Config, recorder recor
 		enrichers = newStatusEnrichers(support)
 	}
 
-	stateMachine := common.NewReconciliationStateMachine(
-		&ensureRunningWorkflowState{StateSupport: support, ensurers: ensurers},
-		&followWorkflowDeploymentState{StateSupport: support, enrichers: enrichers},
-		&recoverFromFailureState{StateSupport: support})
+	ensureRunning := &ensureRunningWorkflowState{StateSupport: support, ensurers: ensurers}
+	followDeployment := &followWorkflowDeploymentState{StateSupport: support, enrichers: enrichers}
+	recoverFromFailure := &recoverFromFailureState{StateSupport: support}
+	stateMachine := common.NewReconciliationStateMachine(ensureRunning, followDeployment, recoverFromFailure).
+		AddTransition(ensureRunning, followDeployment, recoverFromFailure).
+		AddTransition(followDeployment, ensureRunning, recoverFromFailure).
+		AddTransition(recoverFromFailure, ensureRunning)
 
 	profile := &developmentProfile{
 		Reconciler: common.NewReconciler(support, stateMachine),
@@ -77,7 +80,7 @@ func NewProfileReconciler(client client.Client, cfg *rest.Config, recorder recor
 func newObjectEnsurers(support *common.StateSupport) *objectEnsurers {
 	return &objectEnsurers{
 		deployment:            common.NewObjectEnsurerWithPlatform(support.C, deploymentCreator),
-		service:               common.NewObjectEnsurer(support.C, serviceCreator),
+		service:               common.NewObjectEnsurerWithPlatform(support.C, serviceCreator),
 		network:               common.NewNoopObjectEnsurer(),
 		definitionConfigMap:   common.NewObjectEnsurer(support.C, workflowDefConfigMapCreator),
 		userPropsConfigMap:    common.NewObjectEnsurer(support.C, common.UserPropsConfigMapCreator),
@@ -88,7 +91,7 @@ func newObjectEnsurers(support *common.StateSupport) *objectEnsurers {
 func newObjectEnsurersOpenShift(support *common.StateSupport) *objectEnsurers {
 	return &objectEnsurers{
 		deployment:            common.NewObjectEnsurerWithPlatform(support.C, deploymentCreator),
-		service:               common.NewObjectEnsurer(support.C, serviceCreator),
+		service:               common.NewObjectEnsurerWithPlatform(support.C, serviceCreator),
 		network:               common.NewObjectEnsurer(support.C, common.OpenShiftRouteCreator),
 		definitionConfigMap:   common.NewObjectEnsurer(support.C, workflowDefConfigMapCreator),
 		userPropsConfigMap:    common.NewObjectEnsurer(support.C, common.UserPropsConfigMapCreator),
@@ -110,7 +113,7 @@ func newStatusEnrichersOpenShift(support *common.StateSupport) *statusEnrichers
 
 type objectEnsurers struct {
 	deployment            common.ObjectEnsurerWithPlatform
-	service               common.ObjectEnsurer
+	service               common.ObjectEnsurerWithPlatform
 	network               common.ObjectEnsurer
 	definitionConfigMap   common.ObjectEnsurer
 	userPropsConfigMap    common.ObjectEnsurer
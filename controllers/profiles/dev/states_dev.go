@@ -34,6 +34,7 @@ import (
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/metrics"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
@@ -55,6 +56,10 @@ type ensureRunningWorkflowState struct {
 	ensurers *objectEnsurers
 }
 
+func (e *ensureRunningWorkflowState) Name() string {
+	return "EnsureRunningWorkflow"
+}
+
 func (e *ensureRunningWorkflowState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	return workflow.Status.IsReady() || workflow.Status.GetTopLevelCondition().IsUnknown() || workflow.Status.IsChildObjectsProblem()
 }
@@ -62,22 +67,23 @@ func (e *ensureRunningWorkflowState) CanReconcile(workflow *operatorapi.SonataFl
 func (e *ensureRunningWorkflowState) Do(ctx context.Context, workflow *operatorapi.SonataFlow) (ctrl.Result, []client.Object, error) {
 	var objs []client.Object
 
-	flowDefCM, _, err := e.ensurers.definitionConfigMap.Ensure(ctx, workflow, ensureWorkflowDefConfigMapMutator(workflow))
+	// check if the Platform available
+	pl, err := platform.GetEffectivePlatform(context.TODO(), e.C, workflow.Namespace)
 	if err != nil {
 		return ctrl.Result{Requeue: false}, objs, err
 	}
-	objs = append(objs, flowDefCM)
 
-	devBaseContainerImage := workflowdef.GetDefaultWorkflowDevModeImageTag()
-	// check if the Platform available
-	pl, err := platform.GetActivePlatform(context.TODO(), e.C, workflow.Namespace)
+	flowDefCM, _, err := e.ensurers.definitionConfigMap.Ensure(ctx, workflow, pl, ensureWorkflowDefConfigMapMutator(workflow))
 	if err != nil {
 		return ctrl.Result{Requeue: false}, objs, err
 	}
+	objs = append(objs, flowDefCM)
+
+	devBaseContainerImage := workflowdef.GetDefaultWorkflowDevModeImageTag()
 	if pl != nil && len(pl.Spec.DevMode.BaseImage) > 0 {
 		devBaseContainerImage = pl.Spec.DevMode.BaseImage
 	}
-	userPropsCM, _, err := e.ensurers.userPropsConfigMap.Ensure(ctx, workflow)
+	userPropsCM, _, err := e.ensurers.userPropsConfigMap.Ensure(ctx, workflow, pl)
 	if err != nil {
 		return ctrl.Result{Requeue: false}, objs, err
 	}
@@ -86,6 +92,10 @@ func (e *ensureRunningWorkflowState) Do(ctx context.Context, workflow *operatora
 		return ctrl.Result{Requeue: false}, objs, err
 	}
 	objs = append(objs, managedPropsCM)
+	if common.ReportServiceDiscovery(e.StateSupport, workflow) {
+		_, err = e.PerformStatusUpdate(ctx, workflow)
+		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
+	}
 
 	externalCM, err := workflowdef.FetchExternalResourcesConfigMapsRef(e.C, workflow)
 	if err != nil {
@@ -99,25 +109,44 @@ func (e *ensureRunningWorkflowState) Do(ctx context.Context, workflow *operatora
 	deployment, _, err := e.ensurers.deployment.Ensure(ctx, workflow, pl,
 		deploymentMutateVisitor(workflow, pl),
 		common.ImageDeploymentMutateVisitor(workflow, devBaseContainerImage),
-		mountDevConfigMapsMutateVisitor(workflow, flowDefCM.(*corev1.ConfigMap), userPropsCM.(*corev1.ConfigMap), managedPropsCM.(*corev1.ConfigMap), externalCM))
+		mountDevConfigMapsMutateVisitor(workflow, flowDefCM.(*corev1.ConfigMap), userPropsCM.(*corev1.ConfigMap), managedPropsCM.(*corev1.ConfigMap), externalCM),
+		common.SecretPropertiesMutateVisitor(workflow))
 	if err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
 	}
 	objs = append(objs, deployment)
 
-	service, _, err := e.ensurers.service.Ensure(ctx, workflow, common.ServiceMutateVisitor(workflow))
+	service, _, err := e.ensurers.service.Ensure(ctx, workflow, pl, common.ServiceMutateVisitor(workflow, pl))
 	if err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
 	}
 	objs = append(objs, service)
 
-	route, _, err := e.ensurers.network.Ensure(ctx, workflow)
+	route, _, err := e.ensurers.network.Ensure(ctx, workflow, pl)
 	if err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
 	}
 	objs = append(objs, route)
 
-	if knativeObjs, err := common.NewKnativeEventingHandler(e.StateSupport).Ensure(ctx, workflow); err != nil {
+	endpoint := workflow.Status.Endpoint
+	exposureObjs, err := common.NewExposureHandler(e.StateSupport).Ensure(ctx, workflow, pl)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
+	}
+	objs = append(objs, exposureObjs...)
+	if endpoint.String() != workflow.Status.Endpoint.String() {
+		if _, err = e.PerformStatusUpdate(ctx, workflow); err != nil {
+			return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
+		}
+	}
+
+	monitoringObjs, err := common.NewMonitoringHandler(e.StateSupport).Ensure(ctx, workflow, pl)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
+	}
+	objs = append(objs, monitoringObjs...)
+
+	if knativeObjs, err := common.NewKnativeEventingHandler(e.StateSupport).Ensure(ctx, workflow, pl); err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, objs, err
 	} else {
 		objs = append(objs, knativeObjs...)
@@ -158,6 +187,10 @@ type followWorkflowDeploymentState struct {
 	enrichers *statusEnrichers
 }
 
+func (f *followWorkflowDeploymentState) Name() string {
+	return "FollowWorkflowDeployment"
+}
+
 func (f *followWorkflowDeploymentState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	return workflow.Status.IsWaitingForDeployment()
 }
@@ -196,6 +229,10 @@ type recoverFromFailureState struct {
 	*common.StateSupport
 }
 
+func (r *recoverFromFailureState) Name() string {
+	return "RecoverFromFailure"
+}
+
 func (r *recoverFromFailureState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	return workflow.Status.GetCondition(api.RunningConditionType).IsFalse()
 }
@@ -249,7 +286,7 @@ func (r *recoverFromFailureState) Do(ctx context.Context, workflow *operatorapi.
 		return ctrl.Result{}, nil, err
 	}
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		updateErr := r.C.Update(ctx, deployment)
+		updateErr := r.C.Update(ctx, deployment, client.FieldOwner(common.FieldManager))
 		return updateErr
 	})
 
@@ -259,6 +296,7 @@ func (r *recoverFromFailureState) Do(ctx context.Context, workflow *operatorapi.
 	}
 
 	workflow.Status.RecoverFailureAttempts += 1
+	metrics.IncRecoveryAttempts(workflow)
 	workflow.Status.LastTimeRecoverAttempt = metav1.Now()
 	if _, err := r.PerformStatusUpdate(ctx, workflow); err != nil {
 		return ctrl.Result{Requeue: false}, nil, err
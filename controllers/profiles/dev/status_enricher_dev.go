@@ -49,7 +49,8 @@ func statusEnricher(ctx context.Context, c client.Client, workflow *operatorapi.
 
 	//If the service has got a Port that is a nodePort we have to use it to create the workflow's NodePort Endpoint
 	if service.Spec.Ports != nil && len(service.Spec.Ports) > 0 {
-		if port := findNodePortFromPorts(service.Spec.Ports); port > 0 {
+		// the Ingress or the HTTPRoute exposing the workflow provides the endpoint
+		if port := findNodePortFromPorts(service.Spec.Ports); port > 0 && workflow.Spec.Exposure == nil {
 			labels := workflowproj.GetDefaultLabels(workflow)
 
 			podList := &v1.PodList{}
@@ -114,7 +115,9 @@ func statusEnricherOpenShift(ctx context.Context, client client.Client, workflow
 	}
 	url.Path = workflow.Name
 
-	workflow.Status.Endpoint = url
+	if workflow.Spec.Exposure == nil {
+		workflow.Status.Endpoint = url
+	}
 
 	if err != nil {
 		return nil, err
@@ -39,7 +39,7 @@ func Test_enrichmentStatusOnK8s(t *testing.T) {
 
 		workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
 		workflow.Namespace = toK8SNamespace(t.Name())
-		service, _ := common.ServiceCreator(workflow)
+		service, _ := common.ServiceCreator(workflow, nil)
 		client := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, service).Build()
 		obj, err := statusEnricher(context.TODO(), client, workflow)
 
@@ -55,7 +55,7 @@ func Test_enrichmentStatusOnK8s(t *testing.T) {
 
 		workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
 		workflow.Namespace = t.Name()
-		service, _ := serviceCreator(workflow)
+		service, _ := serviceCreator(workflow, nil)
 		client := test.NewSonataFlowClientBuilder().WithRuntimeObjects(workflow, service).Build()
 		_, err := statusEnricher(context.TODO(), client, workflow)
 		assert.Error(t, err)
@@ -67,7 +67,7 @@ func Test_enrichmentStatusOnOCP(t *testing.T) {
 	t.Run("verify that the service URL is returned with the default cluster name on default namespace", func(t *testing.T) {
 		workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
 		workflow.Namespace = toK8SNamespace(t.Name())
-		service, _ := serviceCreator(workflow)
+		service, _ := serviceCreator(workflow, nil)
 		route := &openshiftv1.Route{}
 		route.Name = workflow.Name
 		route.Namespace = workflow.Namespace
@@ -39,11 +39,11 @@ func NewProfileForOpsReconciler(client client.Client, cfg *rest.Config, recorder
 		Catalog:  discovery.NewServiceCatalogForConfig(client, cfg),
 		Recorder: recorder,
 	}
+	buildSkipped := &ensureBuildSkipped{StateSupport: support}
+	followDeploy := &followDeployWorkflowState{StateSupport: support, ensurers: newObjectEnsurers(support)}
 	// the reconciliation state machine
-	stateMachine := common.NewReconciliationStateMachine(
-		&ensureBuildSkipped{StateSupport: support},
-		&followDeployWorkflowState{StateSupport: support, ensurers: newObjectEnsurers(support)},
-	)
+	stateMachine := common.NewReconciliationStateMachine(buildSkipped, followDeploy).
+		AddTransition(buildSkipped, followDeploy)
 	reconciler := &gitOpsProfile{
 		Reconciler: common.NewReconciler(support, stateMachine),
 	}
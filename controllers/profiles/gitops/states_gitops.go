@@ -30,6 +30,10 @@ type ensureBuildSkipped struct {
 	*common.StateSupport
 }
 
+func (f *ensureBuildSkipped) Name() string {
+	return "EnsureBuildSkipped"
+}
+
 func (f *ensureBuildSkipped) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	return workflow.Status.GetCondition(api.BuiltConditionType).IsUnknown() ||
 		workflow.Status.GetCondition(api.BuiltConditionType).IsTrue() ||
@@ -56,6 +60,10 @@ type followDeployWorkflowState struct {
 	ensurers *objectEnsurers
 }
 
+func (f *followDeployWorkflowState) Name() string {
+	return "FollowDeployWorkflow"
+}
+
 func (f *followDeployWorkflowState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	// we always reconcile since in this flow we don't mind building anything, just reconcile the deployment state
 	return workflow.Status.GetCondition(api.BuiltConditionType).Reason == api.BuildSkippedReason
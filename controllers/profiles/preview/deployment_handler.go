@@ -18,7 +18,10 @@ import (
 	"context"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/knative"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -28,6 +31,7 @@ import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/properties"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
 )
 
@@ -53,6 +57,22 @@ func (d *DeploymentReconciler) reconcileWithImage(ctx context.Context, workflow
 		return reconcile.Result{Requeue: false}, nil, err
 	}
 
+	// the previous version must be copied before the workflow objects are updated with the new one
+	versioning := newVersioningHandler(d.StateSupport)
+	if retired, err := versioning.retirePreviousVersion(ctx, workflow); err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to keep the previous version running due to ", err)
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{}, nil, err
+	} else if retired {
+		if _, err := d.PerformStatusUpdate(ctx, workflow); err != nil {
+			return reconcile.Result{}, nil, err
+		}
+	}
+
+	// the idle policy decides the replicas of the Deployment
+	idle := newIdleHandler(d.StateSupport)
+	idle.evaluate(ctx, workflow)
+
 	// Ensure objects
 	result, objs, err := d.ensureObjects(ctx, workflow, image)
 	if err != nil || result.Requeue {
@@ -64,6 +84,26 @@ func (d *DeploymentReconciler) reconcileWithImage(ctx context.Context, workflow
 	if err != nil {
 		return reconcile.Result{Requeue: false}, nil, err
 	}
+	if workflow.Status.IsHibernated() {
+		idle.markHibernated(workflow)
+		result = reconcile.Result{RequeueAfter: constants.RequeueAfterIsRunning}
+	}
+	if wait := rolloutRequeueAfter(workflow); wait > 0 && (result.RequeueAfter == 0 || wait < result.RequeueAfter) {
+		result.RequeueAfter = wait
+	}
+
+	latestImage := deployedImage(workflow, image)
+	if len(latestImage) == 0 {
+		latestImage = workflow.Spec.PodTemplate.Container.Image
+	}
+	if err = versioning.setLatestVersion(ctx, workflow, latestImage); err != nil {
+		return reconcile.Result{Requeue: false}, nil, err
+	}
+	if draining, err := versioning.drain(ctx, workflow); err != nil {
+		return reconcile.Result{Requeue: false}, nil, err
+	} else if draining && result.RequeueAfter == 0 {
+		result.RequeueAfter = constants.RequeueAfterIsRunning
+	}
 
 	if _, err := d.PerformStatusUpdate(ctx, workflow); err != nil {
 		return reconcile.Result{Requeue: false}, nil, err
@@ -90,8 +130,8 @@ func (d *DeploymentReconciler) ensureKnativeServingRequired(workflow *operatorap
 }
 
 func (d *DeploymentReconciler) ensureObjects(ctx context.Context, workflow *operatorapi.SonataFlow, image string) (reconcile.Result, []client.Object, error) {
-	pl, _ := platform.GetActivePlatform(ctx, d.C, workflow.Namespace)
-	userPropsCM, _, err := d.ensurers.userPropsConfigMap.Ensure(ctx, workflow)
+	pl, _ := platform.GetEffectivePlatform(ctx, d.C, workflow.Namespace)
+	userPropsCM, _, err := d.ensurers.userPropsConfigMap.Ensure(ctx, workflow, pl)
 	if err != nil {
 		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.ExternalResourcesNotFoundReason, "Unable to retrieve the user properties config map")
 		_, _ = d.PerformStatusUpdate(ctx, workflow)
@@ -104,29 +144,79 @@ func (d *DeploymentReconciler) ensureObjects(ctx context.Context, workflow *oper
 		_, _ = d.PerformStatusUpdate(ctx, workflow)
 		return reconcile.Result{}, nil, err
 	}
+	referencesVersions, err := properties.GetPropertiesReferencesVersions(ctx, d.C, workflow)
+	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.ExternalResourcesNotFoundReason, "Unable to retrieve the objects referenced by the properties")
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{}, nil, err
+	}
+	if common.ReportServiceDiscovery(d.StateSupport, workflow) {
+		_, err = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{RequeueAfter: constants.RequeueAfterFailure}, []client.Object{managedPropsCM}, err
+	}
+
+	// the rollout must move forward before the deployment and the service are ensured, so they reflect its current phase
+	rolloutObjs, err := newRolloutHandler(d.StateSupport, d.ensurers).reconcile(ctx, workflow, pl, userPropsCM.(*v1.ConfigMap), managedPropsCM.(*v1.ConfigMap), referencesVersions)
+	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to perform the rollout due to ", err)
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{}, nil, err
+	}
 
 	deployment, deploymentOp, err :=
 		d.ensurers.DeploymentByDeploymentModel(workflow).Ensure(ctx, workflow, pl,
-			d.deploymentModelMutateVisitors(workflow, pl, image, userPropsCM.(*v1.ConfigMap), managedPropsCM.(*v1.ConfigMap))...)
+			d.deploymentModelMutateVisitors(workflow, pl, deployedImage(workflow, image), userPropsCM.(*v1.ConfigMap), managedPropsCM.(*v1.ConfigMap), referencesVersions)...)
 	if err != nil {
 		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to perform the deploy due to ", err)
 		_, _ = d.PerformStatusUpdate(ctx, workflow)
 		return reconcile.Result{}, nil, err
 	}
 
-	service, _, err := d.ensurers.ServiceByDeploymentModel(workflow).Ensure(ctx, workflow, common.ServiceMutateVisitor(workflow))
+	autoscalerObjs, err := d.ensureHorizontalPodAutoscaler(ctx, workflow, pl)
+	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to configure the autoscaling due to ", err)
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{}, nil, err
+	}
+
+	service, _, err := d.ensurers.ServiceByDeploymentModel(workflow).Ensure(ctx, workflow, pl, common.ServiceMutateVisitor(workflow, pl), rolloutServiceMutateVisitor(workflow))
 	if err != nil {
 		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to make the service available due to ", err)
 		_, _ = d.PerformStatusUpdate(ctx, workflow)
 		return reconcile.Result{}, nil, err
 	}
 
-	eventingObjs, err := common.NewKnativeEventingHandler(d.StateSupport).Ensure(ctx, workflow)
+	exposureObjs, err := common.NewExposureHandler(d.StateSupport).Ensure(ctx, workflow, pl)
+	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to expose the workflow due to ", err)
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{}, nil, err
+	}
+
+	monitoringObjs, err := common.NewMonitoringHandler(d.StateSupport).Ensure(ctx, workflow, pl)
+	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to configure the workflow monitoring due to ", err)
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
+		return reconcile.Result{}, nil, err
+	}
+
+	networkPolicyObjs, err := common.NewNetworkPolicyHandler(d.StateSupport).Ensure(ctx, workflow, pl)
 	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "Unable to isolate the workflow network due to ", err)
+		_, _ = d.PerformStatusUpdate(ctx, workflow)
 		return reconcile.Result{}, nil, err
 	}
 
-	objs := []client.Object{deployment, managedPropsCM, service}
+	eventingObjs, err := common.NewKnativeEventingHandler(d.StateSupport).Ensure(ctx, workflow, pl)
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+
+	objs := append([]client.Object{deployment, managedPropsCM, service}, rolloutObjs...)
+	objs = append(objs, autoscalerObjs...)
+	objs = append(objs, exposureObjs...)
+	objs = append(objs, monitoringObjs...)
+	objs = append(objs, networkPolicyObjs...)
 	if deploymentOp == controllerutil.OperationResultCreated {
 		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForDeploymentReason, "")
 		if _, err := d.PerformStatusUpdate(ctx, workflow); err != nil {
@@ -139,29 +229,61 @@ func (d *DeploymentReconciler) ensureObjects(ctx context.Context, workflow *oper
 	return reconcile.Result{}, objs, nil
 }
 
+// ensureHorizontalPodAutoscaler ensures the HorizontalPodAutoscaler of the workflow Deployment when autoscaling is configured,
+// and removes it otherwise. Knative Serving scales the workflow by itself.
+func (d *DeploymentReconciler) ensureHorizontalPodAutoscaler(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform) ([]client.Object, error) {
+	if workflow.IsKnativeDeployment() || workflow.Spec.PodTemplate.Autoscaling == nil {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := d.C.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, hpa); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(hpa, workflow) {
+			return nil, nil
+		}
+		return nil, client.IgnoreNotFound(d.C.Delete(ctx, hpa))
+	}
+	hpa, _, err := d.ensurers.horizontalPodAutoscaler.Ensure(ctx, workflow, pl, common.HorizontalPodAutoscalerMutateVisitor(workflow))
+	if err != nil {
+		return nil, err
+	}
+	return []client.Object{hpa}, nil
+}
+
 func (d *DeploymentReconciler) deploymentModelMutateVisitors(
 	workflow *operatorapi.SonataFlow,
 	plf *operatorapi.SonataFlowPlatform,
 	image string,
 	userPropsCM *v1.ConfigMap,
-	managedPropsCM *v1.ConfigMap) []common.MutateVisitor {
+	managedPropsCM *v1.ConfigMap,
+	referencesVersions map[string]string) []common.MutateVisitor {
 
 	if workflow.IsKnativeDeployment() {
 		return []common.MutateVisitor{common.KServiceMutateVisitor(workflow, plf),
 			common.ImageKServiceMutateVisitor(workflow, image),
-			mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM)}
+			mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM, referencesVersions),
+			common.SecretPropertiesMutateVisitor(workflow),
+			rolloutKServiceMutateVisitor(workflow),
+			versionMutateVisitor(workflow)}
 	}
 
 	if utils.IsOpenShift() {
 		return []common.MutateVisitor{common.DeploymentMutateVisitor(workflow, plf),
-			mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM),
+			mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM, referencesVersions),
+			common.SecretPropertiesMutateVisitor(workflow),
 			addOpenShiftImageTriggerDeploymentMutateVisitor(workflow, image),
 			common.ImageDeploymentMutateVisitor(workflow, image),
-			common.RolloutDeploymentIfCMChangedMutateVisitor(workflow, userPropsCM, managedPropsCM),
+			rolloutDeploymentMutateVisitor(workflow),
+			common.RolloutDeploymentIfCMChangedMutateVisitor(workflow, userPropsCM, managedPropsCM, referencesVersions),
+			versionMutateVisitor(workflow),
+			idleMutateVisitor(workflow),
 		}
 	}
 	return []common.MutateVisitor{common.DeploymentMutateVisitor(workflow, plf),
 		common.ImageDeploymentMutateVisitor(workflow, image),
-		mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM),
-		common.RolloutDeploymentIfCMChangedMutateVisitor(workflow, userPropsCM, managedPropsCM)}
+		mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM, referencesVersions),
+		common.SecretPropertiesMutateVisitor(workflow),
+		rolloutDeploymentMutateVisitor(workflow),
+		common.RolloutDeploymentIfCMChangedMutateVisitor(workflow, userPropsCM, managedPropsCM, referencesVersions),
+		versionMutateVisitor(workflow),
+		idleMutateVisitor(workflow)}
 }
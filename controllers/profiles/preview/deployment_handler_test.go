@@ -26,9 +26,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 type fakeDeploymentReconciler struct {
@@ -216,3 +220,112 @@ func Test_CheckDeploymentUnchangedAfterCMChangeOtherKeys(t *testing.T) {
 		}
 	}
 }
+
+func Test_CheckDeploymentRolloutAfterSecretPropertyChange(t *testing.T) {
+	workflow := test.GetBaseSonataFlowWithPreviewProfile(t.Name())
+	workflow.Spec.Properties = &v1alpha08.PropertyFlowSpec{
+		Flow: []v1alpha08.PropertyVar{{Name: "my.api.key", ValueFrom: &v1alpha08.PropertyVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "key"}}}},
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: workflow.Namespace}, Data: map[string][]byte{"key": []byte("first")}}
+
+	client := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflow, secret).
+		WithStatusSubresource(workflow).
+		Build()
+	stateSupport := fakeReconcilerSupport(client)
+	handler := NewDeploymentReconciler(stateSupport, NewObjectEnsurers(stateSupport))
+
+	_, objects, err := handler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	var checksum string
+	for _, o := range objects {
+		if deployment, ok := o.(*v1.Deployment); ok {
+			checksum = deployment.Spec.Template.ObjectMeta.Annotations[metadata.Checksum]
+			assert.Equal(t, "MY_API_KEY", deployment.Spec.Template.Spec.Containers[0].Env[0].Name)
+		}
+		if cm, ok := o.(*corev1.ConfigMap); ok && cm.Name == workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow) {
+			assert.NotContains(t, cm.Data[workflowproj.GetManagedPropertiesFileName(workflow)], "my.api.key")
+		}
+	}
+	assert.NotEmpty(t, checksum)
+
+	// the managed ConfigMap is unchanged, the rollout comes from the Secret reference
+	workflow.Spec.Properties.Flow[0].ValueFrom.SecretKeyRef.Key = "rotated-key"
+	_, objects, err = handler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	for _, o := range objects {
+		if deployment, ok := o.(*v1.Deployment); ok {
+			assert.Contains(t, deployment.Spec.Template.ObjectMeta.Annotations, metadata.RestartedAt)
+			assert.NotEqual(t, checksum, deployment.Spec.Template.ObjectMeta.Annotations[metadata.Checksum])
+			assert.Equal(t, "rotated-key", deployment.Spec.Template.Spec.Containers[0].Env[0].ValueFrom.SecretKeyRef.Key)
+			checksum = deployment.Spec.Template.ObjectMeta.Annotations[metadata.Checksum]
+			break
+		}
+	}
+
+	// the workflow is unchanged, the rollout comes from the new data of the Secret
+	secret.Data["rotated-key"] = []byte("second")
+	assert.NoError(t, client.Update(context.TODO(), secret))
+	_, objects, err = handler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	for _, o := range objects {
+		if deployment, ok := o.(*v1.Deployment); ok {
+			assert.NotEqual(t, checksum, deployment.Spec.Template.ObjectMeta.Annotations[metadata.Checksum])
+			break
+		}
+	}
+}
+
+func Test_WorkflowExposure(t *testing.T) {
+	workflow := test.GetBaseSonataFlowWithPreviewProfile(t.Name())
+	workflow.Spec.Exposure = &v1alpha08.ExposureSpec{Host: "greeting.example.com", TLSSecretName: "greeting-tls"}
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: workflow.Namespace},
+		Spec: gatewayv1beta1.GatewaySpec{
+			Listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1beta1.HTTPProtocolType},
+				{Name: "https", Port: 8443, Protocol: gatewayv1beta1.HTTPSProtocolType},
+			},
+		},
+		Status: gatewayv1beta1.GatewayStatus{Addresses: []gatewayv1beta1.GatewayAddress{{Value: "10.0.0.1"}}},
+	}
+
+	client := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflow, gateway).
+		WithStatusSubresource(workflow).
+		Build()
+	stateSupport := fakeReconcilerSupport(client)
+	handler := NewDeploymentReconciler(stateSupport, NewObjectEnsurers(stateSupport))
+
+	_, _, err := handler.ensureObjects(context.TODO(), workflow, "")
+	assert.NoError(t, err)
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, ingress))
+	assert.Equal(t, "greeting.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, workflow.Name, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "greeting-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, "https://greeting.example.com/", workflow.Status.Endpoint.String())
+
+	// moving to the Gateway API replaces the Ingress with an HTTPRoute
+	workflow.Spec.Exposure = &v1alpha08.ExposureSpec{
+		Type:    v1alpha08.HTTPRouteExposureType,
+		Path:    "/greeting",
+		Gateway: &v1alpha08.GatewayReference{Name: gateway.Name, SectionName: "https"},
+	}
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "")
+	assert.NoError(t, err)
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, ingress)))
+	route := &gatewayv1beta1.HTTPRoute{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, route))
+	assert.Equal(t, gatewayv1beta1.ObjectName(gateway.Name), route.Spec.ParentRefs[0].Name)
+	assert.Equal(t, "/greeting", *route.Spec.Rules[0].Matches[0].Path.Value)
+	assert.Equal(t, "https://10.0.0.1:8443/greeting", workflow.Status.Endpoint.String())
+
+	workflow.Spec.Exposure = nil
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "")
+	assert.NoError(t, err)
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, route)))
+	assert.Nil(t, workflow.Status.Endpoint)
+}
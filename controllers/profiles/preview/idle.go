@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package preview
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+const workflowMetricsRequestTimeout = 10 * time.Second
+
+// activityMetrics the Prometheus samples, exposed by the workflow, counting the HTTP requests and the incoming events
+var activityMetrics = map[string]bool{
+	"http_server_requests_seconds_count": true,
+	"mp_messaging_message_count_total":   true,
+}
+
+// readWorkflowActivity returns the total of the activity metrics of each workflow pod, replaced by the tests
+var readWorkflowActivity = readWorkflowActivityMetrics
+
+// scrapeActivity returns the total of the activity metrics exposed at the given metrics URL, replaced by the tests
+var scrapeActivity = scrapeActivityMetrics
+
+// idleClock returns the current time, replaced by the tests
+var idleClock = time.Now
+
+// idleHandler hibernates the workflows using the kubernetes deployment model, as described by operatorapi.IdlePolicySpec.
+type idleHandler struct {
+	*common.StateSupport
+}
+
+func newIdleHandler(support *common.StateSupport) *idleHandler {
+	return &idleHandler{StateSupport: support}
+}
+
+// isIdlePolicyApplied whether the idle policy of the workflow is applied, the knative deployment model has its own scale to zero.
+func isIdlePolicyApplied(workflow *operatorapi.SonataFlow) bool {
+	return workflow.Spec.PodTemplate.IdlePolicy != nil && !workflow.IsKnativeDeployment()
+}
+
+// evaluate updates the idle status of the workflow, deciding whether it must be hibernated or woken up.
+// Must be called before ensuring the workflow Deployment, scaled by idleMutateVisitor.
+func (h *idleHandler) evaluate(ctx context.Context, workflow *operatorapi.SonataFlow) {
+	if !isIdlePolicyApplied(workflow) {
+		workflow.Status.Idle = nil
+		return
+	}
+	policy := workflow.Spec.PodTemplate.IdlePolicy
+	now := idleClock()
+	if workflow.Status.Idle == nil {
+		workflow.Status.Idle = &operatorapi.IdleStatus{LastActivityTime: metav1.NewTime(now)}
+	}
+	status := workflow.Status.Idle
+	status.NextScheduleTime = h.nextScheduleTime(ctx, workflow)
+
+	if request := workflow.Annotations[metadata.WakeUp]; len(request) > 0 && request != status.WakeUpRequest {
+		status.WakeUpRequest = request
+		h.wakeUp(workflow, now, fmt.Sprintf("Woken up by the %s annotation", metadata.WakeUp))
+		return
+	}
+	if status.Hibernated {
+		if status.NextScheduleTime != nil && status.NextScheduleTime.Time.Before(now.Add(policy.GetWakeUpLeadTime())) {
+			h.wakeUp(workflow, now, fmt.Sprintf("Woken up for the schedule due at %s", status.NextScheduleTime.UTC().Format(time.RFC3339)))
+		}
+		return
+	}
+
+	activity, err := readWorkflowActivity(ctx, h.C, workflow)
+	if err != nil {
+		// without metrics, the workflow is considered active to never hibernate it while in use
+		klog.V(log.D).InfoS("Failed to read the workflow activity", "workflow", workflow.Name, "error", err)
+		status.LastActivityTime = metav1.NewTime(now)
+		status.Message = fmt.Sprintf("Unable to read the activity from the workflow metrics, kept running: %s", err.Error())
+		return
+	}
+	if hasNewActivity(status.PodActivity, activity) {
+		status.LastActivityTime = metav1.NewTime(now)
+	}
+	status.PodActivity = activity
+	status.ObservedActivity = 0
+	for _, count := range activity {
+		status.ObservedActivity += count
+	}
+	quietPeriod := policy.GetQuietPeriod()
+	if status.NextScheduleTime != nil && status.NextScheduleTime.Time.Before(now.Add(max(quietPeriod, policy.GetWakeUpLeadTime()))) {
+		status.Message = fmt.Sprintf("Kept running for the schedule due at %s", status.NextScheduleTime.UTC().Format(time.RFC3339))
+		return
+	}
+	if now.Sub(status.LastActivityTime.Time) < quietPeriod {
+		status.Message = ""
+		return
+	}
+	status.Hibernated = true
+	status.Message = fmt.Sprintf("No activity since %s", status.LastActivityTime.UTC().Format(time.RFC3339))
+	klog.V(log.I).InfoS("Workflow hibernated", "workflow", workflow.Name, "lastActivity", status.LastActivityTime)
+	h.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.HibernatedReason,
+		"Workflow %s has been idle for %s and is scaled to zero.", workflow.Name, quietPeriod)
+}
+
+// markHibernated reports the hibernated workflow in the Running condition, once its Deployment is scaled to zero.
+func (h *idleHandler) markHibernated(workflow *operatorapi.SonataFlow) {
+	workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.HibernatedReason,
+		"Workflow hibernated by its idle policy. %s", workflow.Status.Idle.Message)
+}
+
+func (h *idleHandler) wakeUp(workflow *operatorapi.SonataFlow, now time.Time, message string) {
+	status := workflow.Status.Idle
+	status.LastActivityTime = metav1.NewTime(now)
+	status.Message = message
+	if !status.Hibernated {
+		return
+	}
+	status.Hibernated = false
+	klog.V(log.I).InfoS("Workflow woken up", "workflow", workflow.Name, "reason", message)
+	h.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.WokenUpReason, "Workflow %s is scaled back up. %s", workflow.Name, message)
+}
+
+// nextScheduleTime returns the time the next Jobs Service schedule of the workflow is due, if the Data Index knows it.
+func (h *idleHandler) nextScheduleTime(ctx context.Context, workflow *operatorapi.SonataFlow) *metav1.Time {
+	if workflow.Status.Services == nil || workflow.Status.Services.DataIndexRef == nil || len(workflow.Status.Services.DataIndexRef.Url) == 0 {
+		return nil
+	}
+	next, err := newDataIndexClient(workflow.Status.Services.DataIndexRef.Url).NextScheduledJobTime(ctx, workflow.Name)
+	if err != nil {
+		klog.V(log.E).ErrorS(err, "Failed to get the next schedule of the workflow", "workflow", workflow.Name)
+		// the last known schedule still holds
+		return workflow.Status.Idle.NextScheduleTime
+	}
+	if next == nil {
+		return nil
+	}
+	nextTime := metav1.NewTime(*next)
+	return &nextTime
+}
+
+// hasNewActivity whether the activity read from the workflow pods differs from the last observed one.
+// A counter lower than the observed one has been reset by a restart of the pod, only its new samples are activity.
+func hasNewActivity(observed, activity map[string]int64) bool {
+	for pod, count := range activity {
+		if previous := observed[pod]; count > previous || (count < previous && count > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// readWorkflowActivityMetrics scrapes the metrics endpoint of each running workflow pod and sums their activity metrics.
+func readWorkflowActivityMetrics(ctx context.Context, c client.Client, workflow *operatorapi.SonataFlow) (map[string]int64, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(workflow.Namespace), client.MatchingLabels(workflowproj.GetDefaultLabels(workflow))); err != nil {
+		return nil, err
+	}
+	activity := make(map[string]int64)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil || len(pod.Status.PodIP) == 0 {
+			continue
+		}
+		url := fmt.Sprintf("%s://%s:%d%s", constants.KogitoServiceURLProtocol, pod.Status.PodIP, constants.DefaultHTTPWorkflowPortInt, constants.QuarkusMetricsPath)
+		count, err := scrapeActivity(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the metrics of the pod %s: %w", pod.Name, err)
+		}
+		activity[pod.Name] = count
+	}
+	if len(activity) == 0 {
+		return nil, fmt.Errorf("no running pod of the workflow %s", workflow.Name)
+	}
+	return activity, nil
+}
+
+// scrapeActivityMetrics scrapes the given workflow metrics endpoint and sums the activity metrics.
+func scrapeActivityMetrics(ctx context.Context, url string) (int64, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	response, err := (&http.Client{Timeout: workflowMetricsRequestTimeout}).Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("the workflow metrics endpoint %s answered %s", url, response.Status)
+	}
+	return sumActivityMetrics(bufio.NewScanner(response.Body))
+}
+
+// sumActivityMetrics sums the samples of the activity metrics in the Prometheus text format.
+func sumActivityMetrics(scanner *bufio.Scanner) (int64, error) {
+	total := 0.0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, sample := line, ""
+		if i := strings.IndexAny(line, "{ "); i >= 0 {
+			name, sample = line[:i], line[i:]
+		}
+		if !activityMetrics[name] {
+			continue
+		}
+		if i := strings.LastIndex(sample, "}"); i >= 0 {
+			sample = sample[i+1:]
+		}
+		fields := strings.Fields(sample)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid sample %q: %w", line, err)
+		}
+		total += value
+	}
+	return int64(total), scanner.Err()
+}
+
+// idleMutateVisitor scales the workflow Deployment to zero while the workflow is hibernated. When the workflow wakes
+// up, the replicas owned by the HorizontalPodAutoscaler are restored to the lower limit of the autoscaling: the
+// HorizontalPodAutoscaler doesn't scale a Deployment with zero replicas.
+func idleMutateVisitor(workflow *operatorapi.SonataFlow) common.MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if !isIdlePolicyApplied(workflow) {
+				return nil
+			}
+			deployment, ok := object.(*appsv1.Deployment)
+			if !ok || deployment.Name != workflow.Name {
+				return nil
+			}
+			if workflow.Status.IsHibernated() {
+				var replicas int32 = 0
+				deployment.Spec.Replicas = &replicas
+			} else if autoscaling := workflow.Spec.PodTemplate.Autoscaling; autoscaling != nil &&
+				deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+				replicas := max(autoscaling.GetMinReplicas(), 1)
+				deployment.Spec.Replicas = &replicas
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,285 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package preview
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+const workflowMetrics = `# HELP http_server_requests_seconds
+# TYPE http_server_requests_seconds summary
+http_server_requests_seconds_count{method="POST",outcome="SUCCESS",status="201",uri="/greeting"} 4.0
+http_server_requests_seconds_sum{method="POST",outcome="SUCCESS",status="201",uri="/greeting"} 0.25
+http_server_requests_seconds_count{method="GET",outcome="SUCCESS",status="200",uri="/greeting"} 2.0
+# TYPE mp_messaging_message_count_total counter
+mp_messaging_message_count_total{channel="kogito_incoming_stream"} 3.0
+jvm_threads_live_threads 24.0
+`
+
+type fakeIdleClock struct {
+	now time.Time
+}
+
+func (c *fakeIdleClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func useFakeIdleClock(t *testing.T) *fakeIdleClock {
+	clock := &fakeIdleClock{now: time.Date(2024, 5, 10, 10, 0, 0, 0, time.UTC)}
+	idleClock = func() time.Time { return clock.now }
+	t.Cleanup(func() { idleClock = time.Now })
+	return clock
+}
+
+func useFakeWorkflowActivity(t *testing.T, activity *int64, err *error) {
+	readWorkflowActivity = func(context.Context, client.Client, *operatorapi.SonataFlow) (map[string]int64, error) {
+		return map[string]int64{"greeting-pod": *activity}, *err
+	}
+	t.Cleanup(func() { readWorkflowActivity = readWorkflowActivityMetrics })
+}
+
+func idleWorkflow(t *testing.T) *operatorapi.SonataFlow {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.PodTemplate.IdlePolicy = &operatorapi.IdlePolicySpec{
+		QuietPeriod:    &metav1.Duration{Duration: 10 * time.Minute},
+		WakeUpLeadTime: &metav1.Duration{Duration: time.Minute},
+	}
+	workflow.Status.Services = &operatorapi.PlatformServicesStatus{
+		DataIndexRef: &operatorapi.PlatformServiceRefStatus{Url: "http://sonataflow-platform-data-index-service." + t.Name()},
+	}
+	return workflow
+}
+
+func Test_sumActivityMetrics(t *testing.T) {
+	total, err := sumActivityMetrics(bufio.NewScanner(strings.NewReader(workflowMetrics)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), total)
+
+	_, err = sumActivityMetrics(bufio.NewScanner(strings.NewReader("http_server_requests_seconds_count{uri=\"/\"} NaN-ish")))
+	assert.Error(t, err)
+}
+
+func Test_idleHandler_HibernateAndWakeUp(t *testing.T) {
+	clock := useFakeIdleClock(t)
+	var activity int64 = 5
+	var activityErr error
+	useFakeWorkflowActivity(t, &activity, &activityErr)
+	dataIndex := &fakeDataIndexClient{}
+	useFakeDataIndexClient(t, dataIndex)
+	workflow := idleWorkflow(t)
+	handler := newIdleHandler(fakeReconcilerSupport(test.NewSonataFlowClientBuilder().Build()))
+
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	assert.Equal(t, int64(5), workflow.Status.Idle.ObservedActivity)
+
+	// new requests during the quiet period
+	clock.advance(8 * time.Minute)
+	activity = 7
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	assert.Equal(t, clock.now, workflow.Status.Idle.LastActivityTime.Time)
+
+	clock.advance(8 * time.Minute)
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	clock.advance(2 * time.Minute)
+	handler.evaluate(context.TODO(), workflow)
+	assert.True(t, workflow.Status.IsHibernated())
+
+	// the Deployment is scaled to zero
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: workflow.Name, Namespace: workflow.Namespace}}
+	deployment.Spec.Replicas = workflow.Spec.PodTemplate.Replicas
+	assert.NoError(t, idleMutateVisitor(workflow)(deployment)())
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	handler.markHibernated(workflow)
+	assert.Equal(t, api.HibernatedReason, workflow.Status.GetCondition(api.RunningConditionType).Reason)
+
+	// the wake-up annotation scales it back up
+	clock.advance(time.Hour)
+	workflow.Annotations[metadata.WakeUp] = "2024-05-10T11:18:00Z"
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	assert.Equal(t, "2024-05-10T11:18:00Z", workflow.Status.Idle.WakeUpRequest)
+	deployment.Spec.Replicas = nil
+	assert.NoError(t, idleMutateVisitor(workflow)(deployment)())
+	assert.Nil(t, deployment.Spec.Replicas)
+
+	// the same annotation doesn't wake it up twice
+	clock.advance(10 * time.Minute)
+	handler.evaluate(context.TODO(), workflow)
+	assert.True(t, workflow.Status.IsHibernated())
+	handler.evaluate(context.TODO(), workflow)
+	assert.True(t, workflow.Status.IsHibernated())
+}
+
+func Test_idleMutateVisitor_HibernateAndWakeUpWithAutoscaling(t *testing.T) {
+	workflow := idleWorkflow(t)
+	workflow.Spec.PodTemplate.Autoscaling = &operatorapi.AutoscalingSpec{MinReplicas: utils.Pint(2), MaxReplicas: 5}
+	plf := test.GetBasePlatformInReadyPhase(workflow.Namespace)
+	object, err := common.DeploymentCreator(workflow, plf)
+	assert.NoError(t, err)
+	deployment := object.(*appsv1.Deployment)
+	deployment.ResourceVersion = "1"
+	deployment.Spec.Replicas = utils.Pint(3)
+	mutate := func() {
+		for _, visitor := range []common.MutateVisitor{common.DeploymentMutateVisitor(workflow, plf), idleMutateVisitor(workflow)} {
+			assert.NoError(t, visitor(deployment)())
+		}
+	}
+
+	// the replicas are owned by the HorizontalPodAutoscaler
+	mutate()
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+
+	workflow.Status.Idle = &operatorapi.IdleStatus{Hibernated: true}
+	mutate()
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	mutate()
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	// the wake-up gives back the replicas to the HorizontalPodAutoscaler
+	workflow.Status.Idle.Hibernated = false
+	mutate()
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+}
+
+func workflowPod(workflow *operatorapi.SonataFlow, name, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: workflow.Namespace, Labels: workflowproj.GetMergedLabels(workflow)},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: ip},
+	}
+}
+
+func Test_idleHandler_ActivityOfEachPod(t *testing.T) {
+	clock := useFakeIdleClock(t)
+	workflow := idleWorkflow(t)
+	workflow.Status.Services = nil
+	podActivity := map[string]int64{"10.0.0.1": 5, "10.0.0.2": 3}
+	scrapeActivity = func(_ context.Context, url string) (int64, error) {
+		for ip, count := range podActivity {
+			if strings.Contains(url, "//"+ip+":") {
+				return count, nil
+			}
+		}
+		return 0, fmt.Errorf("unexpected metrics endpoint %s", url)
+	}
+	t.Cleanup(func() { scrapeActivity = scrapeActivityMetrics })
+	cli := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflowPod(workflow, "greeting-1", "10.0.0.1"), workflowPod(workflow, "greeting-2", "10.0.0.2")).Build()
+	handler := newIdleHandler(fakeReconcilerSupport(cli))
+
+	handler.evaluate(context.TODO(), workflow)
+	assert.Equal(t, int64(8), workflow.Status.Idle.ObservedActivity)
+	assert.Equal(t, map[string]int64{"greeting-1": 5, "greeting-2": 3}, workflow.Status.Idle.PodActivity)
+	start := clock.now
+
+	// the second pod restarted, its counter reset isn't an activity
+	clock.advance(5 * time.Minute)
+	podActivity["10.0.0.2"] = 0
+	handler.evaluate(context.TODO(), workflow)
+	assert.Equal(t, start, workflow.Status.Idle.LastActivityTime.Time)
+	assert.Equal(t, int64(5), workflow.Status.Idle.ObservedActivity)
+
+	// new requests served by the second pod only
+	clock.advance(5 * time.Minute)
+	podActivity["10.0.0.2"] = 2
+	handler.evaluate(context.TODO(), workflow)
+	assert.Equal(t, clock.now, workflow.Status.Idle.LastActivityTime.Time)
+	assert.False(t, workflow.Status.IsHibernated())
+
+	clock.advance(11 * time.Minute)
+	handler.evaluate(context.TODO(), workflow)
+	assert.True(t, workflow.Status.IsHibernated())
+}
+
+func Test_idleHandler_Schedules(t *testing.T) {
+	clock := useFakeIdleClock(t)
+	var activity int64 = 1
+	var activityErr error
+	useFakeWorkflowActivity(t, &activity, &activityErr)
+	dataIndex := &fakeDataIndexClient{}
+	useFakeDataIndexClient(t, dataIndex)
+	workflow := idleWorkflow(t)
+	handler := newIdleHandler(fakeReconcilerSupport(test.NewSonataFlowClientBuilder().Build()))
+
+	handler.evaluate(context.TODO(), workflow)
+	// a schedule due within the quiet period keeps the workflow running
+	next := clock.now.Add(15 * time.Minute)
+	dataIndex.nextJob = &next
+	clock.advance(10 * time.Minute)
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	assert.Equal(t, next, workflow.Status.Idle.NextScheduleTime.Time)
+
+	// a schedule far ahead doesn't
+	next = clock.now.Add(3 * time.Hour)
+	handler.evaluate(context.TODO(), workflow)
+	assert.True(t, workflow.Status.IsHibernated())
+
+	// the workflow is woken up ahead of the schedule
+	clock.advance(3*time.Hour - 2*time.Minute)
+	handler.evaluate(context.TODO(), workflow)
+	assert.True(t, workflow.Status.IsHibernated())
+	clock.advance(90 * time.Second)
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	assert.True(t, strings.HasPrefix(workflow.Status.Idle.Message, "Woken up for the schedule"))
+}
+
+func Test_idleHandler_WithoutMetrics(t *testing.T) {
+	clock := useFakeIdleClock(t)
+	var activity int64
+	activityErr := fmt.Errorf("connection refused")
+	useFakeWorkflowActivity(t, &activity, &activityErr)
+	workflow := idleWorkflow(t)
+	workflow.Status.Services = nil
+	handler := newIdleHandler(fakeReconcilerSupport(test.NewSonataFlowClientBuilder().Build()))
+
+	handler.evaluate(context.TODO(), workflow)
+	clock.advance(time.Hour)
+	handler.evaluate(context.TODO(), workflow)
+	assert.False(t, workflow.Status.IsHibernated())
+	assert.Contains(t, workflow.Status.Idle.Message, "connection refused")
+
+	// the knative deployment model scales to zero by itself
+	workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+	handler.evaluate(context.TODO(), workflow)
+	assert.Nil(t, workflow.Status.Idle)
+}
@@ -66,7 +66,7 @@ func addOpenShiftImageTriggerDeploymentMutateVisitor(workflow *v1alpha08.SonataF
 }
 
 // mountConfigMapsMutateVisitor mounts the required configMaps in the SonataFlow instance
-func mountConfigMapsMutateVisitor(workflow *operatorapi.SonataFlow, userPropsCM *v1.ConfigMap, managedPropsCM *v1.ConfigMap) common.MutateVisitor {
+func mountConfigMapsMutateVisitor(workflow *operatorapi.SonataFlow, userPropsCM *v1.ConfigMap, managedPropsCM *v1.ConfigMap, referencesVersions map[string]string) common.MutateVisitor {
 	return func(object client.Object) controllerutil.MutateFn {
 		return func() error {
 			var podTemplateSpec *v1.PodSpec
@@ -77,7 +77,7 @@ func mountConfigMapsMutateVisitor(workflow *operatorapi.SonataFlow, userPropsCM
 			} else {
 				deployment := object.(*appsv1.Deployment)
 				podTemplateSpec = &deployment.Spec.Template.Spec
-				if err := kubeutil.AnnotateDeploymentConfigChecksum(workflow, deployment, userPropsCM, managedPropsCM); err != nil {
+				if err := kubeutil.AnnotateDeploymentConfigChecksum(workflow, deployment, userPropsCM, managedPropsCM, referencesVersions); err != nil {
 					return err
 				}
 			}
@@ -58,9 +58,13 @@ type ObjectEnsurers struct {
 	// kservice Knative Serving deployment for this ensurer. Don't call it directly, use DeploymentByDeploymentModel instead
 	kservice common.ObjectEnsurerWithPlatform
 	// service for this ensurer. Don't call it directly, use ServiceByDeploymentModel instead
-	service               common.ObjectEnsurer
+	service               common.ObjectEnsurerWithPlatform
 	userPropsConfigMap    common.ObjectEnsurer
 	managedPropsConfigMap common.ObjectEnsurerWithPlatform
+	// candidateDeployment runs the new version of a workflow with the Kubernetes deployment model during a progressive rollout
+	candidateDeployment common.ObjectEnsurerWithPlatform
+	// horizontalPodAutoscaler scales the workflow Deployment, only for the Kubernetes deployment model with autoscaling configured
+	horizontalPodAutoscaler common.ObjectEnsurer
 }
 
 // DeploymentByDeploymentModel gets the deployment ensurer based on the SonataFlow deployment model
@@ -72,7 +76,7 @@ func (o *ObjectEnsurers) DeploymentByDeploymentModel(workflow *v1alpha08.SonataF
 }
 
 // ServiceByDeploymentModel gets the service ensurer based on the SonataFlow deployment model
-func (o *ObjectEnsurers) ServiceByDeploymentModel(workflow *v1alpha08.SonataFlow) common.ObjectEnsurer {
+func (o *ObjectEnsurers) ServiceByDeploymentModel(workflow *v1alpha08.SonataFlow) common.ObjectEnsurerWithPlatform {
 	if workflow.IsKnativeDeployment() {
 		// Knative Serving handles the service
 		return common.NewNoopObjectEnsurer()
@@ -83,11 +87,13 @@ func (o *ObjectEnsurers) ServiceByDeploymentModel(workflow *v1alpha08.SonataFlow
 // NewObjectEnsurers common.ObjectEnsurer(s) for the preview profile.
 func NewObjectEnsurers(support *common.StateSupport) *ObjectEnsurers {
 	return &ObjectEnsurers{
-		deployment:            common.NewObjectEnsurerWithPlatform(support.C, common.DeploymentCreator),
-		kservice:              common.NewObjectEnsurerWithPlatform(support.C, common.KServiceCreator),
-		service:               common.NewObjectEnsurer(support.C, common.ServiceCreator),
-		userPropsConfigMap:    common.NewObjectEnsurer(support.C, common.UserPropsConfigMapCreator),
-		managedPropsConfigMap: common.NewObjectEnsurerWithPlatform(support.C, common.ManagedPropsConfigMapCreator),
+		deployment:              common.NewObjectEnsurerWithPlatform(support.C, common.DeploymentCreator),
+		kservice:                common.NewObjectEnsurerWithPlatform(support.C, common.KServiceCreator),
+		service:                 common.NewObjectEnsurerWithPlatform(support.C, common.ServiceCreator),
+		userPropsConfigMap:      common.NewObjectEnsurer(support.C, common.UserPropsConfigMapCreator),
+		managedPropsConfigMap:   common.NewObjectEnsurerWithPlatform(support.C, common.ManagedPropsConfigMapCreator),
+		candidateDeployment:     common.NewObjectEnsurerWithPlatform(support.C, candidateDeploymentCreator),
+		horizontalPodAutoscaler: common.NewObjectEnsurer(support.C, common.HorizontalPodAutoscalerCreator),
 	}
 }
 
@@ -100,12 +106,15 @@ func NewProfileReconciler(client client.Client, cfg *rest.Config, recorder recor
 		Catalog:  discovery.NewServiceCatalogForConfig(client, cfg),
 		Recorder: recorder,
 	}
-	// the reconciliation state machine
-	stateMachine := common.NewReconciliationStateMachine(
-		&newBuilderState{StateSupport: support, ensurers: NewObjectEnsurers(support)},
-		&followBuildStatusState{StateSupport: support},
-		&deployWithBuildWorkflowState{StateSupport: support, ensurers: NewObjectEnsurers(support)},
-	)
+	newBuilder := &newBuilderState{StateSupport: support, ensurers: NewObjectEnsurers(support)}
+	followBuildStatus := &followBuildStatusState{StateSupport: support, ensurers: NewObjectEnsurers(support)}
+	deployWithBuild := &deployWithBuildWorkflowState{StateSupport: support, ensurers: NewObjectEnsurers(support)}
+	// the reconciliation state machine: the build is followed once started, then deployed if it succeeds or started
+	// again if it fails. A deployed workflow only goes back to the build states when a new build is requested.
+	stateMachine := common.NewReconciliationStateMachine(newBuilder, followBuildStatus, deployWithBuild).
+		AddTransition(newBuilder, followBuildStatus).
+		AddTransition(followBuildStatus, newBuilder, deployWithBuild).
+		AddGuardedTransition(deployWithBuild, isRebuildRequested, newBuilder, followBuildStatus)
 	reconciler := &previewProfile{
 		Reconciler: common.NewReconciler(support, stateMachine),
 	}
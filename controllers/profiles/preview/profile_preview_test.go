@@ -126,6 +126,31 @@ func Test_reconcilerProdBuildConditions(t *testing.T) {
 	assert.True(t, workflow.Status.IsReady())
 }
 
+func Test_reconcilerProdUndeclaredTransition(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	platform := test.GetBasePlatformInReadyPhase(t.Name())
+	// a deployed workflow whose build fails without a new build being requested can't go back to the builder
+	workflow.Status.ReconciliationState = "DeployWithBuildWorkflow"
+	workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.BuildFailedReason, "build failed")
+	client := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflow, platform).
+		WithStatusSubresource(workflow, platform, &operatorapi.SonataFlowBuild{}).Build()
+
+	result, err := NewProfileReconciler(client, &rest.Config{}, test.NewFakeRecorder()).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter)
+	assert.Equal(t, "DeployWithBuildWorkflow", workflow.Status.ReconciliationState)
+	cond := workflow.Status.GetCondition(api.ReconciledConditionType)
+	assert.True(t, cond.IsFalse())
+	assert.Equal(t, api.UnknownStateReason, cond.Reason)
+	assert.Contains(t, cond.Message, "NewBuilder")
+
+	// no build was started by the refused state
+	builds := &operatorapi.SonataFlowBuildList{}
+	assert.NoError(t, client.List(context.TODO(), builds))
+	assert.Empty(t, builds.Items)
+}
+
 func Test_deployWorkflowReconciliationHandler_handleObjects(t *testing.T) {
 	workflow := test.GetBaseSonataFlow(t.Name())
 	platform := test.GetBasePlatformInReadyPhase(t.Name())
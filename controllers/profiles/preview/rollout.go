@@ -0,0 +1,451 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package preview
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	kubeutil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/kubernetes"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+const (
+	// rolloutTrackLabel tells apart the pods of the candidate version during a progressive rollout
+	rolloutTrackLabel = metadata.Domain + "/rollout-track"
+	candidateTrack    = "candidate"
+	// rolloutStartedAtAnnotation forces Knative to create a new revision for the candidate, even if the image reference didn't change
+	rolloutStartedAtAnnotation = metadata.Domain + "/rolloutStartedAt"
+
+	candidateDeploymentSuffix = "-" + candidateTrack
+)
+
+// rolloutHandler drives the progressive rollouts described by operatorapi.RolloutStrategy.
+//
+// For the Knative deployment model, the new version is a new revision of the Knative Service and the traffic is split
+// between the stable and the new revision. For the Kubernetes deployment model, the new version runs in a candidate
+// Deployment and the workflow Service selector is switched between the stable and the candidate pods, without
+// changing the stable pods: the blue/green candidate pods don't match the workflow labels selected by default. Once the
+// candidate is ready, and for canary rollouts once it stayed ready for the canary step, the workflow Deployment is
+// updated and the candidate is removed.
+type rolloutHandler struct {
+	*common.StateSupport
+	ensurers *ObjectEnsurers
+}
+
+func newRolloutHandler(support *common.StateSupport, ensurers *ObjectEnsurers) *rolloutHandler {
+	return &rolloutHandler{StateSupport: support, ensurers: ensurers}
+}
+
+// startRollout starts a progressive rollout of the given image. Returns false if the version currently serving the
+// traffic can't be determined, in which case the caller must replace the running deployment instead.
+func (r *rolloutHandler) startRollout(ctx context.Context, workflow *operatorapi.SonataFlow, image string) (bool, error) {
+	rollout := &operatorapi.RolloutStatus{
+		Strategy:       workflow.Spec.Rollout.GetType(),
+		CandidateImage: image,
+		StartTime:      metav1.Now(),
+	}
+	if current := workflow.Status.Rollout; current.IsInProgress() && current.Phase == operatorapi.RolloutPhaseProgressing {
+		// a newer build supersedes the candidate being verified, the stable version is still the same
+		rollout.StableImage = current.StableImage
+		rollout.StableRevision = current.StableRevision
+	} else if workflow.IsKnativeDeployment() {
+		ksvc := &servingv1.Service{}
+		if err := r.C.Get(ctx, client.ObjectKeyFromObject(workflow), ksvc); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if len(ksvc.Status.LatestReadyRevisionName) == 0 {
+			return false, nil
+		}
+		rollout.StableRevision = ksvc.Status.LatestReadyRevisionName
+		rollout.StableImage = getFlowContainerImage(&ksvc.Spec.Template.Spec.PodSpec)
+	} else {
+		deployment := &appsv1.Deployment{}
+		if err := r.C.Get(ctx, client.ObjectKeyFromObject(workflow), deployment); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		rollout.StableImage = getFlowContainerImage(&deployment.Spec.Template.Spec)
+	}
+	if rollout.Strategy == operatorapi.CanaryRolloutStrategy {
+		rollout.CandidateWeight = workflow.Spec.Rollout.GetCanaryWeight()
+	}
+	rollout.SetPhase(operatorapi.RolloutPhaseProgressing, fmt.Sprintf("Verifying image %s", image))
+	workflow.Status.Rollout = rollout
+	return true, nil
+}
+
+// reconcile moves the rollout forward based on the state of the new version. Must be called before ensuring the
+// workflow deployment and service, so they can reflect the current phase.
+func (r *rolloutHandler) reconcile(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform,
+	userPropsCM *corev1.ConfigMap, managedPropsCM *corev1.ConfigMap, referencesVersions map[string]string) ([]client.Object, error) {
+	if workflow.Status.Rollout == nil {
+		return nil, nil
+	}
+	if workflow.IsKnativeDeployment() {
+		return nil, r.reconcileKnative(ctx, workflow)
+	}
+	return r.reconcileKubernetes(ctx, workflow, pl, userPropsCM, managedPropsCM, referencesVersions)
+}
+
+func (r *rolloutHandler) reconcileKnative(ctx context.Context, workflow *operatorapi.SonataFlow) error {
+	rollout := workflow.Status.Rollout
+	if rollout.Phase != operatorapi.RolloutPhaseProgressing {
+		return nil
+	}
+	ksvc := &servingv1.Service{}
+	if err := r.C.Get(ctx, client.ObjectKeyFromObject(workflow), ksvc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if latest := ksvc.Status.LatestCreatedRevisionName; len(latest) > 0 && latest != rollout.StableRevision {
+		rollout.CandidateRevision = latest
+	}
+	candidateReady := len(rollout.CandidateRevision) > 0 && ksvc.Status.LatestReadyRevisionName == rollout.CandidateRevision
+	switch {
+	case candidateReady && ksvc.Status.GetCondition(servingv1.ServiceConditionReady).IsFalse():
+		r.rollback(workflow, fmt.Sprintf("Revision %s became unavailable: %s", rollout.CandidateRevision,
+			ksvc.Status.GetCondition(servingv1.ServiceConditionReady).GetMessage()))
+	case candidateReady:
+		if isCandidateVerified(workflow) {
+			r.promoted(workflow, fmt.Sprintf("Revision %s is serving all the traffic", rollout.CandidateRevision))
+		} else {
+			rollout.Message = fmt.Sprintf("Revision %s is ready and serving %d%% of the traffic", rollout.CandidateRevision, rollout.CandidateWeight)
+		}
+	case len(rollout.CandidateRevision) > 0 && ksvc.Status.GetCondition(servingv1.ServiceConditionConfigurationsReady).IsFalse():
+		r.rollback(workflow, fmt.Sprintf("Revision %s failed: %s", rollout.CandidateRevision,
+			ksvc.Status.GetCondition(servingv1.ServiceConditionConfigurationsReady).GetMessage()))
+	case isRolloutDeadlineExceeded(workflow):
+		r.rollback(workflow, fmt.Sprintf("Revision %s didn't become ready in time", rollout.CandidateRevision))
+	}
+	return nil
+}
+
+func (r *rolloutHandler) reconcileKubernetes(ctx context.Context, workflow *operatorapi.SonataFlow, pl *operatorapi.SonataFlowPlatform,
+	userPropsCM *corev1.ConfigMap, managedPropsCM *corev1.ConfigMap, referencesVersions map[string]string) ([]client.Object, error) {
+	rollout := workflow.Status.Rollout
+	switch rollout.Phase {
+	case operatorapi.RolloutPhaseProgressing:
+		candidate, _, err := r.ensurers.candidateDeployment.Ensure(ctx, workflow, pl,
+			candidateDeploymentMutateVisitor(workflow, pl),
+			common.ImageDeploymentMutateVisitor(workflow, rollout.CandidateImage),
+			mountConfigMapsMutateVisitor(workflow, userPropsCM, managedPropsCM, referencesVersions))
+		if err != nil {
+			return nil, err
+		}
+		deployment := candidate.(*appsv1.Deployment)
+		switch {
+		case isDeploymentRolledOut(deployment):
+			if !isCandidateVerified(workflow) {
+				rollout.Message = fmt.Sprintf("Image %s is ready and serving %d%% of the traffic", rollout.CandidateImage, rollout.CandidateWeight)
+				break
+			}
+			rollout.SetPhase(operatorapi.RolloutPhasePromoting, fmt.Sprintf("Image %s is ready, updating the workflow deployment", rollout.CandidateImage))
+			r.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.RolloutPromotingReason, "Promoting image %s of workflow %s.", rollout.CandidateImage, workflow.Name)
+		case kubeutil.IsDeploymentFailed(deployment):
+			r.rollback(workflow, common.GetDeploymentUnavailabilityMessage(deployment))
+		case rollout.CandidateReadyTime != nil:
+			r.rollback(workflow, fmt.Sprintf("Deployment %s became unavailable during the canary step", deployment.Name))
+		case isRolloutDeadlineExceeded(workflow):
+			r.rollback(workflow, fmt.Sprintf("Deployment %s didn't become ready in time", deployment.Name))
+		}
+		return []client.Object{candidate}, nil
+	case operatorapi.RolloutPhasePromoting:
+		deployment := &appsv1.Deployment{}
+		if err := r.C.Get(ctx, client.ObjectKeyFromObject(workflow), deployment); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		// the workflow deployment is rolled out by this reconciliation, so it must have the candidate image before checking it
+		if getFlowContainerImage(&deployment.Spec.Template.Spec) != rollout.CandidateImage || !isRolloutRestarted(workflow, deployment) {
+			return nil, nil
+		}
+		switch {
+		case isDeploymentRolledOut(deployment):
+			r.promoted(workflow, fmt.Sprintf("Image %s is serving all the traffic", rollout.CandidateImage))
+		case kubeutil.IsDeploymentFailed(deployment):
+			r.rollback(workflow, common.GetDeploymentUnavailabilityMessage(deployment))
+		case isRolloutDeadlineExceeded(workflow):
+			r.rollback(workflow, fmt.Sprintf("Deployment %s didn't become ready in time", deployment.Name))
+		}
+		if rollout.IsInProgress() {
+			return nil, nil
+		}
+	}
+	return nil, r.deleteCandidateDeployment(ctx, workflow)
+}
+
+func (r *rolloutHandler) promoted(workflow *operatorapi.SonataFlow, message string) {
+	workflow.Status.Rollout.SetPhase(operatorapi.RolloutPhasePromoted, message)
+	workflow.Status.Rollout.CandidateWeight = 100
+	klog.V(log.I).InfoS("Workflow rollout promoted", "workflow", workflow.Name, "message", message)
+	r.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.RolloutPromotedReason, "Workflow %s rollout promoted. %s", workflow.Name, message)
+}
+
+func (r *rolloutHandler) rollback(workflow *operatorapi.SonataFlow, message string) {
+	workflow.Status.Rollout.SetPhase(operatorapi.RolloutPhaseRolledBack, message)
+	workflow.Status.Rollout.CandidateWeight = 0
+	klog.V(log.I).InfoS("Workflow rollout rolled back", "workflow", workflow.Name, "message", message)
+	r.Recorder.Eventf(workflow, corev1.EventTypeWarning, api.RolloutRolledBackReason, "Workflow %s rollout rolled back. %s", workflow.Name, message)
+}
+
+func (r *rolloutHandler) deleteCandidateDeployment(ctx context.Context, workflow *operatorapi.SonataFlow) error {
+	candidate := &appsv1.Deployment{}
+	if err := r.C.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name + candidateDeploymentSuffix}, candidate); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.C.Delete(ctx, candidate); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deployedImage returns the image the workflow Deployment or Knative Service must run, given the image of the last build.
+func deployedImage(workflow *operatorapi.SonataFlow, image string) string {
+	rollout := workflow.Status.Rollout
+	if rollout == nil || len(rollout.StableImage) == 0 {
+		return image
+	}
+	if rollout.Phase == operatorapi.RolloutPhaseRolledBack {
+		return rollout.StableImage
+	}
+	// Knative keeps the stable version in its own revision while the candidate is verified, the traffic split protects it
+	if rollout.Phase == operatorapi.RolloutPhaseProgressing && !workflow.IsKnativeDeployment() {
+		return rollout.StableImage
+	}
+	return image
+}
+
+// isCandidateVerified is called once the candidate is ready and returns true if it can be promoted: blue/green
+// candidates right away, canary candidates once they stayed ready for the canary step.
+func isCandidateVerified(workflow *operatorapi.SonataFlow) bool {
+	rollout := workflow.Status.Rollout
+	if rollout.CandidateReadyTime == nil {
+		now := metav1.Now()
+		rollout.CandidateReadyTime = &now
+	}
+	if rollout.Strategy != operatorapi.CanaryRolloutStrategy {
+		return true
+	}
+	return !time.Now().Before(rollout.CandidateReadyTime.Add(workflow.Spec.Rollout.GetCanaryStepDuration()))
+}
+
+// rolloutRequeueAfter returns when the rollout must be reconciled again to promote a canary at the end of its step,
+// zero when the rollout is waiting for a change in the new version instead.
+func rolloutRequeueAfter(workflow *operatorapi.SonataFlow) time.Duration {
+	rollout := workflow.Status.Rollout
+	if rollout == nil || rollout.Phase != operatorapi.RolloutPhaseProgressing || rollout.CandidateReadyTime == nil {
+		return 0
+	}
+	return max(time.Until(rollout.CandidateReadyTime.Add(workflow.Spec.Rollout.GetCanaryStepDuration())), time.Second)
+}
+
+func isRolloutDeadlineExceeded(workflow *operatorapi.SonataFlow) bool {
+	deadline := time.Duration(workflow.Spec.Rollout.GetProgressDeadlineSeconds()) * time.Second
+	return workflow.Status.Rollout.LastTransitionTime.Add(deadline).Before(time.Now())
+}
+
+// isDeploymentRolledOut returns true when every replica of the Deployment runs its latest template and is available.
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.AvailableReplicas >= replicas &&
+		kubeutil.IsDeploymentAvailable(deployment)
+}
+
+// isRolloutRestarted returns true if the Deployment pods were restarted after the current rollout started its promotion.
+// Other visitors, like the configuration checksum, might have restarted them again in the meantime.
+func isRolloutRestarted(workflow *operatorapi.SonataFlow, deployment *appsv1.Deployment) bool {
+	restartedAt, err := time.Parse(time.RFC3339, deployment.Spec.Template.Annotations[metadata.RestartedAt])
+	if err != nil {
+		return false
+	}
+	return !restartedAt.Before(workflow.Status.Rollout.LastTransitionTime.Truncate(time.Second))
+}
+
+func getFlowContainerImage(podSpec *corev1.PodSpec) string {
+	container, _ := kubeutil.GetContainerByName(operatorapi.DefaultContainerName, podSpec)
+	if container == nil {
+		return ""
+	}
+	return container.Image
+}
+
+// candidateReplicas returns the number of replicas of the candidate Deployment. For canary rollouts, the traffic is
+// split by the number of pods behind the workflow Service, so at least one candidate replica is always deployed.
+func candidateReplicas(workflow *operatorapi.SonataFlow) *int32 {
+	replicas := *getWorkflowReplicas(workflow)
+	if workflow.Status.Rollout.Strategy != operatorapi.CanaryRolloutStrategy {
+		return &replicas
+	}
+	weight := float64(workflow.Status.Rollout.CandidateWeight)
+	canary := int32(math.Max(1, math.Ceil(float64(replicas)*weight/(100-weight))))
+	return &canary
+}
+
+func getWorkflowReplicas(workflow *operatorapi.SonataFlow) *int32 {
+	var replicas int32 = 1
+	if workflow.Spec.PodTemplate.Autoscaling != nil {
+		replicas = max(workflow.Spec.PodTemplate.Autoscaling.GetMinReplicas(), 1)
+	} else if workflow.Spec.PodTemplate.Replicas != nil {
+		replicas = *workflow.Spec.PodTemplate.Replicas
+	}
+	return &replicas
+}
+
+// candidateLabels returns the labels of the candidate pods. The canary candidate pods match the workflow labels, so the
+// workflow Service sends them their share of the traffic. The blue/green candidate pods have their own "app" label
+// instead, so they don't receive any traffic until the promotion.
+func candidateLabels(workflow *operatorapi.SonataFlow) map[string]string {
+	labels := workflowproj.GetMergedLabels(workflow)
+	labels[rolloutTrackLabel] = candidateTrack
+	if workflow.Status.Rollout.Strategy != operatorapi.CanaryRolloutStrategy {
+		labels[workflowproj.LabelApp] = workflow.Name + candidateDeploymentSuffix
+	}
+	return labels
+}
+
+// candidateSelector returns the selector of the candidate Deployment, the same for every rollout strategy since it
+// can't be changed once created.
+func candidateSelector(workflow *operatorapi.SonataFlow) map[string]string {
+	labels := candidateLabels(workflow)
+	delete(labels, workflowproj.LabelApp)
+	return labels
+}
+
+// candidateDeploymentCreator creates the Deployment running the candidate version of a workflow with the Kubernetes
+// deployment model. It's the workflow Deployment with its own name and selector.
+func candidateDeploymentCreator(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) (client.Object, error) {
+	object, err := common.DeploymentCreator(workflow, plf)
+	if err != nil {
+		return nil, err
+	}
+	deployment := object.(*appsv1.Deployment)
+	deployment.Name = workflow.Name + candidateDeploymentSuffix
+	deployment.Labels = candidateLabels(workflow)
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: candidateSelector(workflow)}
+	deployment.Spec.Template.Labels = candidateLabels(workflow)
+	deployment.Spec.Replicas = candidateReplicas(workflow)
+	return deployment, nil
+}
+
+// candidateDeploymentMutateVisitor same as common.DeploymentMutateVisitor for the candidate Deployment
+func candidateDeploymentMutateVisitor(workflow *operatorapi.SonataFlow, plf *operatorapi.SonataFlowPlatform) common.MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := candidateDeploymentCreator(workflow, plf)
+			if err != nil {
+				return err
+			}
+			return common.EnsureDeployment(original.(*appsv1.Deployment), object.(*appsv1.Deployment))
+		}
+	}
+}
+
+// rolloutDeploymentMutateVisitor restarts the workflow Deployment pods when the candidate is being promoted, along with
+// the image change. The pod template is left untouched otherwise.
+func rolloutDeploymentMutateVisitor(workflow *operatorapi.SonataFlow) common.MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if workflow.Status.Rollout == nil || workflow.Status.Rollout.Phase != operatorapi.RolloutPhasePromoting {
+				return nil
+			}
+			deployment := object.(*appsv1.Deployment)
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = map[string]string{}
+			}
+			deployment.Spec.Template.Annotations[metadata.RestartedAt] = workflow.Status.Rollout.LastTransitionTime.Format(time.RFC3339)
+			return nil
+		}
+	}
+}
+
+// rolloutServiceMutateVisitor switches the workflow Service selector between the stable and the candidate pods.
+func rolloutServiceMutateVisitor(workflow *operatorapi.SonataFlow) common.MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			rollout := workflow.Status.Rollout
+			if rollout == nil {
+				return nil
+			}
+			selector := workflowproj.GetMergedLabels(workflow)
+			if rollout.Phase == operatorapi.RolloutPhasePromoting {
+				selector = candidateLabels(workflow)
+			}
+			object.(*corev1.Service).Spec.Selector = selector
+			return nil
+		}
+	}
+}
+
+// rolloutKServiceMutateVisitor splits the Knative Service traffic between the stable and the candidate revisions.
+func rolloutKServiceMutateVisitor(workflow *operatorapi.SonataFlow) common.MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			rollout := workflow.Status.Rollout
+			if rollout == nil {
+				return nil
+			}
+			ksvc := object.(*servingv1.Service)
+			latestRevision := true
+			switch rollout.Phase {
+			case operatorapi.RolloutPhaseProgressing:
+				if ksvc.Spec.Template.Annotations == nil {
+					ksvc.Spec.Template.Annotations = map[string]string{}
+				}
+				ksvc.Spec.Template.Annotations[rolloutStartedAtAnnotation] = rollout.StartTime.Format(time.RFC3339)
+				stablePercent := int64(100 - rollout.CandidateWeight)
+				candidatePercent := int64(rollout.CandidateWeight)
+				ksvc.Spec.Traffic = []servingv1.TrafficTarget{
+					{RevisionName: rollout.StableRevision, Percent: &stablePercent},
+					{LatestRevision: &latestRevision, Percent: &candidatePercent, Tag: candidateTrack},
+				}
+			case operatorapi.RolloutPhaseRolledBack:
+				percent := int64(100)
+				ksvc.Spec.Traffic = []servingv1.TrafficTarget{{RevisionName: rollout.StableRevision, Percent: &percent}}
+			default:
+				percent := int64(100)
+				ksvc.Spec.Traffic = []servingv1.TrafficTarget{{LatestRevision: &latestRevision, Percent: &percent}}
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,297 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package preview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	clientruntime "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+func markDeploymentRolledOut(t *testing.T, client clientruntime.Client, key types.NamespacedName) {
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), key, deployment))
+	deployment.Status.ObservedGeneration = deployment.Generation
+	deployment.Status.Replicas = *deployment.Spec.Replicas
+	deployment.Status.UpdatedReplicas = *deployment.Spec.Replicas
+	deployment.Status.AvailableReplicas = *deployment.Spec.Replicas
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}}
+	assert.NoError(t, client.Status().Update(context.TODO(), deployment))
+}
+
+func Test_BlueGreenRolloutKubernetes(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	workflow.Spec.Rollout = &operatorapi.RolloutStrategy{Type: operatorapi.BlueGreenRolloutStrategy}
+	client := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflow).
+		WithStatusSubresource(workflow, &appsv1.Deployment{}).
+		Build()
+	stateSupport := fakeReconcilerSupport(client)
+	ensurers := NewObjectEnsurers(stateSupport)
+	handler := NewDeploymentReconciler(stateSupport, ensurers)
+	workflowKey := clientruntime.ObjectKeyFromObject(workflow)
+	candidateKey := types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name + candidateDeploymentSuffix}
+
+	_, _, err := handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:1.0.0")
+	assert.NoError(t, err)
+	markDeploymentRolledOut(t, client, workflowKey)
+
+	started, err := newRolloutHandler(stateSupport, ensurers).startRollout(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, "quay.io/apache/greeting:1.0.0", workflow.Status.Rollout.StableImage)
+	assert.Equal(t, operatorapi.RolloutPhaseProgressing, workflow.Status.Rollout.Phase)
+
+	// the candidate runs the new image while the workflow keeps serving the stable one
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), workflowKey, deployment))
+	assert.Equal(t, "quay.io/apache/greeting:1.0.0", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.NotContains(t, deployment.Spec.Template.Labels, rolloutTrackLabel)
+	assert.Empty(t, deployment.Spec.Template.Annotations[metadata.RestartedAt])
+	candidate := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), candidateKey, candidate))
+	assert.Equal(t, "quay.io/apache/greeting:2.0.0", candidate.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, candidateTrack, candidate.Spec.Selector.MatchLabels[rolloutTrackLabel])
+	assert.Equal(t, candidateKey.Name, candidate.Spec.Template.Labels[workflowproj.LabelApp])
+	// the workflow Service keeps selecting the stable pods only, the candidate pods have their own app label
+	service := &corev1.Service{}
+	assert.NoError(t, client.Get(context.TODO(), workflowKey, service))
+	assert.Equal(t, workflowproj.GetMergedLabels(workflow), service.Spec.Selector)
+
+	// once the candidate is ready, the traffic is switched to it and the workflow deployment is updated
+	markDeploymentRolledOut(t, client, candidateKey)
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, operatorapi.RolloutPhasePromoting, workflow.Status.Rollout.Phase)
+	assert.NoError(t, client.Get(context.TODO(), workflowKey, deployment))
+	assert.Equal(t, "quay.io/apache/greeting:2.0.0", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.NotEmpty(t, deployment.Spec.Template.Annotations[metadata.RestartedAt])
+	assert.NoError(t, client.Get(context.TODO(), workflowKey, service))
+	assert.Equal(t, candidateTrack, service.Spec.Selector[rolloutTrackLabel])
+	assert.Equal(t, candidateKey.Name, service.Spec.Selector[workflowproj.LabelApp])
+
+	markDeploymentRolledOut(t, client, workflowKey)
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, operatorapi.RolloutPhasePromoted, workflow.Status.Rollout.Phase)
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), candidateKey, candidate)))
+	assert.NoError(t, client.Get(context.TODO(), workflowKey, service))
+	assert.NotContains(t, service.Spec.Selector, rolloutTrackLabel)
+}
+
+func Test_CanaryRolloutFailedCandidateIsRolledBack(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	weight := int32(25)
+	replicas := int32(3)
+	workflow.Spec.PodTemplate.Replicas = &replicas
+	workflow.Spec.Rollout = &operatorapi.RolloutStrategy{Type: operatorapi.CanaryRolloutStrategy, Canary: &operatorapi.CanaryRolloutOptions{Weight: &weight}}
+	client := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflow).
+		WithStatusSubresource(workflow, &appsv1.Deployment{}).
+		Build()
+	stateSupport := fakeReconcilerSupport(client)
+	ensurers := NewObjectEnsurers(stateSupport)
+	handler := NewDeploymentReconciler(stateSupport, ensurers)
+	candidateKey := types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name + candidateDeploymentSuffix}
+
+	_, _, err := handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:1.0.0")
+	assert.NoError(t, err)
+	started, err := newRolloutHandler(stateSupport, ensurers).startRollout(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, weight, workflow.Status.Rollout.CandidateWeight)
+
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	candidate := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), candidateKey, candidate))
+	assert.Equal(t, int32(1), *candidate.Spec.Replicas)
+	assert.Equal(t, workflow.Name, candidate.Spec.Template.Labels[workflowproj.LabelApp])
+	service := &corev1.Service{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), service))
+	assert.NotContains(t, service.Spec.Selector, rolloutTrackLabel)
+
+	candidate.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue, Message: "quota exceeded"}}
+	assert.NoError(t, client.Status().Update(context.TODO(), candidate))
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, operatorapi.RolloutPhaseRolledBack, workflow.Status.Rollout.Phase)
+	assert.Contains(t, workflow.Status.Rollout.Message, "quota exceeded")
+
+	// the candidate is removed on the next reconciliation and the stable image is kept
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), candidateKey, candidate)))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Equal(t, "quay.io/apache/greeting:1.0.0", deployment.Spec.Template.Spec.Containers[0].Image)
+}
+
+func Test_CanaryRolloutKnativeTraffic(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+	workflow.Spec.Rollout = &operatorapi.RolloutStrategy{Type: operatorapi.CanaryRolloutStrategy}
+	ksvc := &servingv1.Service{}
+	ksvc.Name = workflow.Name
+	ksvc.Namespace = workflow.Namespace
+	ksvc.Status.LatestReadyRevisionName = "greeting-00001"
+	ksvc.Status.LatestCreatedRevisionName = "greeting-00001"
+	client := test.NewSonataFlowClientBuilderWithKnative().
+		WithRuntimeObjects(workflow, ksvc).
+		WithStatusSubresource(workflow, ksvc).
+		Build()
+	stateSupport := fakeReconcilerSupport(client)
+	rollout := newRolloutHandler(stateSupport, NewObjectEnsurers(stateSupport))
+
+	started, err := rollout.startRollout(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, "greeting-00001", workflow.Status.Rollout.StableRevision)
+
+	assert.NoError(t, rolloutKServiceMutateVisitor(workflow)(ksvc)())
+	assert.Len(t, ksvc.Spec.Traffic, 2)
+	assert.Equal(t, "greeting-00001", ksvc.Spec.Traffic[0].RevisionName)
+	assert.Equal(t, int64(90), *ksvc.Spec.Traffic[0].Percent)
+	assert.True(t, *ksvc.Spec.Traffic[1].LatestRevision)
+	assert.Equal(t, int64(10), *ksvc.Spec.Traffic[1].Percent)
+
+	// the new revision becomes ready and keeps its weight of the traffic for the canary step
+	ksvc.Status.LatestCreatedRevisionName = "greeting-00002"
+	ksvc.Status.LatestReadyRevisionName = "greeting-00002"
+	assert.NoError(t, client.Status().Update(context.TODO(), ksvc))
+	assert.NoError(t, rollout.reconcileKnative(context.TODO(), workflow))
+	assert.Equal(t, operatorapi.RolloutPhaseProgressing, workflow.Status.Rollout.Phase)
+	assert.NotNil(t, workflow.Status.Rollout.CandidateReadyTime)
+	assert.Greater(t, rolloutRequeueAfter(workflow), time.Duration(0))
+	assert.NoError(t, rolloutKServiceMutateVisitor(workflow)(ksvc)())
+	assert.Len(t, ksvc.Spec.Traffic, 2)
+
+	// promoted once the step elapsed
+	workflow.Status.Rollout.CandidateReadyTime = &metav1.Time{Time: time.Now().Add(-workflow.Spec.Rollout.GetCanaryStepDuration())}
+	assert.NoError(t, rollout.reconcileKnative(context.TODO(), workflow))
+	assert.Equal(t, operatorapi.RolloutPhasePromoted, workflow.Status.Rollout.Phase)
+	assert.Equal(t, time.Duration(0), rolloutRequeueAfter(workflow))
+	assert.Equal(t, "greeting-00002", workflow.Status.Rollout.CandidateRevision)
+
+	assert.NoError(t, rolloutKServiceMutateVisitor(workflow)(ksvc)())
+	assert.Len(t, ksvc.Spec.Traffic, 1)
+	assert.Equal(t, int64(100), *ksvc.Spec.Traffic[0].Percent)
+}
+
+func Test_CanaryRolloutKnativeRollbackRevertsImage(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+	workflow.Spec.Rollout = &operatorapi.RolloutStrategy{Type: operatorapi.CanaryRolloutStrategy}
+	workflow.Status.Rollout = &operatorapi.RolloutStatus{
+		Strategy:        operatorapi.CanaryRolloutStrategy,
+		StableImage:     "quay.io/apache/greeting:1.0.0",
+		StableRevision:  "greeting-00001",
+		CandidateImage:  "quay.io/apache/greeting:2.0.0",
+		CandidateWeight: 10,
+	}
+	workflow.Status.Rollout.SetPhase(operatorapi.RolloutPhaseProgressing, "")
+	assert.Equal(t, "quay.io/apache/greeting:2.0.0", deployedImage(workflow, "quay.io/apache/greeting:2.0.0"))
+
+	ksvc := &servingv1.Service{}
+	ksvc.Name = workflow.Name
+	ksvc.Namespace = workflow.Namespace
+	ksvc.Status.LatestCreatedRevisionName = "greeting-00002"
+	ksvc.Status.LatestReadyRevisionName = "greeting-00002"
+	ksvc.Status.Conditions = duckv1.Conditions{{Type: servingv1.ServiceConditionReady, Status: corev1.ConditionFalse, Message: "probe failed"}}
+	client := test.NewSonataFlowClientBuilderWithKnative().WithRuntimeObjects(workflow, ksvc).Build()
+	stateSupport := fakeReconcilerSupport(client)
+
+	assert.NoError(t, newRolloutHandler(stateSupport, NewObjectEnsurers(stateSupport)).reconcileKnative(context.TODO(), workflow))
+	assert.Equal(t, operatorapi.RolloutPhaseRolledBack, workflow.Status.Rollout.Phase)
+	assert.Contains(t, workflow.Status.Rollout.Message, "probe failed")
+	// the template goes back to the stable image too, not only the traffic
+	assert.Equal(t, "quay.io/apache/greeting:1.0.0", deployedImage(workflow, "quay.io/apache/greeting:2.0.0"))
+	assert.NoError(t, rolloutKServiceMutateVisitor(workflow)(ksvc)())
+	assert.Equal(t, "greeting-00001", ksvc.Spec.Traffic[0].RevisionName)
+}
+
+func Test_CanaryRolloutUnavailableDuringStepIsRolledBack(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	workflow.Spec.Rollout = &operatorapi.RolloutStrategy{Type: operatorapi.CanaryRolloutStrategy}
+	client := test.NewSonataFlowClientBuilder().
+		WithRuntimeObjects(workflow).
+		WithStatusSubresource(workflow, &appsv1.Deployment{}).
+		Build()
+	stateSupport := fakeReconcilerSupport(client)
+	ensurers := NewObjectEnsurers(stateSupport)
+	handler := NewDeploymentReconciler(stateSupport, ensurers)
+	candidateKey := types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name + candidateDeploymentSuffix}
+
+	_, _, err := handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:1.0.0")
+	assert.NoError(t, err)
+	started, err := newRolloutHandler(stateSupport, ensurers).startRollout(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.True(t, started)
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+
+	// the ready candidate isn't promoted before the end of the step
+	markDeploymentRolledOut(t, client, candidateKey)
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, operatorapi.RolloutPhaseProgressing, workflow.Status.Rollout.Phase)
+	assert.NotNil(t, workflow.Status.Rollout.CandidateReadyTime)
+
+	candidate := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), candidateKey, candidate))
+	candidate.Status.AvailableReplicas = 0
+	candidate.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse}}
+	assert.NoError(t, client.Status().Update(context.TODO(), candidate))
+	_, _, err = handler.ensureObjects(context.TODO(), workflow, "quay.io/apache/greeting:2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, operatorapi.RolloutPhaseRolledBack, workflow.Status.Rollout.Phase)
+	assert.Contains(t, workflow.Status.Rollout.Message, "canary step")
+}
+
+func Test_ReplaceRolloutIsNotStarted(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	workflow.Status.Manager().MarkTrue(api.RunningConditionType)
+	assert.Equal(t, "quay.io/apache/greeting:2.0.0", deployedImage(workflow, "quay.io/apache/greeting:2.0.0"))
+	assert.Equal(t, operatorapi.ReplaceRolloutStrategy, workflow.Spec.Rollout.GetType())
+}
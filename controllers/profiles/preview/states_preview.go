@@ -43,6 +43,10 @@ type newBuilderState struct {
 	ensurers *ObjectEnsurers
 }
 
+func (h *newBuilderState) Name() string {
+	return "NewBuilder"
+}
+
 func (h *newBuilderState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	return workflow.Status.GetTopLevelCondition().IsUnknown() ||
 		workflow.Status.IsWaitingForPlatform() ||
@@ -50,7 +54,7 @@ func (h *newBuilderState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 }
 
 func (h *newBuilderState) Do(ctx context.Context, workflow *operatorapi.SonataFlow) (ctrl.Result, []client.Object, error) {
-	pl, err := platform.GetActivePlatform(ctx, h.C, workflow.Namespace)
+	pl, err := platform.GetEffectivePlatform(ctx, h.C, workflow.Namespace)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.WaitingForPlatformReason,
@@ -68,7 +72,7 @@ func (h *newBuilderState) Do(ctx context.Context, workflow *operatorapi.SonataFl
 	_, err = h.PerformStatusUpdate(ctx, workflow)
 	// Ensure the user and managed properties are prepared before starting the build process, and thus, we make them
 	// available at build time.
-	userPropsCM, _, err := h.ensurers.userPropsConfigMap.Ensure(ctx, workflow)
+	userPropsCM, _, err := h.ensurers.userPropsConfigMap.Ensure(ctx, workflow, pl)
 	if err != nil {
 		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.ExternalResourcesNotFoundReason, "Unable to retrieve the user properties config map")
 		_, err = h.PerformStatusUpdate(ctx, workflow)
@@ -115,6 +119,11 @@ func (h *newBuilderState) PostReconcile(ctx context.Context, workflow *operatora
 
 type followBuildStatusState struct {
 	*common.StateSupport
+	ensurers *ObjectEnsurers
+}
+
+func (h *followBuildStatusState) Name() string {
+	return "FollowBuildStatus"
 }
 
 func (h *followBuildStatusState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
@@ -135,14 +144,30 @@ func (h *followBuildStatusState) Do(ctx context.Context, workflow *operatorapi.S
 
 	if build.Status.BuildPhase == operatorapi.BuildPhaseSucceeded {
 		klog.V(log.I).InfoS("Workflow build has finished")
-		if workflow.Status.IsReady() {
-			// Rollout our deployment to take the latest changes in the new image.
-			if err := common.DeploymentManager(h.C).RolloutDeployment(ctx, workflow); err != nil {
+		workflow.Status.SetLastSuccessfulBuild(build.Status.Record())
+		progressive := false
+		if workflow.Status.IsReady() && workflow.Spec.Rollout.GetType() != operatorapi.ReplaceRolloutStrategy {
+			// The current version keeps serving the traffic while the new image is verified.
+			if progressive, err = newRolloutHandler(h.StateSupport, h.ensurers).startRollout(ctx, workflow, build.Status.ImageTag); err != nil {
 				return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, nil, err
 			}
-			h.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.WaitingForDeploymentReason, "Rolling out workflow %s deployment.", workflow.Name)
+			if progressive {
+				h.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.RolloutStartedReason, "Starting %s rollout of workflow %s.",
+					workflow.Status.Rollout.Strategy, workflow.Name)
+			}
+		}
+		if !progressive {
+			if workflow.Status.IsReady() {
+				// Rollout our deployment to take the latest changes in the new image.
+				if err := common.DeploymentManager(h.C).RolloutDeployment(ctx, workflow); err != nil {
+					return ctrl.Result{RequeueAfter: constants.RequeueAfterFailure}, nil, err
+				}
+				h.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.WaitingForDeploymentReason, "Rolling out workflow %s deployment.", workflow.Name)
+			}
+			// a previous progressive rollout doesn't apply to the new image
+			workflow.Status.Rollout = nil
+			workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForDeploymentReason, "Build has finished, rolling out deployment")
 		}
-		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForDeploymentReason, "Build has finished, rolling out deployment")
 		//If we have finished a build and the workflow is not running, we will start the provisioning phase
 		workflow.Status.Manager().MarkTrue(api.BuiltConditionType)
 		_, err = h.PerformStatusUpdate(ctx, workflow)
@@ -176,6 +201,10 @@ type deployWithBuildWorkflowState struct {
 	deploymentVisitors []common.MutateVisitor
 }
 
+func (h *deployWithBuildWorkflowState) Name() string {
+	return "DeployWithBuildWorkflow"
+}
+
 func (h *deployWithBuildWorkflowState) CanReconcile(workflow *operatorapi.SonataFlow) bool {
 	// If we have a built ready, we should deploy the object
 	return workflow.Status.GetCondition(api.BuiltConditionType).IsTrue()
@@ -185,7 +214,7 @@ func (h *deployWithBuildWorkflowState) Do(ctx context.Context, workflow *operato
 	// Guard to avoid errors while getting a new builder manager.
 	// Maybe we can do typed errors in the buildManager and
 	// have something like sonataerr.IsPlatformNotFound(err) instead.
-	_, err := platform.GetActivePlatform(ctx, h.C, workflow.Namespace)
+	_, err := platform.GetEffectivePlatform(ctx, h.C, workflow.Namespace)
 	if err != nil {
 		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
 			"No active Platform for namespace %s so the resWorkflowDef cannot be deployed. Waiting for an active platform", workflow.Namespace)
@@ -199,6 +228,10 @@ func (h *deployWithBuildWorkflowState) Do(ctx context.Context, workflow *operato
 	}
 
 	if h.isWorkflowChanged(workflow) { // Let's check that the 2 resWorkflowDef definition are different
+		// the running version must be kept with its current properties before the new one is built
+		if _, err = newVersioningHandler(h.StateSupport).retirePreviousVersion(ctx, workflow); err != nil {
+			return ctrl.Result{}, nil, err
+		}
 		if err = buildManager.MarkToRestart(build); err != nil {
 			return ctrl.Result{}, nil, err
 		}
@@ -218,6 +251,12 @@ func (h *deployWithBuildWorkflowState) PostReconcile(ctx context.Context, workfl
 	return nil
 }
 
+// isRebuildRequested tells if a new build of a deployed workflow was requested, the deployWithBuildWorkflowState marks
+// the build as running when the workflow changes.
+func isRebuildRequested(workflow *operatorapi.SonataFlow) bool {
+	return workflow.Status.IsBuildRunningOrUnknown()
+}
+
 // isWorkflowChanged marks the workflow status as unknown to require a new build reconciliation
 func (h *deployWithBuildWorkflowState) isWorkflowChanged(workflow *operatorapi.SonataFlow) bool {
 	generation := kubeutil.GetLastGeneration(workflow.Namespace, workflow.Name, h.C, context.TODO())
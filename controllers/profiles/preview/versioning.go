@@ -0,0 +1,382 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package preview
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/magiconair/properties"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+var invalidVersionNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// newDataIndexClient creates the client counting the active instances of the draining versions, replaced by the tests
+var newDataIndexClient = services.NewDataIndexClient
+
+// versioningHandler deploys the versions of the flow side by side, as described by operatorapi.VersioningSpec.
+//
+// The workflow Deployment or Knative Service always runs the latest version. When the version changes, the running
+// objects of the previous version are copied under the name of the version, with copies of the properties ConfigMaps,
+// before the workflow objects are updated. The copies are removed once the Data Index reports the previous version
+// doesn't have any active instance anymore.
+type versioningHandler struct {
+	*common.StateSupport
+}
+
+func newVersioningHandler(support *common.StateSupport) *versioningHandler {
+	return &versioningHandler{StateSupport: support}
+}
+
+// retirePreviousVersion keeps the version run by the workflow Deployment or Knative Service running side by side if
+// it's not the version of the workflow anymore. Must be called before updating the workflow objects. Returns true when
+// the previous version has been retired, so the caller can persist the workflow status.
+func (v *versioningHandler) retirePreviousVersion(ctx context.Context, workflow *operatorapi.SonataFlow) (bool, error) {
+	version := workflow.Annotations[metadata.Version]
+	if !workflow.Spec.Versioning.IsEnabled() || len(version) == 0 {
+		return false, nil
+	}
+	var running client.Object = &appsv1.Deployment{}
+	if workflow.IsKnativeDeployment() {
+		running = &servingv1.Service{}
+	}
+	if err := v.C.Get(ctx, client.ObjectKeyFromObject(workflow), running); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	previous := running.GetAnnotations()[metadata.Version]
+	if len(previous) == 0 || previous == version {
+		return false, nil
+	}
+	if status := workflow.Status.GetVersion(previous); status != nil && status.Phase == operatorapi.WorkflowVersionPhaseDraining {
+		return false, nil
+	}
+
+	name := versionedName(workflow, previous)
+	objects, err := v.versionedConfigMaps(ctx, workflow, name)
+	if err != nil {
+		return false, err
+	}
+	var image string
+	if workflow.IsKnativeDeployment() {
+		ksvc := versionedKService(workflow, running.(*servingv1.Service), name)
+		image = getFlowContainerImage(&ksvc.Spec.Template.Spec.PodSpec)
+		objects = append(objects, ksvc)
+	} else {
+		deployment := versionedDeployment(workflow, running.(*appsv1.Deployment), name)
+		image = getFlowContainerImage(&deployment.Spec.Template.Spec)
+		objects = append(objects, deployment, versionedService(workflow, name))
+	}
+	for _, object := range objects {
+		if err = controllerutil.SetControllerReference(workflow, object, v.C.Scheme()); err != nil {
+			return false, err
+		}
+		if err = v.C.Create(ctx, object); err != nil && !errors.IsAlreadyExists(err) {
+			return false, err
+		}
+	}
+
+	workflow.Status.RemoveVersion(previous)
+	workflow.Status.Versions = append(workflow.Status.Versions, operatorapi.WorkflowVersionStatus{
+		Version:            previous,
+		Phase:              operatorapi.WorkflowVersionPhaseDraining,
+		Name:               name,
+		Image:              image,
+		Message:            "Waiting for the active instances to complete",
+		LastTransitionTime: metav1.Now(),
+	})
+	klog.V(log.I).InfoS("Workflow version draining", "workflow", workflow.Name, "version", previous, "name", name)
+	v.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.VersionDrainingReason,
+		"Version %s of workflow %s keeps running as %s until its active instances complete.", previous, workflow.Name, name)
+	return true, nil
+}
+
+// setLatestVersion reports the version of the workflow as the latest version, run by the given image.
+func (v *versioningHandler) setLatestVersion(ctx context.Context, workflow *operatorapi.SonataFlow, image string) error {
+	version := workflow.Annotations[metadata.Version]
+	if !workflow.Spec.Versioning.IsEnabled() || len(version) == 0 {
+		workflow.Status.Versions = nil
+		return nil
+	}
+	latest := workflow.Status.GetVersion(version)
+	if latest != nil && latest.Phase == operatorapi.WorkflowVersionPhaseDraining {
+		// a draining version deployed again doesn't need its copy anymore
+		if err := v.removeVersion(ctx, workflow, latest.Name); err != nil {
+			return err
+		}
+		latest = nil
+	}
+	if latest == nil {
+		versions := []operatorapi.WorkflowVersionStatus{{
+			Version:            version,
+			Phase:              operatorapi.WorkflowVersionPhaseLatest,
+			Name:               workflow.Name,
+			LastTransitionTime: metav1.Now(),
+		}}
+		// only the draining versions have their own objects, a former latest version not retired has been replaced
+		for _, status := range workflow.Status.Versions {
+			if status.Phase == operatorapi.WorkflowVersionPhaseDraining && status.Version != version {
+				versions = append(versions, status)
+			}
+		}
+		workflow.Status.Versions = versions
+		latest = &workflow.Status.Versions[0]
+	}
+	latest.Image = image
+	return nil
+}
+
+// drain removes the draining versions without active instances. Returns true if some versions are still draining.
+func (v *versioningHandler) drain(ctx context.Context, workflow *operatorapi.SonataFlow) (bool, error) {
+	draining := false
+	for _, version := range append([]operatorapi.WorkflowVersionStatus{}, workflow.Status.Versions...) {
+		if version.Phase != operatorapi.WorkflowVersionPhaseDraining {
+			continue
+		}
+		status := workflow.Status.GetVersion(version.Version)
+		if workflow.Status.Services == nil || workflow.Status.Services.DataIndexRef == nil || len(workflow.Status.Services.DataIndexRef.Url) == 0 {
+			status.Message = "The Data Index is not available to report the active instances, the version is kept"
+			draining = true
+			continue
+		}
+		active, err := newDataIndexClient(workflow.Status.Services.DataIndexRef.Url).CountActiveProcessInstances(ctx, workflow.Name, version.Version)
+		if err != nil {
+			klog.V(log.E).ErrorS(err, "Failed to count the active instances", "workflow", workflow.Name, "version", version.Version)
+			status.Message = fmt.Sprintf("Failed to count the active instances: %s", err.Error())
+			draining = true
+			continue
+		}
+		status.ActiveInstances = &active
+		if active > 0 {
+			status.Message = "Waiting for the active instances to complete"
+			draining = true
+			continue
+		}
+		if err = v.removeVersion(ctx, workflow, version.Name); err != nil {
+			return true, err
+		}
+		workflow.Status.RemoveVersion(version.Version)
+		klog.V(log.I).InfoS("Workflow version removed", "workflow", workflow.Name, "version", version.Version)
+		v.Recorder.Eventf(workflow, corev1.EventTypeNormal, api.VersionRemovedReason,
+			"Version %s of workflow %s doesn't have active instances anymore and has been removed.", version.Version, workflow.Name)
+	}
+	return draining, nil
+}
+
+func (v *versioningHandler) removeVersion(ctx context.Context, workflow *operatorapi.SonataFlow, name string) error {
+	objects := []client.Object{
+		&appsv1.Deployment{}, &corev1.Service{},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: versionedConfigMapName(name, workflowproj.GetWorkflowUserPropertiesConfigMapName(workflow), workflow)}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: versionedConfigMapName(name, workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow), workflow)}},
+	}
+	if workflow.IsKnativeDeployment() {
+		objects = append(objects, &servingv1.Service{})
+	}
+	for _, object := range objects {
+		if len(object.GetName()) == 0 {
+			object.SetName(name)
+		}
+		if err := v.C.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: object.GetName()}, object); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(object, workflow) {
+			continue
+		}
+		if err := v.C.Delete(ctx, object); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// versionedConfigMaps copies the properties ConfigMaps of the running version. The workflow Service runs the latest
+// version, so the service URL of the copies targets the Service of the version instead: the callbacks of the Jobs
+// Service and the links of the Data Index reach the pods running the instances of the version.
+func (v *versioningHandler) versionedConfigMaps(ctx context.Context, workflow *operatorapi.SonataFlow, name string) ([]client.Object, error) {
+	var objects []client.Object
+	for _, cmName := range []string{workflowproj.GetWorkflowUserPropertiesConfigMapName(workflow), workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow)} {
+		cm := &corev1.ConfigMap{}
+		if err := v.C.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: cmName}, cm); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		objects = append(objects, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      versionedConfigMapName(name, cmName, workflow),
+				Namespace: workflow.Namespace,
+				Labels:    versionedLabels(workflow, name),
+			},
+			Data: versionedProperties(workflow, cm.Data, name),
+		})
+	}
+	return objects, nil
+}
+
+// versionedProperties returns a copy of the properties files with the service URL of the workflow replaced by the URL
+// of the Service of the version. Service URLs set by the user to something else are kept.
+func versionedProperties(workflow *operatorapi.SonataFlow, data map[string]string, name string) map[string]string {
+	serviceURL := fmt.Sprintf("%s://%s.%s", constants.KogitoServiceURLProtocol, workflow.Name, workflow.Namespace)
+	versioned := make(map[string]string, len(data))
+	for key, value := range data {
+		versioned[key] = value
+		props, err := properties.LoadString(value)
+		if err != nil {
+			continue
+		}
+		if url, ok := props.Get(constants.KogitoServiceURLProperty); !ok || url != serviceURL {
+			continue
+		}
+		props.DisableExpansion = true
+		props.Set(constants.KogitoServiceURLProperty, fmt.Sprintf("%s://%s.%s", constants.KogitoServiceURLProtocol, name, workflow.Namespace))
+		versioned[key] = props.String()
+	}
+	return versioned
+}
+
+// versionedName returns the name of the objects running the given version of the workflow, a valid DNS label.
+func versionedName(workflow *operatorapi.SonataFlow, version string) string {
+	name := fmt.Sprintf("%s-%s", workflow.Name, strings.Trim(invalidVersionNameChars.ReplaceAllString(strings.ToLower(version), "-"), "-"))
+	if len(name) > 63 {
+		name = fmt.Sprintf("%s-%x", strings.TrimSuffix(workflow.Name[:min(len(workflow.Name), 54)], "-"), sha256.Sum256([]byte(version)))[:63]
+	}
+	return name
+}
+
+// versionedConfigMapName returns the name of the copy of the given properties ConfigMap, with the same suffix.
+func versionedConfigMapName(name, cmName string, workflow *operatorapi.SonataFlow) string {
+	return name + strings.TrimPrefix(cmName, workflow.Name)
+}
+
+// versionedLabels returns the labels of the objects of a previous version. The "app" label tells apart their pods
+// from the pods of the latest version, selected by the workflow Service.
+func versionedLabels(workflow *operatorapi.SonataFlow, name string) map[string]string {
+	labels := workflowproj.GetMergedLabels(workflow)
+	labels[workflowproj.LabelApp] = name
+	return labels
+}
+
+func versionedDeployment(workflow *operatorapi.SonataFlow, running *appsv1.Deployment, name string) *appsv1.Deployment {
+	template := running.Spec.Template.DeepCopy()
+	template.Labels = versionedLabels(workflow, name)
+	versionedPodSpec(workflow, &template.Spec, name)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   workflow.Namespace,
+			Labels:      versionedLabels(workflow, name),
+			Annotations: map[string]string{metadata.Version: running.Annotations[metadata.Version]},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: running.Spec.Replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: versionedLabels(workflow, name)},
+			Template: *template,
+		},
+	}
+}
+
+func versionedService(workflow *operatorapi.SonataFlow, name string) *corev1.Service {
+	// the copies are not selected by the workflow monitors, the platform monitoring doesn't apply to them
+	object, _ := common.ServiceCreator(workflow, nil)
+	service := object.(*corev1.Service)
+	service.Name = name
+	service.Labels = versionedLabels(workflow, name)
+	service.Spec.Selector = versionedLabels(workflow, name)
+	return service
+}
+
+func versionedKService(workflow *operatorapi.SonataFlow, running *servingv1.Service, name string) *servingv1.Service {
+	template := running.Spec.Template.DeepCopy()
+	template.Name = ""
+	template.Labels = versionedLabels(workflow, name)
+	versionedPodSpec(workflow, &template.Spec.PodSpec, name)
+	return &servingv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   workflow.Namespace,
+			Labels:      versionedLabels(workflow, name),
+			Annotations: map[string]string{metadata.Version: running.Annotations[metadata.Version]},
+		},
+		Spec: servingv1.ServiceSpec{
+			ConfigurationSpec: servingv1.ConfigurationSpec{Template: *template},
+		},
+	}
+}
+
+// versionedPodSpec mounts the copies of the properties ConfigMaps instead of the ones of the latest version.
+func versionedPodSpec(workflow *operatorapi.SonataFlow, podSpec *corev1.PodSpec, name string) {
+	cmNames := map[string]bool{
+		workflowproj.GetWorkflowUserPropertiesConfigMapName(workflow):    true,
+		workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow): true,
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil && cmNames[source.ConfigMap.Name] {
+				source.ConfigMap.Name = versionedConfigMapName(name, source.ConfigMap.Name, workflow)
+			}
+		}
+	}
+}
+
+// versionMutateVisitor records the version run by the workflow Deployment or Knative Service.
+func versionMutateVisitor(workflow *operatorapi.SonataFlow) common.MutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			version := workflow.Annotations[metadata.Version]
+			if !workflow.Spec.Versioning.IsEnabled() || len(version) == 0 {
+				return nil
+			}
+			annotations := object.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[metadata.Version] = version
+			object.SetAnnotations(annotations)
+			return nil
+		}
+	}
+}
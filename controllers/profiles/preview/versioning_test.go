@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package preview
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientruntime "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+type fakeDataIndexClient struct {
+	active  map[string]int32
+	nextJob *time.Time
+}
+
+func (f *fakeDataIndexClient) CountActiveProcessInstances(_ context.Context, _, version string) (int32, error) {
+	return f.active[version], nil
+}
+
+func (f *fakeDataIndexClient) NextScheduledJobTime(context.Context, string) (*time.Time, error) {
+	return f.nextJob, nil
+}
+
+func useFakeDataIndexClient(t *testing.T, client *fakeDataIndexClient) {
+	newDataIndexClient = func(string) services.DataIndexClient {
+		return client
+	}
+	t.Cleanup(func() { newDataIndexClient = services.NewDataIndexClient })
+}
+
+func versionedWorkflow(t *testing.T, version string) *operatorapi.SonataFlow {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.UID = "workflow-uid"
+	workflow.Annotations[metadata.Version] = version
+	workflow.Spec.Versioning = &operatorapi.VersioningSpec{Enabled: true}
+	workflow.Status.Services = &operatorapi.PlatformServicesStatus{
+		DataIndexRef: &operatorapi.PlatformServiceRefStatus{Url: "http://sonataflow-platform-data-index-service." + t.Name()},
+	}
+	return workflow
+}
+
+func runningVersionObjects(workflow *operatorapi.SonataFlow, version string) []clientruntime.Object {
+	managedCM := workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow)
+	return []clientruntime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        workflow.Name,
+				Namespace:   workflow.Namespace,
+				Annotations: map[string]string{metadata.Version: version},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: operatorapi.DefaultContainerName, Image: "quay.io/kiegroup/greeting:" + version}},
+						Volumes: []corev1.Volume{{
+							Name: "application-config",
+							VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+								Sources: []corev1.VolumeProjection{{ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: managedCM},
+								}}},
+							}},
+						}},
+					},
+				},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: managedCM, Namespace: workflow.Namespace},
+			Data: map[string]string{"application-prod.properties": "quarkus.http.port=8080\n" +
+				"kogito.service.url=http://" + workflow.Name + "." + workflow.Namespace + "\n"},
+		},
+	}
+}
+
+func Test_versioningHandler_RetirePreviousVersion(t *testing.T) {
+	workflow := versionedWorkflow(t, "2.0")
+	client := test.NewSonataFlowClientBuilder().
+		WithObjects(append(runningVersionObjects(workflow, "1.0"), workflow)...).
+		WithStatusSubresource(workflow).
+		Build()
+	handler := newVersioningHandler(fakeReconcilerSupport(client))
+
+	retired, err := handler.retirePreviousVersion(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.True(t, retired)
+	name := versionedName(workflow, "1.0")
+	assert.Equal(t, workflow.Name+"-1-0", name)
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: name}, deployment))
+	assert.True(t, metav1.IsControlledBy(deployment, workflow))
+	assert.Equal(t, "1.0", deployment.Annotations[metadata.Version])
+	assert.Equal(t, name, deployment.Spec.Selector.MatchLabels[workflowproj.LabelApp])
+	assert.Equal(t, name+strings.TrimPrefix(workflowproj.GetWorkflowManagedPropertiesConfigMapName(workflow), workflow.Name),
+		deployment.Spec.Template.Spec.Volumes[0].Projected.Sources[0].ConfigMap.Name)
+	service := &corev1.Service{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: name}, service))
+	assert.Equal(t, name, service.Spec.Selector[workflowproj.LabelApp])
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: deployment.Spec.Template.Spec.Volumes[0].Projected.Sources[0].ConfigMap.Name}, cm))
+	props, err := properties.LoadString(cm.Data["application-prod.properties"])
+	assert.NoError(t, err)
+	assert.Equal(t, "8080", props.GetString("quarkus.http.port", ""))
+	assert.Equal(t, "http://"+name+"."+workflow.Namespace, props.GetString("kogito.service.url", ""))
+
+	draining := workflow.Status.GetVersion("1.0")
+	assert.NotNil(t, draining)
+	assert.Equal(t, operatorapi.WorkflowVersionPhaseDraining, draining.Phase)
+	assert.Equal(t, name, draining.Name)
+	assert.Equal(t, "quay.io/kiegroup/greeting:1.0", draining.Image)
+
+	// a version already draining isn't retired twice
+	retired, err = handler.retirePreviousVersion(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, retired)
+
+	assert.NoError(t, handler.setLatestVersion(context.TODO(), workflow, "quay.io/kiegroup/greeting:2.0"))
+	assert.Len(t, workflow.Status.Versions, 2)
+	assert.Equal(t, "2.0", workflow.Status.Versions[0].Version)
+	assert.Equal(t, operatorapi.WorkflowVersionPhaseLatest, workflow.Status.Versions[0].Phase)
+	assert.Equal(t, workflow.Name, workflow.Status.Versions[0].Name)
+	assert.Equal(t, "quay.io/kiegroup/greeting:2.0", workflow.Status.Versions[0].Image)
+}
+
+func Test_versioningHandler_RetirePreviousVersionDisabled(t *testing.T) {
+	workflow := versionedWorkflow(t, "2.0")
+	workflow.Spec.Versioning = nil
+	client := test.NewSonataFlowClientBuilder().
+		WithObjects(append(runningVersionObjects(workflow, "1.0"), workflow)...).
+		Build()
+	handler := newVersioningHandler(fakeReconcilerSupport(client))
+
+	retired, err := handler.retirePreviousVersion(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, retired)
+	err = client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: versionedName(workflow, "1.0")}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, handler.setLatestVersion(context.TODO(), workflow, "quay.io/kiegroup/greeting:2.0"))
+	assert.Empty(t, workflow.Status.Versions)
+}
+
+func Test_versioningHandler_Drain(t *testing.T) {
+	workflow := versionedWorkflow(t, "2.0")
+	client := test.NewSonataFlowClientBuilder().
+		WithObjects(append(runningVersionObjects(workflow, "1.0"), workflow)...).
+		Build()
+	handler := newVersioningHandler(fakeReconcilerSupport(client))
+	_, err := handler.retirePreviousVersion(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.NoError(t, handler.setLatestVersion(context.TODO(), workflow, "quay.io/kiegroup/greeting:2.0"))
+	name := versionedName(workflow, "1.0")
+
+	active := map[string]int32{"1.0": 3}
+	useFakeDataIndexClient(t, &fakeDataIndexClient{active: active})
+	draining, err := handler.drain(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.True(t, draining)
+	assert.Equal(t, int32(3), *workflow.Status.GetVersion("1.0").ActiveInstances)
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: name}, &appsv1.Deployment{}))
+
+	active["1.0"] = 0
+	draining, err = handler.drain(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, draining)
+	assert.Nil(t, workflow.Status.GetVersion("1.0"))
+	assert.Len(t, workflow.Status.Versions, 1)
+	err = client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: name}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+	err = client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: name}, &corev1.Service{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func Test_versionedName(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	assert.Equal(t, workflow.Name+"-1-0-0-snapshot", versionedName(workflow, "1.0.0-SNAPSHOT"))
+	long := versionedName(workflow, strings.Repeat("1.", 40))
+	assert.Len(t, long, 63)
+	assert.True(t, strings.HasPrefix(long, workflow.Name+"-"))
+}
@@ -41,6 +41,7 @@ import (
 //
 // 3. reconciliationStateMachine: is a struct within the ProfileReconciler that do the actual reconciliation.
 // Each part of the reconciliation algorithm is a ReconciliationState that will be executed based on the ReconciliationState.CanReconcile call.
+// The machine only moves from the current state of the workflow to the states declared as its transitions, and records every transition in the workflow status.
 //
 // 4. ReconciliationState: is where your business code should be focused on. Each state should react to a specific operatorapi.SonataFlowConditionType.
 // The least conditions your state handles, the better.
@@ -65,6 +66,8 @@ type ProfileReconciler interface {
 
 // ReconciliationState is an interface implemented internally by different reconciliation algorithms to perform the adequate logic for a given workflow profile
 type ReconciliationState interface {
+	// Name of the state, as recorded in the workflow status transitions
+	Name() string
 	// CanReconcile checks if this state can perform its reconciliation task
 	CanReconcile(workflow *operatorapi.SonataFlow) bool
 	// Do perform the reconciliation task. It returns the controller result, the objects updated, and an error if any.
@@ -29,7 +29,9 @@ import (
 	profiles "github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/factory"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/rest"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -38,15 +40,22 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/discovery"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/metrics"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/properties"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/workflows"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
 )
 
 // SonataFlowReconciler reconciles a SonataFlow object
@@ -55,6 +64,8 @@ type SonataFlowReconciler struct {
 	Scheme   *runtime.Scheme
 	Config   *rest.Config
 	Recorder record.EventRecorder
+	// DiscoveryWatcher reconciles the workflows when the resources resolved by their service discovery change
+	DiscoveryWatcher *discovery.ResourceWatcher
 }
 
 //+kubebuilder:rbac:groups=sonataflow.org,resources=sonataflows,verbs=get;list;watch;create;update;patch;delete
@@ -84,23 +95,84 @@ func (r *SonataFlowReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	err := r.Client.Get(ctx, req.NamespacedName, workflow)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.untrackDiscoveredResources(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		klog.V(log.E).ErrorS(err, "Failed to get SonataFlow")
 		return ctrl.Result{}, err
 	}
 
-	r.setDefaults(workflow)
-
 	// Only process resources assigned to the operator
 	if !platform.IsOperatorHandlerConsideringLock(ctx, r.Client, req.Namespace, workflow) {
 		klog.V(log.I).InfoS("Ignoring request because resource is not assigned to current operator")
 		return reconcile.Result{}, nil
 	}
+
+	if !workflow.DeletionTimestamp.IsZero() {
+		r.untrackDiscoveredResources(req.NamespacedName)
+		return r.finalizeWorkflow(ctx, workflow)
+	}
+	if controllerutil.AddFinalizer(workflow, operatorapi.SonataFlowFinalizer) {
+		if err = r.Client.Update(ctx, workflow); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	r.setDefaults(workflow)
+	if err = r.trackDiscoveredResources(ctx, workflow); err != nil {
+		return ctrl.Result{}, err
+	}
 	return profiles.NewReconciler(r.Client, r.Config, r.Recorder, workflow).Reconcile(ctx, workflow)
 }
 
-// TODO: move to webhook see https://github.com/apache/incubator-kie-kogito-serverless-operator/pull/239
+// finalizeWorkflow cleans up the workflow resources that aren't garbage collected through owner references, then
+// releases the workflow deletion.
+func (r *SonataFlowReconciler) finalizeWorkflow(ctx context.Context, workflow *operatorapi.SonataFlow) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(workflow, operatorapi.SonataFlowFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	pl, err := platform.FindEffectivePlatform(ctx, r.Client, workflow.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err = workflows.Cleanup(ctx, r.Client, r.Recorder, workflow, pl); err != nil {
+		klog.V(log.E).ErrorS(err, "Failed to clean up the SonataFlow resources", "workflow", workflow.Name, "namespace", workflow.Namespace)
+		return ctrl.Result{}, err
+	}
+	metrics.DeleteWorkflowMetrics(workflow)
+	controllerutil.RemoveFinalizer(workflow, operatorapi.SonataFlowFinalizer)
+	return ctrl.Result{}, r.Client.Update(ctx, workflow)
+}
+
+// trackDiscoveredResources watches the resources the service discovery of the workflow depends on, and the Secrets and
+// ConfigMaps its properties are read from. A change in any of them reconciles the workflow again to refresh its managed
+// properties and roll the Deployment out.
+func (r *SonataFlowReconciler) trackDiscoveredResources(ctx context.Context, workflow *operatorapi.SonataFlow) error {
+	if r.DiscoveryWatcher == nil {
+		return nil
+	}
+	userProps := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflowproj.GetWorkflowUserPropertiesConfigMapName(workflow)}, userProps); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	uris := properties.GetDiscoveryResources(workflow, userProps.Data[workflowproj.ApplicationPropertiesFileName])
+	gateways, err := discovery.GetHTTPRouteParentGateways(ctx, r.Client, uris)
+	if err != nil {
+		return err
+	}
+	uris = append(uris, gateways...)
+	r.DiscoveryWatcher.Track(client.ObjectKeyFromObject(workflow), append(uris, properties.GetPropertiesReferences(workflow)...))
+	return nil
+}
+
+func (r *SonataFlowReconciler) untrackDiscoveredResources(workflow types.NamespacedName) {
+	if r.DiscoveryWatcher != nil {
+		r.DiscoveryWatcher.Untrack(workflow)
+	}
+}
+
+// setDefaults applies the defaults the profile reconcilers expect, without persisting them: the defaulting webhook is
+// optional and only sets the profile when empty.
 func (r *SonataFlowReconciler) setDefaults(workflow *operatorapi.SonataFlow) {
 	if workflow.Annotations == nil {
 		workflow.Annotations = map[string]string{}
@@ -180,10 +252,23 @@ func buildEnqueueRequestsFromMapFunc(c client.Client, b *operatorapi.SonataFlowB
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SonataFlowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.DiscoveryWatcher == nil {
+		watcher, err := discovery.NewResourceWatcherForConfig(mgr.GetConfig(), mgr.GetRESTMapper())
+		if err != nil {
+			return err
+		}
+		r.DiscoveryWatcher = watcher
+	}
+	if err := mgr.Add(r.DiscoveryWatcher); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&operatorapi.SonataFlow{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&operatorapi.SonataFlowBuild{}).
 		Watches(&operatorapi.SonataFlowPlatform{}, handler.EnqueueRequestsFromMapFunc(func(c context.Context, a client.Object) []reconcile.Request {
@@ -202,5 +287,6 @@ func (r *SonataFlowReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}
 			return buildEnqueueRequestsFromMapFunc(mgr.GetClient(), build)
 		})).
+		WatchesRawSource(&source.Channel{Source: r.DiscoveryWatcher.Events()}, &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -43,6 +43,8 @@ import (
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/builder"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/metrics"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
 )
 
@@ -96,6 +98,11 @@ func (r *SonataFlowBuildReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		if err = buildManager.Reconcile(build); err != nil {
 			return ctrl.Result{}, err
 		}
+		if build.Status.IsFinished() {
+			if err = r.completeBuildRecord(ctx, build); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 		if !reflect.DeepEqual(build.Status, beforeReconcileStatus) {
 			if err = r.manageStatusUpdate(ctx, build, beforeReconcileStatus.BuildPhase); err != nil {
 				return ctrl.Result{}, err
@@ -111,6 +118,17 @@ func (r *SonataFlowBuildReconciler) scheduleNewBuild(ctx context.Context, buildM
 	if err := buildManager.Schedule(build); err != nil {
 		return ctrl.Result{}, err
 	}
+	// the build manager might wait for an ongoing build, there's nothing to record until the new build is scheduled
+	if build.Status.BuildPhase != operatorapi.BuildPhaseNone {
+		if err := builder.StartBuildRecord(ctx, r.Client, build); err != nil {
+			return ctrl.Result{}, err
+		}
+		if build.Status.IsFinished() {
+			if err := r.completeBuildRecord(ctx, build); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
 	if err := r.manageStatusUpdate(ctx, build, ""); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -133,6 +151,19 @@ func (r *SonataFlowBuildReconciler) scheduleNewBuild(ctx context.Context, buildM
 	return ctrl.Result{RequeueAfter: requeueAfterForNewBuild}, nil
 }
 
+// completeBuildRecord adds the finished build to its history, as many records as the platform allows are kept.
+func (r *SonataFlowBuildReconciler) completeBuildRecord(ctx context.Context, build *operatorapi.SonataFlowBuild) error {
+	p, err := platform.GetEffectivePlatform(ctx, r.Client, build.Namespace)
+	if err != nil {
+		return err
+	}
+	if build.Status.CompletionTime == nil {
+		builder.CompleteBuildRecord(build, p.Spec.Build.Config.GetHistoryLimit())
+		metrics.ObserveBuildCompletion(build, p.Spec.Build.Config.BuildStrategy)
+	}
+	return nil
+}
+
 func (r *SonataFlowBuildReconciler) manageStatusUpdate(ctx context.Context, instance *operatorapi.SonataFlowBuild, beforeReconcilePhase operatorapi.BuildPhase) error {
 	err := r.Status().Update(ctx, instance)
 	// Don't need to spam events if the phase hasn't changed
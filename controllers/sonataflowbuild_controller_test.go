@@ -63,6 +63,9 @@ func TestSonataFlowBuildController(t *testing.T) {
 	assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksb))
 	assert.Equal(t, operatorapi.BuildPhaseScheduling, ksb.Status.BuildPhase)
 	assert.NotNil(t, ksb.Status.InnerBuild)
+	assert.Equal(t, int32(1), ksb.Status.BuildNumber)
+	assert.NotEmpty(t, ksb.Status.InputsHash)
+	assert.NotNil(t, ksb.Status.StartTime)
 
 	containerBuild := &api.ContainerBuild{}
 	assert.NoError(t, ksb.Status.GetInnerBuild(containerBuild))
@@ -35,6 +35,7 @@ import (
 	ctrlrun "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -78,6 +79,15 @@ func (r *SonataFlowClusterPlatformReconciler) Reconcile(ctx context.Context, req
 		return reconcile.Result{}, err
 	}
 
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.finalizeClusterPlatform(ctx, &instance)
+	}
+	if controllerutil.AddFinalizer(&instance, operatorapi.SonataFlowClusterPlatformFinalizer) {
+		if err = r.Client.Update(ctx, &instance); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	instance.Status.Manager().InitializeConditions()
 
 	cli, _ := clientr.FromCtrlClientSchemeAndConfig(r.Client, r.Scheme, r.Config)
@@ -123,6 +133,19 @@ func (r *SonataFlowClusterPlatformReconciler) Reconcile(ctx context.Context, req
 	return reconcile.Result{}, nil
 }
 
+// finalizeClusterPlatform removes the references to the cluster platform from the platforms status before releasing
+// the cluster platform deletion.
+func (r *SonataFlowClusterPlatformReconciler) finalizeClusterPlatform(ctx context.Context, instance *operatorapi.SonataFlowClusterPlatform) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(instance, operatorapi.SonataFlowClusterPlatformFinalizer) {
+		return reconcile.Result{}, nil
+	}
+	if err := clusterplatform.ClearPlatformReferences(ctx, r.Client, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(instance, operatorapi.SonataFlowClusterPlatformFinalizer)
+	return reconcile.Result{}, r.Client.Update(ctx, instance)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SonataFlowClusterPlatformReconciler) SetupWithManager(mgr ctrlrun.Manager) error {
 	return ctrlrun.NewControllerManagedBy(mgr).
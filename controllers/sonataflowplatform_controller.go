@@ -22,6 +22,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api"
@@ -33,6 +34,7 @@ import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,6 +44,7 @@ import (
 	ctrlrun "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -102,6 +105,16 @@ func (r *SonataFlowPlatformReconciler) Reconcile(ctx context.Context, req reconc
 		klog.V(log.I).InfoS("Ignoring request because resource is not assigned to current operator")
 		return reconcile.Result{}, nil
 	}
+
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.finalizePlatform(ctx, &instance)
+	}
+	if controllerutil.AddFinalizer(&instance, operatorapi.SonataFlowPlatformFinalizer) {
+		if err := r.Client.Update(ctx, &instance); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	actions := []platform.Action{
 		platform.NewInitializeAction(),
 		platform.NewServiceAction(),
@@ -170,6 +183,43 @@ func (r *SonataFlowPlatformReconciler) Reconcile(ctx context.Context, req reconc
 
 }
 
+// finalizePlatform holds the platform deletion while workflows still depend on it, then cleans up the platform
+// resources that aren't garbage collected through owner references. A SonataFlowClusterPlatform referencing the
+// platform is only reported, it must be pointed at another platform to share its services again.
+func (r *SonataFlowPlatformReconciler) finalizePlatform(ctx context.Context, instance *operatorapi.SonataFlowPlatform) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(instance, operatorapi.SonataFlowPlatformFinalizer) {
+		return reconcile.Result{}, nil
+	}
+	dependents, err := platform.GetPlatformDependents(ctx, r.Client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(dependents) > 0 {
+		message := fmt.Sprintf("Waiting for the deletion of the resources depending on the platform: %s", strings.Join(dependents, ", "))
+		target := instance.DeepCopy()
+		target.Status.Manager().MarkFalse(api.SucceedConditionType, operatorapi.PlatformDeletionBlockedReason, message)
+		if err = r.Client.Status().Patch(ctx, target, ctrl.MergeFrom(instance)); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "DeletionBlocked", message)
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	cPlatform, err := platform.GetReferencingClusterPlatform(ctx, r.Client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if cPlatform != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "ClusterPlatformReference",
+			fmt.Sprintf("Deleting the platform referenced by the %s %s, the other platforms don't inherit its services and configuration anymore",
+				operatorapi.SonataFlowClusterPlatformKind, cPlatform.Name))
+	}
+	if err = platform.CleanupPlatform(ctx, r.Client, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(instance, operatorapi.SonataFlowPlatformFinalizer)
+	return reconcile.Result{}, r.Client.Update(ctx, instance)
+}
+
 // If an active cluster platform exists, update platform.Status accordingly
 func (r *SonataFlowPlatformReconciler) SonataFlowPlatformUpdateStatus(ctx context.Context, req reconcile.Request, target *operatorapi.SonataFlowPlatform) error {
 	// Fetch the active SonataFlowClusterPlatform instance
@@ -181,6 +231,8 @@ func (r *SonataFlowPlatformReconciler) SonataFlowPlatformUpdateStatus(ctx contex
 
 	if sfcPlatform != nil {
 		sfPlatform := &operatorapi.SonataFlowPlatform{}
+		// the referenced platform shares its configuration with the others, it doesn't inherit from itself
+		var sharedPlatform *operatorapi.SonataFlowPlatform
 
 		platformRef := sfcPlatform.Spec.PlatformRef
 		namespacedName := types.NamespacedName{Namespace: platformRef.Namespace, Name: platformRef.Name}
@@ -192,6 +244,8 @@ func (r *SonataFlowPlatformReconciler) SonataFlowPlatformUpdateStatus(ctx contex
 			if err != nil && !errors.IsNotFound(err) {
 				klog.V(log.E).ErrorS(err, "Failed to get referenced SonataFlowPlatform", namespacedName)
 				return err
+			} else if err == nil {
+				sharedPlatform = sfPlatform
 			}
 		}
 
@@ -203,15 +257,23 @@ func (r *SonataFlowPlatformReconciler) SonataFlowPlatformUpdateStatus(ctx contex
 			},
 		}
 
-		if sfcPlatform.Spec.Capabilities != nil && contains(sfcPlatform.Spec.Capabilities.Workflows, clusterplatform.PlatformServices) {
+		capabilities := platform.GetInheritedCapabilities(sfcPlatform, target)
+		if contains(capabilities, clusterplatform.PlatformServices) {
 			tpsDI := services.NewDataIndexHandler(target)
 			tpsDI.SetServiceUrlInPlatformStatus(sfPlatform)
 
 			tpsJS := services.NewJobServiceHandler(target)
 			tpsJS.SetServiceUrlInPlatformStatus(sfPlatform)
 		}
+
+		target.Status.EffectiveConfig = platform.NewEffectiveConfig(target, sharedPlatform, capabilities)
+		if err := platform.CopySharedPersistenceSecrets(ctx, r.Client, target, platformRef.Namespace); err != nil {
+			klog.V(log.E).ErrorS(err, "Failed to copy the shared persistence secrets", "namespace", target.Namespace)
+			return err
+		}
 	} else {
 		target.Status.ClusterPlatformRef = nil
+		target.Status.EffectiveConfig = nil
 	}
 
 	return nil
@@ -222,8 +284,10 @@ func (r *SonataFlowPlatformReconciler) SetupWithManager(mgr ctrlrun.Manager) err
 	return ctrlrun.NewControllerManagedBy(mgr).
 		For(&operatorapi.SonataFlowPlatform{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(&operatorapi.SonataFlowPlatform{}, handler.EnqueueRequestsFromMapFunc(r.mapPlatformToPlatformRequests)).
 		Watches(&operatorapi.SonataFlowClusterPlatform{}, handler.EnqueueRequestsFromMapFunc(r.mapClusterPlatformToPlatformRequests)).
 		Complete(r)
@@ -22,15 +22,19 @@ package controllers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/clusterplatform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/platform/services"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/constants"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -827,4 +831,152 @@ func TestSonataFlowPlatformController(t *testing.T) {
 		assert.NotNil(t, ksp2.Status.ClusterPlatformRef)
 		assert.Nil(t, ksp2.Status.ClusterPlatformRef.Services)
 	})
+
+	t.Run("verify that the platform deletion waits for the workflows depending on it", func(t *testing.T) {
+		namespace := t.Name()
+		ksp := test.GetBasePlatformInReadyPhase(namespace)
+		ksp.Finalizers = []string{v1alpha08.SonataFlowPlatformFinalizer}
+		ksp.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		workflow := test.GetBaseSonataFlow(namespace)
+
+		cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(ksp, workflow).WithStatusSubresource(ksp).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &SonataFlowPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, recorder}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		result, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NotZero(t, result.RequeueAfter)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformDeletionBlockedReason, ksp.Status.GetTopLevelCondition().Reason)
+		assert.Contains(t, ksp.Status.GetTopLevelCondition().Message, v1alpha08.SonataFlowKind+"/"+workflow.Name)
+		assert.Len(t, recorder.Events, 1)
+
+		assert.NoError(t, cl.Delete(context.TODO(), workflow))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), req.NamespacedName, ksp)))
+	})
+
+	t.Run("verify that the platform referenced by the cluster platform can be deleted", func(t *testing.T) {
+		namespace := t.Name()
+		ksp := test.GetBasePlatformInReadyPhase(namespace)
+		ksp.Finalizers = []string{v1alpha08.SonataFlowPlatformFinalizer}
+		ksp.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		kscp := test.GetBaseClusterPlatformInReadyPhase(namespace)
+
+		cl := test.NewSonataFlowClientBuilder().WithRuntimeObjects(ksp, kscp).WithStatusSubresource(ksp, kscp).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &SonataFlowPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, recorder}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), req.NamespacedName, ksp)))
+		assert.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "ClusterPlatformReference")
+	})
+
+	t.Run("verify that the platforms inherit the configuration shared by the cluster platform", func(t *testing.T) {
+		sharedNamespace := t.Name() + "-shared"
+		namespace := t.Name()
+
+		shared := test.GetBasePlatformInReadyPhase(sharedNamespace)
+		shared.Spec.Build.Config.BaseImage = "quay.io/shared/builder:latest"
+		shared.Spec.Properties.Flow = []v1alpha08.PropertyVar{
+			{Name: "quarkus.log.level", Value: "DEBUG"},
+			{Name: "shared.token", ValueFrom: &v1alpha08.PropertyVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "shared-props"}, Key: "token"},
+			}},
+		}
+		shared.Spec.Persistence = &v1alpha08.PlatformPersistenceOptionsSpec{
+			PostgreSQL: &v1alpha08.PlatformPersistencePostgreSQL{
+				SecretRef:  v1alpha08.PostgreSQLSecretOptions{Name: "shared-db"},
+				ServiceRef: &v1alpha08.SQLServiceOptions{Name: "postgres"},
+			},
+		}
+		dbSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-db", Namespace: sharedNamespace},
+			Data:       map[string][]byte{"POSTGRESQL_PASSWORD": []byte("secret")},
+		}
+		kscp := test.GetBaseClusterPlatformInReadyPhase(sharedNamespace)
+		kscp.Spec.Capabilities = &v1alpha08.SonataFlowClusterPlatformCapSpec{
+			Workflows: []v1alpha08.WorkFlowCapability{clusterplatform.PlatformBuild, clusterplatform.PlatformProperties, clusterplatform.PlatformPersistence},
+		}
+
+		// the local platform overrides the log level, and leaves the registry to the shared platform
+		ksp := test.GetBasePlatformInReadyPhase(namespace)
+		ksp.Spec.Build.Config.Registry = v1alpha08.RegistrySpec{}
+
+		cl := test.NewSonataFlowClientBuilder().
+			WithRuntimeObjects(kscp, shared, ksp, dbSecret).
+			WithStatusSubresource(kscp, shared, ksp).
+			Build()
+		r := &SonataFlowPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		cr := &SonataFlowClusterPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+
+		_, err := cr.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: kscp.Name}})
+		assert.NoError(t, err)
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+
+		// nothing is inherited without opting in
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Empty(t, ksp.Status.EffectiveConfig.InheritedFields)
+		assert.Nil(t, ksp.Status.EffectiveConfig.Persistence)
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), types.NamespacedName{Name: "shared-db", Namespace: namespace}, &corev1.Secret{})))
+
+		ksp.Spec.ClusterCapabilities = &v1alpha08.SonataFlowClusterPlatformCapSpec{
+			Workflows: []v1alpha08.WorkFlowCapability{clusterplatform.PlatformBuild, clusterplatform.PlatformProperties, clusterplatform.PlatformPersistence},
+		}
+		assert.NoError(t, cl.Update(context.TODO(), ksp))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		effective := ksp.Status.EffectiveConfig
+		assert.NotNil(t, effective)
+		assert.ElementsMatch(t, []string{
+			"build.config.baseImage",
+			"build.config.registry",
+			v1alpha08.InheritedPropertyField("shared.token"),
+			"persistence",
+		}, effective.InheritedFields)
+		assert.Equal(t, "quay.io/kiegroup", effective.Build.Config.Registry.Address)
+		assert.Equal(t, "quay.io/shared/builder:latest", effective.Build.Config.BaseImage)
+		assert.Len(t, effective.Properties.Flow, 2)
+		assert.Equal(t, "INFO", effective.Properties.Flow[0].Value)
+		assert.Equal(t, sharedNamespace, effective.Persistence.PostgreSQL.ServiceRef.Namespace)
+		// the inherited fields are never written to the platform spec
+		assert.Empty(t, ksp.Spec.Build.Config.Registry.Address)
+		assert.Nil(t, ksp.Spec.Persistence)
+
+		copied := &corev1.Secret{}
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "shared-db", Namespace: namespace}, copied))
+		assert.Equal(t, dbSecret.Data, copied.Data)
+
+		effectivePlatform, err := platform.GetEffectivePlatform(context.TODO(), cl, namespace)
+		assert.NoError(t, err)
+		assert.Equal(t, "quay.io/kiegroup", effectivePlatform.Spec.Build.Config.Registry.Address)
+		assert.NotNil(t, effectivePlatform.Spec.Persistence)
+		// the active platform is the one stored in the cluster, safe to update
+		active, err := platform.GetActivePlatform(context.TODO(), cl, namespace)
+		assert.NoError(t, err)
+		assert.Empty(t, active.Spec.Build.Config.Registry.Address)
+		assert.Nil(t, active.Spec.Persistence)
+
+		// opting out of the shared build configuration and persistence
+		ksp.Spec.ClusterCapabilities = &v1alpha08.SonataFlowClusterPlatformCapSpec{
+			Workflows: []v1alpha08.WorkFlowCapability{clusterplatform.PlatformProperties},
+		}
+		assert.NoError(t, cl.Update(context.TODO(), ksp))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, []string{v1alpha08.InheritedPropertyField("shared.token")}, ksp.Status.EffectiveConfig.InheritedFields)
+		assert.Empty(t, ksp.Status.EffectiveConfig.Build.Config.BaseImage)
+		assert.Nil(t, ksp.Status.EffectiveConfig.Persistence)
+	})
 }
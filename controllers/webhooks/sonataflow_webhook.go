@@ -0,0 +1,333 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cncfmodel "github.com/serverlessworkflow/sdk-go/v2/model"
+	cncfvalidator "github.com/serverlessworkflow/sdk-go/v2/validator"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/knative"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/profiles/common/properties"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+)
+
+//+kubebuilder:webhook:path=/mutate-sonataflow-org-v1alpha08-sonataflow,mutating=true,failurePolicy=fail,sideEffects=None,groups=sonataflow.org,resources=sonataflows,verbs=create;update,versions=v1alpha08,name=msonataflow.sonataflow.org,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-sonataflow-org-v1alpha08-sonataflow,mutating=false,failurePolicy=fail,sideEffects=None,groups=sonataflow.org,resources=sonataflows,verbs=create;update,versions=v1alpha08,name=vsonataflow.sonataflow.org,admissionReviewVersions=v1
+
+var profilePath = field.NewPath("metadata", "annotations").Key(metadata.Profile)
+
+var _ admission.CustomDefaulter = &sonataFlowDefaulter{}
+
+type sonataFlowDefaulter struct{}
+
+func (d *sonataFlowDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	workflow, ok := obj.(*operatorapi.SonataFlow)
+	if !ok {
+		return fmt.Errorf("expected a SonataFlow object, got %T", obj)
+	}
+	SetSonataFlowDefaults(workflow)
+	return nil
+}
+
+// SetSonataFlowDefaults sets the profile to metadata.DefaultProfile when empty. Any other profile is kept as given:
+// the profile factory picks the reconciler of deprecated profiles and of preview workflows with a container image on
+// every reconciliation, and the validating webhook rejects the unsupported ones.
+func SetSonataFlowDefaults(workflow *operatorapi.SonataFlow) {
+	if workflow.Annotations == nil {
+		workflow.Annotations = map[string]string{}
+	}
+	if len(workflow.Annotations[metadata.Profile]) == 0 {
+		workflow.Annotations[metadata.Profile] = metadata.DefaultProfile.String()
+	}
+}
+
+var _ admission.CustomValidator = &sonataFlowValidator{}
+
+type sonataFlowValidator struct {
+	client client.Client
+	// knativeAvailability is used to verify if the knative deployment model can be honored in the current cluster
+	knativeAvailability func() (*knative.Availability, error)
+}
+
+func newSonataFlowValidator(cli client.Client, cfg *rest.Config) *sonataFlowValidator {
+	return &sonataFlowValidator{
+		client: cli,
+		knativeAvailability: func() (*knative.Availability, error) {
+			return knative.GetKnativeAvailability(cfg)
+		},
+	}
+}
+
+func (v *sonataFlowValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj, true)
+}
+
+// ValidateUpdate skips the workflows being deleted, so that removing their finalizers never depends on the cluster
+// state. The referenced ConfigMaps and Knative Serving are only verified again when the spec changes, since they can
+// be removed before the workflow, for example when its namespace is deleted.
+func (v *sonataFlowValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	workflow, ok := newObj.(*operatorapi.SonataFlow)
+	if !ok {
+		return nil, fmt.Errorf("expected a SonataFlow object, got %T", newObj)
+	}
+	if workflow.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	oldWorkflow, ok := oldObj.(*operatorapi.SonataFlow)
+	specChanged := !ok || oldWorkflow == nil || !equality.Semantic.DeepEqual(oldWorkflow.Spec, workflow.Spec)
+	return v.validate(ctx, workflow, specChanged)
+}
+
+func (v *sonataFlowValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate runs every check of the workflow, the ones depending on the cluster state only when clusterChecks is set.
+func (v *sonataFlowValidator) validate(ctx context.Context, obj runtime.Object, clusterChecks bool) (admission.Warnings, error) {
+	workflow, ok := obj.(*operatorapi.SonataFlow)
+	if !ok {
+		return nil, fmt.Errorf("expected a SonataFlow object, got %T", obj)
+	}
+	warnings, errs := v.validateProfile(workflow, clusterChecks)
+	errs = append(errs, validateFlow(ctx, workflow)...)
+	errs = append(errs, validatePersistence(field.NewPath("spec", "persistence"), workflow.Spec.Persistence)...)
+	rolloutWarnings, rolloutErrs := validateRollout(workflow)
+	warnings = append(warnings, rolloutWarnings...)
+	errs = append(errs, rolloutErrs...)
+	autoscalingWarnings, autoscalingErrs := validateAutoscaling(workflow)
+	warnings = append(warnings, autoscalingWarnings...)
+	errs = append(errs, autoscalingErrs...)
+	errs = append(errs, validateExposure(workflow)...)
+	errs = append(errs, validateProperties(workflow)...)
+	if clusterChecks {
+		resourcesErrs, err := v.validateResources(ctx, workflow)
+		if err != nil {
+			return warnings, err
+		}
+		errs = append(errs, resourcesErrs...)
+	}
+	return warnings, toInvalidError(operatorapi.SonataFlowKind, workflow.Name, errs)
+}
+
+// validateProfile enforces the profile and deployment model combinations supported by the profile reconcilers.
+// Knative Serving is only looked up when knativeCheck is set.
+func (v *sonataFlowValidator) validateProfile(workflow *operatorapi.SonataFlow, knativeCheck bool) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	profile := metadata.ProfileType(workflow.Annotations[metadata.Profile])
+	switch profile {
+	case "", metadata.PreviewProfile, metadata.GitOpsProfile, metadata.DevProfile:
+	case metadata.ProdProfile:
+		warnings = append(warnings, fmt.Sprintf("Profile %s is deprecated, please use '%s' instead.", metadata.ProdProfile, metadata.PreviewProfile))
+	default:
+		errs = append(errs, field.NotSupported(profilePath, profile,
+			[]string{metadata.PreviewProfile.String(), metadata.DevProfile.String(), metadata.GitOpsProfile.String()}))
+	}
+
+	deploymentModelPath := field.NewPath("spec", "podTemplate", "deploymentModel")
+	if profile == metadata.DevProfile && workflow.IsKnativeDeployment() {
+		errs = append(errs, field.Invalid(deploymentModelPath, workflow.Spec.PodTemplate.DeploymentModel,
+			fmt.Sprintf("the %s profile only supports the %s deployment model", metadata.DevProfile, operatorapi.KubernetesDeploymentModel)))
+	}
+	if knativeCheck && workflow.IsKnativeDeployment() && v.knativeAvailability != nil {
+		if avail, err := v.knativeAvailability(); err != nil {
+			klog.V(log.E).ErrorS(err, "Unable to determine if knative is installed in the cluster")
+			warnings = append(warnings, "unable to verify if Knative Serving is installed in the cluster")
+		} else if !avail.Serving {
+			errs = append(errs, field.Invalid(deploymentModelPath, workflow.Spec.PodTemplate.DeploymentModel,
+				"Knative Serving is not installed in the cluster"))
+		}
+	}
+	return warnings, errs
+}
+
+// validateRollout checks the rollout options against the strategy type. Progressive rollouts are only driven by the
+// preview profile, since it's the one building the images.
+func validateRollout(workflow *operatorapi.SonataFlow) (admission.Warnings, field.ErrorList) {
+	rollout := workflow.Spec.Rollout
+	if rollout == nil {
+		return nil, nil
+	}
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	if rollout.Canary != nil && rollout.GetType() != operatorapi.CanaryRolloutStrategy {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "rollout", "canary"),
+			fmt.Sprintf("may only be set when the rollout type is %s", operatorapi.CanaryRolloutStrategy)))
+	}
+	profile := metadata.ProfileType(workflow.Annotations[metadata.Profile])
+	if rollout.GetType() != operatorapi.ReplaceRolloutStrategy && profile != metadata.PreviewProfile && profile != metadata.ProdProfile {
+		warnings = append(warnings, fmt.Sprintf("Rollout strategy %s is only applied to workflows with the %s profile", rollout.GetType(), metadata.PreviewProfile))
+	}
+	return warnings, errs
+}
+
+// validateAutoscaling checks the autoscaling limits, and warns about the settings the deployment model can't honor.
+func validateAutoscaling(workflow *operatorapi.SonataFlow) (admission.Warnings, field.ErrorList) {
+	autoscaling := workflow.Spec.PodTemplate.Autoscaling
+	if autoscaling == nil {
+		return nil, nil
+	}
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	path := field.NewPath("spec", "podTemplate", "autoscaling")
+	if autoscaling.GetMinReplicas() > autoscaling.MaxReplicas {
+		errs = append(errs, field.Invalid(path.Child("minReplicas"), autoscaling.GetMinReplicas(), "must be less than or equal to maxReplicas"))
+	}
+	if autoscaling.GetMinReplicas() == 0 && !workflow.IsKnativeDeployment() {
+		errs = append(errs, field.Invalid(path.Child("minReplicas"), 0,
+			fmt.Sprintf("scale to zero is only supported by the %s deployment model", operatorapi.KnativeDeploymentModel)))
+	}
+	for i, metric := range autoscaling.Metrics {
+		if len(metric.Name) == 0 {
+			errs = append(errs, field.Required(path.Child("metrics").Index(i).Child("name"), ""))
+		}
+	}
+	if metadata.ProfileType(workflow.Annotations[metadata.Profile]) == metadata.DevProfile {
+		warnings = append(warnings, fmt.Sprintf("Autoscaling is ignored by the %s profile", metadata.DevProfile))
+	}
+	if workflow.Spec.PodTemplate.Replicas != nil {
+		warnings = append(warnings, "spec.podTemplate.replicas is ignored when autoscaling is configured")
+	}
+	if workflow.IsKnativeDeployment() {
+		targets := len(autoscaling.Metrics)
+		if autoscaling.TargetCPUUtilizationPercentage != nil {
+			targets++
+		}
+		if autoscaling.TargetMemoryUtilizationPercentage != nil {
+			targets++
+			if _, ok := workflow.Spec.PodTemplate.Container.Resources.Requests[corev1.ResourceMemory]; !ok && targets == 1 {
+				warnings = append(warnings, "the memory autoscaling target is ignored, the workflow container has no memory request")
+			}
+		}
+		if targets > 1 {
+			warnings = append(warnings, "Knative Serving scales on a single metric, only the first custom metric, or the CPU target, is used")
+		}
+	}
+	return warnings, errs
+}
+
+// validateExposure checks that the exposure settings match the exposure type.
+func validateExposure(workflow *operatorapi.SonataFlow) field.ErrorList {
+	exposure := workflow.Spec.Exposure
+	if exposure == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	path := field.NewPath("spec", "exposure")
+	if workflow.IsKnativeDeployment() {
+		errs = append(errs, field.Forbidden(path,
+			fmt.Sprintf("the %s deployment model is exposed by Knative Serving", operatorapi.KnativeDeploymentModel)))
+	}
+	if exposure.GetType() == operatorapi.HTTPRouteExposureType {
+		if exposure.Gateway == nil {
+			errs = append(errs, field.Required(path.Child("gateway"), "the Gateway the HTTPRoute is attached to is required"))
+		}
+		if len(exposure.TLSSecretName) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("tlsSecretName"), "TLS is terminated by the Gateway listeners"))
+		}
+		if exposure.IngressClassName != nil {
+			errs = append(errs, field.Forbidden(path.Child("ingressClassName"), "only supported by the ingress type"))
+		}
+	} else if exposure.Gateway != nil {
+		errs = append(errs, field.Forbidden(path.Child("gateway"), "only supported by the httpRoute type"))
+	}
+	return errs
+}
+
+// validateProperties applies the platform properties rules to the workflow properties. The Secret-backed properties
+// delivered as environment variables must also map to distinct variable names.
+func validateProperties(workflow *operatorapi.SonataFlow) field.ErrorList {
+	if workflow.Spec.Properties == nil {
+		return nil
+	}
+	path := field.NewPath("spec", "properties", "flow")
+	errs := validatePropertyVars(path, workflow.Spec.Properties.Flow)
+	if workflow.Spec.Properties.GetSecretsDelivery() != operatorapi.EnvSecretPropertiesDelivery {
+		return errs
+	}
+	envVarNames := map[string]string{}
+	for i, propVar := range workflow.Spec.Properties.Flow {
+		if len(propVar.Value) > 0 || propVar.ValueFrom == nil || propVar.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		envVarName := properties.GetPropertyEnvVarName(propVar.Name)
+		if other, ok := envVarNames[envVarName]; ok && other != propVar.Name {
+			errs = append(errs, field.Invalid(path.Index(i).Child("name"), propVar.Name,
+				fmt.Sprintf("maps to the same environment variable %s as the property %s", envVarName, other)))
+		}
+		envVarNames[envVarName] = propVar.Name
+	}
+	return errs
+}
+
+// validateFlow runs the CNCF Serverless Workflow validator over the workflow definition, the same validation a
+// workflow project build runs when parsing the definition file.
+func validateFlow(ctx context.Context, workflow *operatorapi.SonataFlow) field.ErrorList {
+	flowPath := field.NewPath("spec", "flow")
+	cncfWorkflow, err := operatorapi.ToCNCFWorkflow(workflow, ctx)
+	if err != nil {
+		return field.ErrorList{field.Invalid(flowPath, field.OmitValueType{}, err.Error())}
+	}
+	if err = cncfvalidator.GetValidator().StructCtx(cncfmodel.NewValidatorContext(cncfWorkflow), cncfWorkflow); err == nil {
+		return nil
+	}
+	var workflowErrs cncfvalidator.WorkflowErrors
+	if !errors.As(cncfvalidator.WorkflowError(err), &workflowErrs) {
+		return field.ErrorList{field.Invalid(flowPath, field.OmitValueType{}, err.Error())}
+	}
+	errs := field.ErrorList{}
+	for _, workflowErr := range workflowErrs {
+		errs = append(errs, field.Invalid(flowPath, field.OmitValueType{}, workflowErr.Error()))
+	}
+	return errs
+}
+
+// validateResources verifies that every ConfigMap referenced in .spec.resources exists in the workflow's namespace.
+func (v *sonataFlowValidator) validateResources(ctx context.Context, workflow *operatorapi.SonataFlow) (field.ErrorList, error) {
+	var errs field.ErrorList
+	configMapsPath := field.NewPath("spec", "resources", "configMaps")
+	for i, res := range workflow.Spec.Resources.ConfigMaps {
+		cm := &corev1.ConfigMap{}
+		if err := v.client.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: res.ConfigMap.Name}, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, field.NotFound(configMapsPath.Index(i).Child("configMap", "name"), res.ConfigMap.Name))
+				continue
+			}
+			return nil, err
+		}
+	}
+	return errs, nil
+}
@@ -0,0 +1,308 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/knative"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func newTestSonataFlowValidator(serving bool, objs ...corev1.ConfigMap) *sonataFlowValidator {
+	builder := test.NewSonataFlowClientBuilder()
+	for i := range objs {
+		builder.WithObjects(&objs[i])
+	}
+	return &sonataFlowValidator{
+		client: builder.Build(),
+		knativeAvailability: func() (*knative.Availability, error) {
+			return &knative.Availability{Serving: serving}, nil
+		},
+	}
+}
+
+func TestSetSonataFlowDefaults(t *testing.T) {
+	t.Run("empty profile defaults to preview", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		SetSonataFlowDefaults(workflow)
+		assert.Equal(t, metadata.PreviewProfile.String(), workflow.Annotations[metadata.Profile])
+	})
+	t.Run("prod profile is kept", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		workflow.Annotations[metadata.Profile] = metadata.ProdProfile.String()
+		SetSonataFlowDefaults(workflow)
+		assert.Equal(t, metadata.ProdProfile.String(), workflow.Annotations[metadata.Profile])
+	})
+	t.Run("preview profile with container image is kept", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		workflow.Spec.PodTemplate.Container.Image = "quay.io/org/greeting:latest"
+		SetSonataFlowDefaults(workflow)
+		assert.Equal(t, metadata.PreviewProfile.String(), workflow.Annotations[metadata.Profile])
+	})
+	t.Run("deployment model of the dev profile is kept", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
+		workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+		SetSonataFlowDefaults(workflow)
+		assert.Equal(t, operatorapi.KnativeDeploymentModel, workflow.Spec.PodTemplate.DeploymentModel)
+	})
+	t.Run("unsupported profile is kept to be rejected", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		workflow.Annotations[metadata.Profile] = "IDontExist"
+		SetSonataFlowDefaults(workflow)
+		assert.Equal(t, "IDontExist", workflow.Annotations[metadata.Profile])
+	})
+}
+
+func TestSonataFlowValidator_ValidWorkflow(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	SetSonataFlowDefaults(workflow)
+	warnings, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestSonataFlowValidator_DeprecatedProfileWarning(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Annotations[metadata.Profile] = metadata.ProdProfile.String()
+	warnings, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+}
+
+func TestSonataFlowValidator_UnsupportedProfile(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Annotations[metadata.Profile] = "IDontExist"
+	_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), metadata.Profile)
+}
+
+func TestSonataFlowValidator_InvalidFlow(t *testing.T) {
+	t.Run("transition to missing state", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		workflow.Spec.Flow.States[1].Transition.NextState = "IDontExist"
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "IDontExist")
+	})
+	t.Run("reference to undeclared function", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		workflow.Spec.Flow.Functions = nil
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "greetFunction")
+	})
+	t.Run("no states", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		workflow.Spec.Flow.States = nil
+		_, err := newTestSonataFlowValidator(false).ValidateUpdate(context.TODO(), nil, workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+	})
+}
+
+func TestSonataFlowValidator_ResourcesConfigMaps(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.Resources.ConfigMaps = []operatorapi.ConfigMapWorkflowResource{
+		{ConfigMap: corev1.LocalObjectReference{Name: "specs"}, WorkflowPath: "specs"},
+	}
+
+	_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.resources.configMaps[0].configMap.name")
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "specs", Namespace: workflow.Namespace}}
+	_, err = newTestSonataFlowValidator(false, cm).ValidateCreate(context.TODO(), workflow)
+	assert.NoError(t, err)
+}
+
+func TestSonataFlowValidator_UpdateWithoutClusterDependencies(t *testing.T) {
+	workflow := test.GetBaseSonataFlow(t.Name())
+	test.SetPreviewProfile(workflow)
+	workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+	workflow.Spec.Resources.ConfigMaps = []operatorapi.ConfigMapWorkflowResource{
+		{ConfigMap: corev1.LocalObjectReference{Name: "specs"}, WorkflowPath: "specs"},
+	}
+
+	t.Run("finalizer removal of a deleted workflow", func(t *testing.T) {
+		deleted := workflow.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		deleted.Finalizers = nil
+		_, err := newTestSonataFlowValidator(false).ValidateUpdate(context.TODO(), workflow, deleted)
+		assert.NoError(t, err)
+	})
+	t.Run("metadata change without spec change", func(t *testing.T) {
+		updated := workflow.DeepCopy()
+		updated.Labels = map[string]string{"app": "greeting"}
+		_, err := newTestSonataFlowValidator(false).ValidateUpdate(context.TODO(), workflow, updated)
+		assert.NoError(t, err)
+	})
+	t.Run("spec change", func(t *testing.T) {
+		updated := workflow.DeepCopy()
+		updated.Spec.PodTemplate.Container.Image = "quay.io/org/greeting:1.1"
+		_, err := newTestSonataFlowValidator(false).ValidateUpdate(context.TODO(), workflow, updated)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.resources.configMaps[0].configMap.name")
+		assert.Contains(t, err.Error(), "Knative Serving is not installed")
+	})
+}
+
+func TestSonataFlowValidator_DeploymentModel(t *testing.T) {
+	t.Run("knative deployment model in dev profile", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
+		workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+		_, err := newTestSonataFlowValidator(true).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.podTemplate.deploymentModel")
+	})
+	t.Run("knative deployment model without knative serving", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+
+		_, err = newTestSonataFlowValidator(true).ValidateCreate(context.TODO(), workflow)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSonataFlowValidator_Rollout(t *testing.T) {
+	t.Run("canary options with blue/green rollout", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		weight := int32(20)
+		workflow.Spec.Rollout = &operatorapi.RolloutStrategy{
+			Type:   operatorapi.BlueGreenRolloutStrategy,
+			Canary: &operatorapi.CanaryRolloutOptions{Weight: &weight},
+		}
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.rollout.canary")
+
+		workflow.Spec.Rollout.Type = operatorapi.CanaryRolloutStrategy
+		warnings, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("progressive rollout with dev profile", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlowWithDevProfile(t.Name())
+		workflow.Spec.Rollout = &operatorapi.RolloutStrategy{Type: operatorapi.BlueGreenRolloutStrategy}
+		warnings, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+	})
+}
+
+func TestSonataFlowValidator_Autoscaling(t *testing.T) {
+	t.Run("min replicas greater than max replicas", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		minReplicas := int32(4)
+		workflow.Spec.PodTemplate.Autoscaling = &operatorapi.AutoscalingSpec{MinReplicas: &minReplicas, MaxReplicas: 2}
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.podTemplate.autoscaling.minReplicas")
+	})
+	t.Run("scale to zero", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		minReplicas := int32(0)
+		workflow.Spec.PodTemplate.Autoscaling = &operatorapi.AutoscalingSpec{MinReplicas: &minReplicas, MaxReplicas: 2}
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+
+		workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+		warnings, err := newTestSonataFlowValidator(true).ValidateCreate(context.TODO(), workflow)
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("replicas with autoscaling", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		replicas := int32(3)
+		workflow.Spec.PodTemplate.Replicas = &replicas
+		workflow.Spec.PodTemplate.Autoscaling = &operatorapi.AutoscalingSpec{MaxReplicas: 5}
+		warnings, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+	})
+}
+
+func TestSonataFlowValidator_Exposure(t *testing.T) {
+	t.Run("httpRoute without gateway", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		workflow.Spec.Exposure = &operatorapi.ExposureSpec{Type: operatorapi.HTTPRouteExposureType, TLSSecretName: "tls"}
+		_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.exposure.gateway")
+		assert.Contains(t, err.Error(), "spec.exposure.tlsSecretName")
+
+		workflow.Spec.Exposure = &operatorapi.ExposureSpec{Type: operatorapi.HTTPRouteExposureType, Gateway: &operatorapi.GatewayReference{Name: "external"}}
+		_, err = newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+		assert.NoError(t, err)
+	})
+	t.Run("exposure with knative deployment model", func(t *testing.T) {
+		workflow := test.GetBaseSonataFlow(t.Name())
+		test.SetPreviewProfile(workflow)
+		workflow.Spec.PodTemplate.DeploymentModel = operatorapi.KnativeDeploymentModel
+		workflow.Spec.Exposure = &operatorapi.ExposureSpec{Host: "greeting.example.com"}
+		_, err := newTestSonataFlowValidator(true).ValidateCreate(context.TODO(), workflow)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.exposure")
+	})
+}
+
+func TestSonataFlowValidator_Properties(t *testing.T) {
+	secretRef := func(key string) *operatorapi.PropertyVarSource {
+		return &operatorapi.PropertyVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: key}}
+	}
+	workflow := test.GetBaseSonataFlow(t.Name())
+	workflow.Spec.Properties = &operatorapi.PropertyFlowSpec{
+		Flow: []operatorapi.PropertyVar{
+			{Name: "my.api-key", ValueFrom: secretRef("key")},
+			{Name: "my.api.key", ValueFrom: secretRef("other")},
+			{Name: "my.literal", Value: "value", ValueFrom: secretRef("key")},
+		},
+	}
+	_, err := newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.properties.flow[1].name")
+	assert.Contains(t, err.Error(), "MY_API_KEY")
+	assert.Contains(t, err.Error(), "spec.properties.flow[2].valueFrom")
+
+	// files are named after the properties, no clash
+	workflow.Spec.Properties.Flow = workflow.Spec.Properties.Flow[:2]
+	workflow.Spec.Properties.SecretsDelivery = operatorapi.FileSecretPropertiesDelivery
+	_, err = newTestSonataFlowValidator(false).ValidateCreate(context.TODO(), workflow)
+	assert.NoError(t, err)
+}
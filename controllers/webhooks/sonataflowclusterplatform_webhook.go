@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/clusterplatform"
+)
+
+//+kubebuilder:webhook:path=/mutate-sonataflow-org-v1alpha08-sonataflowclusterplatform,mutating=true,failurePolicy=fail,sideEffects=None,groups=sonataflow.org,resources=sonataflowclusterplatforms,verbs=create;update,versions=v1alpha08,name=msonataflowclusterplatform.sonataflow.org,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-sonataflow-org-v1alpha08-sonataflowclusterplatform,mutating=false,failurePolicy=fail,sideEffects=None,groups=sonataflow.org,resources=sonataflowclusterplatforms,verbs=create;update,versions=v1alpha08,name=vsonataflowclusterplatform.sonataflow.org,admissionReviewVersions=v1
+
+var _ admission.CustomDefaulter = &sonataFlowClusterPlatformDefaulter{}
+
+type sonataFlowClusterPlatformDefaulter struct{}
+
+func (d *sonataFlowClusterPlatformDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cp, ok := obj.(*operatorapi.SonataFlowClusterPlatform)
+	if !ok {
+		return fmt.Errorf("expected a SonataFlowClusterPlatform object, got %T", obj)
+	}
+	if cp.Spec.Capabilities == nil {
+		cp.Spec.Capabilities = &operatorapi.SonataFlowClusterPlatformCapSpec{
+			Workflows: []operatorapi.WorkFlowCapability{clusterplatform.PlatformServices},
+		}
+	}
+	return nil
+}
+
+var _ admission.CustomValidator = &sonataFlowClusterPlatformValidator{}
+
+type sonataFlowClusterPlatformValidator struct {
+	client client.Client
+}
+
+func (v *sonataFlowClusterPlatformValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+func (v *sonataFlowClusterPlatformValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+func (v *sonataFlowClusterPlatformValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *sonataFlowClusterPlatformValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cp, ok := obj.(*operatorapi.SonataFlowClusterPlatform)
+	if !ok {
+		return nil, fmt.Errorf("expected a SonataFlowClusterPlatform object, got %T", obj)
+	}
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	refPath := field.NewPath("spec", "platformRef")
+	if len(cp.Spec.PlatformRef.Name) == 0 {
+		errs = append(errs, field.Required(refPath.Child("name"), ""))
+	}
+	if len(cp.Spec.PlatformRef.Namespace) == 0 {
+		errs = append(errs, field.Required(refPath.Child("namespace"), ""))
+	}
+	if len(errs) > 0 {
+		return nil, toInvalidError(operatorapi.SonataFlowClusterPlatformKind, cp.Name, errs)
+	}
+	// The referenced platform may be created afterward, the cluster platform reconciliation waits for it.
+	platform := &operatorapi.SonataFlowPlatform{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: cp.Spec.PlatformRef.Namespace, Name: cp.Spec.PlatformRef.Name}, platform); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		warnings = append(warnings, fmt.Sprintf("SonataFlowPlatform %s/%s not found", cp.Spec.PlatformRef.Namespace, cp.Spec.PlatformRef.Name))
+	}
+	if active, err := clusterplatform.GetActiveClusterPlatform(ctx, v.client); err == nil && active.Name != cp.Name && !clusterplatform.IsSecondary(cp) {
+		warnings = append(warnings, fmt.Sprintf("SonataFlowClusterPlatform %s is already active, %s will be marked as duplicated", active.Name, cp.Name))
+	}
+	return warnings, nil
+}
@@ -0,0 +1,249 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/builder"
+)
+
+//+kubebuilder:webhook:path=/mutate-sonataflow-org-v1alpha08-sonataflowplatform,mutating=true,failurePolicy=fail,sideEffects=None,groups=sonataflow.org,resources=sonataflowplatforms,verbs=create;update,versions=v1alpha08,name=msonataflowplatform.sonataflow.org,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-sonataflow-org-v1alpha08-sonataflowplatform,mutating=false,failurePolicy=fail,sideEffects=None,groups=sonataflow.org,resources=sonataflowplatforms,verbs=create;update,versions=v1alpha08,name=vsonataflowplatform.sonataflow.org,admissionReviewVersions=v1
+
+const (
+	postgreSQLJdbcUrlPrefix = "jdbc:postgresql://"
+	mongoDBURIPrefix        = "mongodb://"
+	mongoDBSRVURIPrefix     = "mongodb+srv://"
+)
+
+var _ admission.CustomDefaulter = &sonataFlowPlatformDefaulter{}
+
+type sonataFlowPlatformDefaulter struct{}
+
+// Default sets the platform defaults that don't depend on the cluster. Cluster dependent defaults, like the build
+// strategy and the registry, are still applied by the platform initialization action.
+func (d *sonataFlowPlatformDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	p, ok := obj.(*operatorapi.SonataFlowPlatform)
+	if !ok {
+		return fmt.Errorf("expected a SonataFlowPlatform object, got %T", obj)
+	}
+	if p.Spec.Services != nil {
+		var enable = true
+		// When the service object is set, default to enabled if the `Enabled` field's value is nil
+		if p.Spec.Services.DataIndex != nil && p.Spec.Services.DataIndex.Enabled == nil {
+			p.Spec.Services.DataIndex.Enabled = &enable
+		}
+		if p.Spec.Services.JobService != nil && p.Spec.Services.JobService.Enabled == nil {
+			p.Spec.Services.JobService.Enabled = &enable
+		}
+	}
+	return nil
+}
+
+var _ admission.CustomValidator = &sonataFlowPlatformValidator{}
+
+type sonataFlowPlatformValidator struct{}
+
+func (v *sonataFlowPlatformValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *sonataFlowPlatformValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(newObj)
+}
+
+func (v *sonataFlowPlatformValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *sonataFlowPlatformValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	p, ok := obj.(*operatorapi.SonataFlowPlatform)
+	if !ok {
+		return nil, fmt.Errorf("expected a SonataFlowPlatform object, got %T", obj)
+	}
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	errs = append(errs, validateBuildStrategy(specPath.Child("build", "config"), &p.Spec.Build.Config)...)
+	if p.Spec.Persistence != nil {
+		persistencePath := specPath.Child("persistence")
+		if pg := p.Spec.Persistence.PostgreSQL; pg != nil {
+			errs = append(errs, validatePostgreSQL(persistencePath.Child("postgresql"), pg.ServiceRef != nil, pg.JdbcUrl)...)
+			if pg.ServiceRef != nil && len(pg.ServiceRef.Name) == 0 {
+				errs = append(errs, field.Required(persistencePath.Child("postgresql", "serviceRef", "name"), ""))
+			}
+		}
+		errs = append(errs, validateMongoDB(persistencePath.Child("mongodb"), p.Spec.Persistence.MongoDB)...)
+		errs = append(errs, validateInfinispan(persistencePath.Child("infinispan"), p.Spec.Persistence.Infinispan)...)
+		if pg := p.Spec.Persistence.ProvisionedPostgreSQL; pg != nil && pg.StorageSize != nil && pg.StorageSize.Sign() <= 0 {
+			errs = append(errs, field.Invalid(persistencePath.Child("provisionedPostgresql", "storageSize"), pg.StorageSize.String(), "must be greater than zero"))
+		}
+	}
+	if p.Spec.Services != nil {
+		servicesPath := specPath.Child("services")
+		errs = append(errs, validateServicePersistence(servicesPath.Child("dataIndex", "persistence"), p.Spec.Services.DataIndex)...)
+		errs = append(errs, validateServicePersistence(servicesPath.Child("jobService", "persistence"), p.Spec.Services.JobService)...)
+	}
+	if p.Spec.Properties != nil {
+		errs = append(errs, validatePropertyVars(specPath.Child("properties", "flow"), p.Spec.Properties.Flow)...)
+	}
+	return nil, toInvalidError(operatorapi.SonataFlowPlatformKind, p.Name, errs)
+}
+
+func validateBuildStrategy(path *field.Path, config *operatorapi.BuildPlatformConfig) field.ErrorList {
+	if publishStrategy, ok := config.BuildStrategyOptions[builder.PublishStrategyOption]; ok {
+		var supported []string
+		for _, s := range builder.SupportedPublishStrategies {
+			supported = append(supported, string(s))
+		}
+		if !slices.Contains(supported, publishStrategy) {
+			return field.ErrorList{field.NotSupported(path.Child("strategyOptions").Key(builder.PublishStrategyOption), publishStrategy, supported)}
+		}
+	}
+	switch config.BuildStrategy {
+	case "", operatorapi.OperatorBuildStrategy, operatorapi.PlatformBuildStrategy:
+		return nil
+	case operatorapi.TektonBuildStrategy:
+		if len(config.BuildStrategyOptions[builder.TektonPipelineOption]) == 0 {
+			return field.ErrorList{field.Required(path.Child("strategyOptions").Key(builder.TektonPipelineOption),
+				fmt.Sprintf("the %s build strategy needs the name of the Pipeline to run", operatorapi.TektonBuildStrategy))}
+		}
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(path.Child("strategy"), config.BuildStrategy,
+			[]string{string(operatorapi.OperatorBuildStrategy), string(operatorapi.PlatformBuildStrategy), string(operatorapi.TektonBuildStrategy)})}
+	}
+}
+
+func validateServicePersistence(path *field.Path, service *operatorapi.ServiceSpec) field.ErrorList {
+	if service == nil {
+		return nil
+	}
+	return validatePersistence(path, service.Persistence)
+}
+
+// validatePersistence validates the database manager configured in a PersistenceOptionsSpec, if any.
+func validatePersistence(path *field.Path, persistence *operatorapi.PersistenceOptionsSpec) field.ErrorList {
+	if persistence == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if pg := persistence.PostgreSQL; pg != nil {
+		errs = append(errs, validatePostgreSQL(path.Child("postgresql"), pg.ServiceRef != nil, pg.JdbcUrl)...)
+		if pg.ServiceRef != nil && (pg.ServiceRef.SQLServiceOptions == nil || len(pg.ServiceRef.Name) == 0) {
+			errs = append(errs, field.Required(path.Child("postgresql", "serviceRef", "name"), ""))
+		}
+	}
+	errs = append(errs, validateMongoDB(path.Child("mongodb"), persistence.MongoDB)...)
+	errs = append(errs, validateInfinispan(path.Child("infinispan"), persistence.Infinispan)...)
+	return errs
+}
+
+// validatePostgreSQL checks that exactly one of serviceRef and jdbcUrl is given, and that jdbcUrl is a PostgreSQL JDBC URL.
+func validatePostgreSQL(path *field.Path, hasServiceRef bool, jdbcUrl string) field.ErrorList {
+	var errs field.ErrorList
+	if hasServiceRef && len(jdbcUrl) > 0 {
+		errs = append(errs, field.Forbidden(path.Child("jdbcUrl"), "serviceRef and jdbcUrl are mutually exclusive"))
+	} else if !hasServiceRef && len(jdbcUrl) == 0 {
+		errs = append(errs, field.Required(path, "one of serviceRef or jdbcUrl must be set"))
+	}
+	if len(jdbcUrl) > 0 && !strings.HasPrefix(jdbcUrl, postgreSQLJdbcUrlPrefix) {
+		errs = append(errs, field.Invalid(path.Child("jdbcUrl"), jdbcUrl, fmt.Sprintf("must start with %q", postgreSQLJdbcUrlPrefix)))
+	}
+	return errs
+}
+
+func validateMongoDB(path *field.Path, mongodb *operatorapi.PersistenceMongoDB) field.ErrorList {
+	if mongodb == nil {
+		return nil
+	}
+	errs := validateNoSQLService(path, mongodb.SecretRef.Name, mongodb.ServiceRef, "connectionString", mongodb.ConnectionString)
+	if len(mongodb.ConnectionString) > 0 &&
+		!strings.HasPrefix(mongodb.ConnectionString, mongoDBURIPrefix) && !strings.HasPrefix(mongodb.ConnectionString, mongoDBSRVURIPrefix) {
+		errs = append(errs, field.Invalid(path.Child("connectionString"), mongodb.ConnectionString,
+			fmt.Sprintf("must start with %q or %q", mongoDBURIPrefix, mongoDBSRVURIPrefix)))
+	}
+	return errs
+}
+
+func validateInfinispan(path *field.Path, infinispan *operatorapi.PersistenceInfinispan) field.ErrorList {
+	if infinispan == nil {
+		return nil
+	}
+	return validateNoSQLService(path, infinispan.SecretRef.Name, infinispan.ServiceRef, "hosts", infinispan.Hosts)
+}
+
+// validateNoSQLService checks that the credentials secret is given, and that exactly one of serviceRef and the
+// connection field named addressField is given.
+func validateNoSQLService(path *field.Path, secretName string, serviceRef *operatorapi.NoSQLServiceOptions, addressField, address string) field.ErrorList {
+	var errs field.ErrorList
+	if len(secretName) == 0 {
+		errs = append(errs, field.Required(path.Child("secretRef", "name"), ""))
+	}
+	if serviceRef != nil && len(address) > 0 {
+		errs = append(errs, field.Forbidden(path.Child(addressField), fmt.Sprintf("serviceRef and %s are mutually exclusive", addressField)))
+	} else if serviceRef == nil && len(address) == 0 {
+		errs = append(errs, field.Required(path, fmt.Sprintf("one of serviceRef or %s must be set", addressField)))
+	}
+	if serviceRef != nil && len(serviceRef.Name) == 0 {
+		errs = append(errs, field.Required(path.Child("serviceRef", "name"), ""))
+	}
+	return errs
+}
+
+// validatePropertyVars applies the same rules the Kubernetes API applies to EnvVar to the given PropertyVar list.
+func validatePropertyVars(path *field.Path, vars []operatorapi.PropertyVar) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, v := range vars {
+		idxPath := path.Index(i)
+		if len(v.Name) == 0 {
+			errs = append(errs, field.Required(idxPath.Child("name"), ""))
+		} else if names[v.Name] {
+			errs = append(errs, field.Duplicate(idxPath.Child("name"), v.Name))
+		}
+		names[v.Name] = true
+		if v.ValueFrom == nil {
+			continue
+		}
+		if len(v.Value) > 0 {
+			errs = append(errs, field.Invalid(idxPath.Child("valueFrom"), "", "may not be specified when `value` is not empty"))
+		}
+		sources := 0
+		if v.ValueFrom.ConfigMapKeyRef != nil {
+			sources++
+		}
+		if v.ValueFrom.SecretKeyRef != nil {
+			sources++
+		}
+		if sources != 1 {
+			errs = append(errs, field.Invalid(idxPath.Child("valueFrom"), "", "must specify exactly one of: `configMapKeyRef` or `secretKeyRef`"))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/builder"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/clusterplatform"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+)
+
+func TestSonataFlowPlatformDefaulter(t *testing.T) {
+	p := test.GetBasePlatform()
+	p.Spec.Services = &operatorapi.ServicesPlatformSpec{DataIndex: &operatorapi.ServiceSpec{}}
+	assert.NoError(t, (&sonataFlowPlatformDefaulter{}).Default(context.TODO(), p))
+	assert.True(t, *p.Spec.Services.DataIndex.Enabled)
+	assert.Nil(t, p.Spec.Services.JobService)
+}
+
+func TestSonataFlowPlatformValidator(t *testing.T) {
+	v := &sonataFlowPlatformValidator{}
+	t.Run("base platform", func(t *testing.T) {
+		_, err := v.ValidateCreate(context.TODO(), test.GetBasePlatform())
+		assert.NoError(t, err)
+	})
+	t.Run("unknown build strategy", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Build.Config.BuildStrategy = "custom"
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+	})
+	t.Run("tekton build strategy without pipeline", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Build.Config.BuildStrategy = operatorapi.TektonBuildStrategy
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.build.config.strategyOptions[TektonPipeline]")
+
+		p.Spec.Build.Config.BuildStrategyOptions = map[string]string{builder.TektonPipelineOption: "supply-chain"}
+		_, err = v.ValidateCreate(context.TODO(), p)
+		assert.NoError(t, err)
+	})
+	t.Run("unknown publish strategy", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Build.Config.BuildStrategyOptions = map[string]string{builder.PublishStrategyOption: "Docker"}
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.build.config.strategyOptions[PublishStrategy]")
+
+		p.Spec.Build.Config.BuildStrategyOptions[builder.PublishStrategyOption] = "Buildah"
+		_, err = v.ValidateCreate(context.TODO(), p)
+		assert.NoError(t, err)
+	})
+	t.Run("postgresql with serviceRef and jdbcUrl", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Persistence = &operatorapi.PlatformPersistenceOptionsSpec{
+			PostgreSQL: &operatorapi.PlatformPersistencePostgreSQL{
+				SecretRef:  operatorapi.PostgreSQLSecretOptions{Name: "creds"},
+				ServiceRef: &operatorapi.SQLServiceOptions{Name: "postgres"},
+				JdbcUrl:    "jdbc:postgresql://postgres:5432/sonataflow",
+			},
+		}
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.persistence.postgresql.jdbcUrl")
+	})
+	t.Run("service persistence with a non postgresql jdbcUrl", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Services = &operatorapi.ServicesPlatformSpec{
+			DataIndex: &operatorapi.ServiceSpec{
+				Persistence: &operatorapi.PersistenceOptionsSpec{
+					PostgreSQL: &operatorapi.PersistencePostgreSQL{
+						SecretRef: operatorapi.PostgreSQLSecretOptions{Name: "creds"},
+						JdbcUrl:   "jdbc:mysql://mysql:3306/sonataflow",
+					},
+				},
+			},
+		}
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.services.dataIndex.persistence.postgresql.jdbcUrl")
+	})
+	t.Run("mongodb with serviceRef and connectionString", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Persistence = &operatorapi.PlatformPersistenceOptionsSpec{
+			MongoDB: &operatorapi.PersistenceMongoDB{
+				SecretRef:        operatorapi.MongoDBSecretOptions{Name: "creds"},
+				ServiceRef:       &operatorapi.NoSQLServiceOptions{Name: "mongodb"},
+				ConnectionString: "http://mongodb:27017",
+			},
+		}
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "serviceRef and connectionString are mutually exclusive")
+		assert.Contains(t, err.Error(), `must start with "mongodb://"`)
+	})
+	t.Run("infinispan without hosts", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Services = &operatorapi.ServicesPlatformSpec{
+			JobService: &operatorapi.ServiceSpec{
+				Persistence: &operatorapi.PersistenceOptionsSpec{
+					Infinispan: &operatorapi.PersistenceInfinispan{SecretRef: operatorapi.InfinispanSecretOptions{Name: "creds"}},
+				},
+			},
+		}
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.services.jobService.persistence.infinispan")
+
+		p.Spec.Services.JobService.Persistence.Infinispan.Hosts = "infinispan:11222"
+		_, err = v.ValidateCreate(context.TODO(), p)
+		assert.NoError(t, err)
+	})
+	t.Run("property with value and valueFrom", func(t *testing.T) {
+		p := test.GetBasePlatform()
+		p.Spec.Properties = &operatorapi.PropertyPlatformSpec{
+			Flow: []operatorapi.PropertyVar{
+				{Name: "quarkus.log.level", Value: "INFO"},
+				{Name: "my.key", Value: "value", ValueFrom: &operatorapi.PropertyVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{Key: "key", LocalObjectReference: corev1.LocalObjectReference{Name: "secret"}},
+				}},
+				{Name: "quarkus.log.level", Value: "DEBUG"},
+			},
+		}
+		_, err := v.ValidateCreate(context.TODO(), p)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.properties.flow[1].valueFrom")
+		assert.Contains(t, err.Error(), "spec.properties.flow[2].name")
+	})
+}
+
+func TestSonataFlowClusterPlatformWebhooks(t *testing.T) {
+	cp := test.GetBaseClusterPlatformInReadyPhase(t.Name())
+	cp.Spec.Capabilities = nil
+	assert.NoError(t, (&sonataFlowClusterPlatformDefaulter{}).Default(context.TODO(), cp))
+	assert.Contains(t, cp.Spec.Capabilities.Workflows, clusterplatform.PlatformServices)
+
+	v := &sonataFlowClusterPlatformValidator{client: test.NewSonataFlowClientBuilder().Build()}
+	warnings, err := v.ValidateCreate(context.TODO(), cp)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	cp.Spec.PlatformRef.Namespace = ""
+	_, err = v.ValidateCreate(context.TODO(), cp)
+	assert.True(t, apierrors.IsInvalid(err))
+}
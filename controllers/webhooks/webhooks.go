@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhooks
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+)
+
+// SetupWebhooksWithManager registers the defaulting and validating admission webhooks for the SonataFlow,
+// SonataFlowPlatform and SonataFlowClusterPlatform custom resources.
+func SetupWebhooksWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorapi.SonataFlow{}).
+		WithDefaulter(&sonataFlowDefaulter{}).
+		WithValidator(newSonataFlowValidator(mgr.GetClient(), mgr.GetConfig())).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorapi.SonataFlowPlatform{}).
+		WithDefaulter(&sonataFlowPlatformDefaulter{}).
+		WithValidator(&sonataFlowPlatformValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorapi.SonataFlowClusterPlatform{}).
+		WithDefaulter(&sonataFlowClusterPlatformDefaulter{}).
+		WithValidator(&sonataFlowClusterPlatformValidator{client: mgr.GetClient()}).
+		Complete()
+}
+
+// toInvalidError converts the given field errors into the API error returned to the client, nil if there's no error.
+func toInvalidError(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.NewInvalid(schema.GroupKind{Group: operatorapi.GroupVersion.Group, Kind: kind}, name, errs)
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/log"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+// dockerConfigKeys keys of the registry secret holding the docker configuration, as supported by the builders.
+var dockerConfigKeys = []string{corev1.DockerConfigJsonKey, "config.json"}
+
+// Cleanup removes the resources of the given workflow that can't be garbage collected through owner references:
+// the Knative Triggers delivering events from the brokers of other namespaces and, when the platform deletion policy
+// says so, the images pushed to the registry by the workflow builds.
+// Failures deleting the images are reported as Warning events, they never block the workflow deletion.
+func Cleanup(ctx context.Context, c client.Client, recorder record.EventRecorder, workflow *v1alpha08.SonataFlow, platform *v1alpha08.SonataFlowPlatform) error {
+	if err := deleteTriggers(ctx, c, workflow); err != nil {
+		return err
+	}
+	if platform == nil || platform.Spec.DeletionPolicies.GetImagesPolicy() != v1alpha08.DeleteDeletionPolicy ||
+		platform.Spec.Build.Config.BuildStrategy == v1alpha08.PlatformBuildStrategy {
+		return nil
+	}
+	images, err := getBuiltImages(ctx, c, workflow)
+	if err != nil {
+		return err
+	}
+	opts, err := registryOptions(ctx, c, platform)
+	if err != nil {
+		recorder.Eventf(workflow, corev1.EventTypeWarning, "ImageDeletionFailed", "Unable to read the registry credentials: %v", err)
+		return nil
+	}
+	for _, image := range images {
+		if err = deleteImage(ctx, image, platform.Spec.Build.Config.Registry.Insecure, opts); err != nil {
+			recorder.Eventf(workflow, corev1.EventTypeWarning, "ImageDeletionFailed", "Unable to delete the image %s: %v", image, err)
+			continue
+		}
+		klog.V(log.I).InfoS("Workflow image deleted from the registry", "workflow", workflow.Name, "namespace", workflow.Namespace, "image", image)
+	}
+	return nil
+}
+
+// deleteTriggers deletes the Knative Triggers subscribing the workflow in its namespace and in the namespace of its
+// sink Broker. The Triggers in the workflow namespace are owned by the workflow, but the ones living with a broker of
+// another namespace can't be.
+func deleteTriggers(ctx context.Context, c client.Client, workflow *v1alpha08.SonataFlow) error {
+	namespaces := []string{workflow.Namespace}
+	if sink := workflow.Spec.Sink; sink != nil && sink.Ref != nil && len(sink.Ref.Namespace) > 0 && sink.Ref.Namespace != workflow.Namespace {
+		namespaces = append(namespaces, sink.Ref.Namespace)
+	}
+	for _, namespace := range namespaces {
+		triggers := &eventingv1.TriggerList{}
+		if err := c.List(ctx, triggers, client.InNamespace(namespace), client.MatchingLabels(workflowproj.GetMergedLabels(workflow))); err != nil {
+			// Knative Eventing might not be installed in the cluster
+			if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				return nil
+			}
+			return err
+		}
+		for i := range triggers.Items {
+			trigger := &triggers.Items[i]
+			ref := trigger.Spec.Subscriber.Ref
+			if ref == nil || ref.Name != workflow.Name || ref.Namespace != workflow.Namespace {
+				continue
+			}
+			if err := c.Delete(ctx, trigger); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getBuiltImages returns the images produced by the builds of the workflow, referenced by digest when known.
+func getBuiltImages(ctx context.Context, c client.Client, workflow *v1alpha08.SonataFlow) ([]string, error) {
+	build := &v1alpha08.SonataFlowBuild{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, build); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	var images []string
+	seen := make(map[string]bool)
+	add := func(tag, digest string) {
+		if len(tag) == 0 {
+			return
+		}
+		image := tag
+		// the digest identifies the manifest even when the tag has been pushed again
+		if ref, err := name.ParseReference(tag); err == nil && len(digest) > 0 {
+			image = ref.Context().Name() + "@" + digest
+		}
+		if !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	add(build.Status.ImageTag, build.Status.ImageDigest)
+	for _, record := range build.Status.History {
+		add(record.ImageTag, record.ImageDigest)
+	}
+	return images, nil
+}
+
+// registryOptions returns the options to access the platform registry with the credentials of its secret, if any.
+func registryOptions(ctx context.Context, c client.Client, platform *v1alpha08.SonataFlowPlatform) ([]remote.Option, error) {
+	opts := []remote.Option{remote.WithContext(ctx)}
+	secretName := platform.Spec.Build.Config.Registry.Secret
+	if len(secretName) == 0 {
+		return opts, nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: platform.Namespace, Name: secretName}, secret); err != nil {
+		return nil, err
+	}
+	for _, key := range dockerConfigKeys {
+		if data, ok := secret.Data[key]; ok {
+			dockerConfig := struct {
+				Auths map[string]authn.AuthConfig `json:"auths"`
+			}{}
+			if err := json.Unmarshal(data, &dockerConfig); err != nil {
+				return nil, err
+			}
+			return append(opts, remote.WithAuthFromKeychain(&dockerConfigKeychain{auths: dockerConfig.Auths})), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported secret %s for registry authentication", secretName)
+}
+
+// deleteImage deletes the manifest of the given image from its registry. Images already gone are ignored.
+func deleteImage(ctx context.Context, image string, insecure bool, opts []remote.Option) error {
+	var nameOpts []name.Option
+	if insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(image, nameOpts...)
+	if err != nil {
+		return err
+	}
+	if _, isDigest := ref.(name.Digest); !isDigest {
+		// registries only delete manifests by digest
+		desc, err := remote.Head(ref, opts...)
+		if err != nil {
+			return ignoreManifestNotFound(err)
+		}
+		ref = ref.Context().Digest(desc.Digest.String())
+	}
+	return ignoreManifestNotFound(remote.Delete(ref, opts...))
+}
+
+func ignoreManifestNotFound(err error) error {
+	if terr, ok := err.(*transport.Error); ok && terr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// dockerConfigKeychain resolves the credentials of a registry from the auths of a docker configuration.
+type dockerConfigKeychain struct {
+	auths map[string]authn.AuthConfig
+}
+
+func (k *dockerConfigKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	for server, auth := range k.auths {
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		host = strings.SplitN(host, "/", 2)[0]
+		if host == target.RegistryStr() || (target.RegistryStr() == name.DefaultRegistry && host == "index.docker.io") {
+			return authn.FromConfig(auth), nil
+		}
+	}
+	return authn.Anonymous, nil
+}
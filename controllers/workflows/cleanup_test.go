@@ -0,0 +1,137 @@
+// Copyright 2024 Apache Software Foundation (ASF)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/test"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/workflowproj"
+)
+
+func pushRandomImage(t *testing.T, tag string) string {
+	img, err := random.Image(1024, 1)
+	assert.NoError(t, err)
+	ref, err := name.ParseReference(tag)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	assert.NoError(t, err)
+	return digest.String()
+}
+
+func TestCleanup(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	registryHost := strings.TrimPrefix(server.URL, "http://")
+
+	namespace := t.Name()
+	workflow := test.GetBaseSonataFlow(namespace)
+	platform := test.GetBasePlatformInReadyPhase(namespace)
+	platform.Spec.Build.Config.Registry = v1alpha08.RegistrySpec{Address: registryHost, Insecure: true}
+	platform.Spec.DeletionPolicies = &v1alpha08.DeletionPoliciesSpec{Images: v1alpha08.DeleteDeletionPolicy}
+
+	currentTag := registryHost + "/" + strings.ToLower(namespace) + "/" + workflow.Name + ":latest"
+	previousTag := registryHost + "/" + strings.ToLower(namespace) + "/" + workflow.Name + ":previous"
+	currentDigest := pushRandomImage(t, currentTag)
+	previousDigest := pushRandomImage(t, previousTag)
+
+	build := test.GetNewEmptySonataFlowBuild(workflow.Name, namespace)
+	build.Status.ImageTag = currentTag
+	build.Status.ImageDigest = currentDigest
+	build.Status.History = []v1alpha08.SonataFlowBuildRecord{{ImageTag: previousTag}}
+
+	workflow.Spec.Sink = &duckv1.Destination{
+		Ref: &duckv1.KReference{Kind: "Broker", APIVersion: "eventing.knative.dev/v1", Name: "default", Namespace: "broker-ns"},
+	}
+	remoteTrigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-trigger", Namespace: "broker-ns", Labels: workflowproj.GetMergedLabels(workflow)},
+		Spec: eventingv1.TriggerSpec{
+			Broker: "default",
+			Subscriber: duckv1.Destination{
+				Ref: &duckv1.KReference{Kind: "Service", APIVersion: "v1", Name: workflow.Name, Namespace: namespace},
+			},
+		},
+	}
+	otherTrigger := remoteTrigger.DeepCopy()
+	otherTrigger.Name = "other-trigger"
+	otherTrigger.Spec.Subscriber.Ref.Name = "other-workflow"
+	// only the namespaces of the workflow and its sink are looked up
+	unrelatedTrigger := remoteTrigger.DeepCopy()
+	unrelatedTrigger.Name = "unrelated-trigger"
+	unrelatedTrigger.Namespace = "other-ns"
+
+	cli := test.NewSonataFlowClientBuilderWithKnative().WithRuntimeObjects(workflow, build, remoteTrigger, otherTrigger, unrelatedTrigger).Build()
+	recorder := record.NewFakeRecorder(10)
+	assert.NoError(t, Cleanup(context.TODO(), cli, recorder, workflow, platform))
+	assert.Empty(t, recorder.Events)
+
+	// registries delete the manifests by digest
+	for _, image := range []string{currentTag + "@" + currentDigest, previousTag + "@" + previousDigest} {
+		ref, err := name.ParseReference(image)
+		assert.NoError(t, err)
+		_, err = remote.Head(ref)
+		assert.Error(t, err, "image %s must have been deleted", image)
+	}
+
+	triggers := &eventingv1.TriggerList{}
+	assert.NoError(t, cli.List(context.TODO(), triggers))
+	assert.Len(t, triggers.Items, 2)
+	for _, trigger := range triggers.Items {
+		assert.NotEqual(t, remoteTrigger.Name, trigger.Name)
+	}
+}
+
+func TestCleanup_RetainImages(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	registryHost := strings.TrimPrefix(server.URL, "http://")
+
+	namespace := t.Name()
+	workflow := test.GetBaseSonataFlow(namespace)
+	platform := test.GetBasePlatformInReadyPhase(namespace)
+	platform.Spec.Build.Config.Registry = v1alpha08.RegistrySpec{Address: registryHost, Insecure: true}
+
+	tag := registryHost + "/" + strings.ToLower(namespace) + "/" + workflow.Name + ":latest"
+	pushRandomImage(t, tag)
+	build := test.GetNewEmptySonataFlowBuild(workflow.Name, namespace)
+	build.Status.ImageTag = tag
+
+	cli := test.NewSonataFlowClientBuilderWithKnative().WithRuntimeObjects(workflow, build).Build()
+	assert.NoError(t, Cleanup(context.TODO(), cli, record.NewFakeRecorder(10), workflow, platform))
+
+	ref, err := name.ParseReference(tag)
+	assert.NoError(t, err)
+	_, err = remote.Head(ref)
+	assert.NoError(t, err)
+}
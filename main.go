@@ -25,9 +25,12 @@ import (
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/cfg"
 	"github.com/apache/incubator-kie-kogito-serverless-operator/version"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"k8s.io/klog/v2/klogr"
 
@@ -36,6 +39,8 @@ import (
 	"github.com/apache/incubator-kie-kogito-serverless-operator/utils"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/metrics"
+	"github.com/apache/incubator-kie-kogito-serverless-operator/controllers/webhooks"
 	ocputil "github.com/apache/incubator-kie-kogito-serverless-operator/utils/openshift"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -62,7 +67,10 @@ func init() {
 	utilruntime.Must(operatorapi.AddToScheme(scheme))
 	utilruntime.Must(sourcesv1.AddToScheme(scheme))
 	utilruntime.Must(eventingv1.AddToScheme(scheme))
+	utilruntime.Must(messagingv1.AddToScheme(scheme))
 	utilruntime.Must(servingv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -71,6 +79,7 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var controllerCfgPath string
+	var enableWebhooks bool
 	klog.InitFlags(nil)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -78,6 +87,9 @@ func main() {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&controllerCfgPath, "controller-cfg-path", "", "The controller config file path.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", os.Getenv("ENABLE_WEBHOOKS") == "true",
+		"Enable the validating and defaulting admission webhooks, defaults to the ENABLE_WEBHOOKS env var. "+
+			"Requires the webhook server certificates to be mounted in the manager container.")
 	flag.Parse()
 
 	ctrl.SetLogger(klogr.New().WithName(controllers.ComponentName))
@@ -144,8 +156,19 @@ func main() {
 		klog.V(log.E).ErrorS(err, "unable to create controller", "controller", "SonataFlowClusterPlatform")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err = webhooks.SetupWebhooksWithManager(mgr); err != nil {
+			klog.V(log.E).ErrorS(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if err = metrics.RegisterStateCollector(mgr.GetClient()); err != nil {
+		klog.V(log.E).ErrorS(err, "unable to register the operator metrics")
+		os.Exit(1)
+	}
+
 	if utils.IsOpenShift() {
 		ocputil.MustAddToScheme(mgr.GetScheme())
 	}
@@ -26,6 +26,7 @@ import (
 	buildv1 "github.com/openshift/api/build/v1"
 	imgv1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -33,9 +34,12 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorapi "github.com/apache/incubator-kie-kogito-serverless-operator/api/v1alpha08"
 )
@@ -48,6 +52,8 @@ func NewFakeRecorder() record.EventRecorder {
 func NewSonataFlowClientBuilder() *fake.ClientBuilder {
 	s := scheme.Scheme
 	utilruntime.Must(operatorapi.AddToScheme(s))
+	utilruntime.Must(gatewayv1beta1.AddToScheme(s))
+	utilruntime.Must(monitoringv1.AddToScheme(s))
 	return fake.NewClientBuilder().WithScheme(s)
 }
 
@@ -55,6 +61,8 @@ func NewSonataFlowClientBuilderWithKnative() *fake.ClientBuilder {
 	s := scheme.Scheme
 	utilruntime.Must(operatorapi.AddToScheme(s))
 	utilruntime.Must(servingv1.AddToScheme(s))
+	utilruntime.Must(eventingv1.AddToScheme(s))
+	utilruntime.Must(messagingv1.AddToScheme(s))
 	return fake.NewClientBuilder().WithScheme(s)
 }
 
@@ -22,8 +22,11 @@ package kubernetes
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-kie-kogito-serverless-operator/api/metadata"
@@ -112,8 +115,10 @@ func MarkDeploymentToRollout(deployment *appsv1.Deployment) error {
 
 // AnnotateDeploymentConfigChecksum adds the checksum/config annotation to the template annotations of the Deployment to set the current configuration.
 // If the checksum has changed from the previous value, the restartedAt annotation is also added and a new rollout is started.
+// The referencesVersions are the resourceVersions of the Secrets and the ConfigMaps referenced by the workflow properties,
+// keyed by property name.
 // Code adapted from here: https://github.com/kubernetes/kubectl/blob/release-1.26/pkg/polymorphichelpers/objectrestarter.go#L44
-func AnnotateDeploymentConfigChecksum(workflow *operatorapi.SonataFlow, deployment *appsv1.Deployment, userPropsCM *v1.ConfigMap, managedPropsCM *v1.ConfigMap) error {
+func AnnotateDeploymentConfigChecksum(workflow *operatorapi.SonataFlow, deployment *appsv1.Deployment, userPropsCM *v1.ConfigMap, managedPropsCM *v1.ConfigMap, referencesVersions map[string]string) error {
 	if deployment.Spec.Paused {
 		return errors.New("can't restart paused deployment (run rollout resume first)")
 	}
@@ -125,7 +130,7 @@ func AnnotateDeploymentConfigChecksum(workflow *operatorapi.SonataFlow, deployme
 	if !ok {
 		currentChecksum = ""
 	}
-	newChecksum, err := calculateHash(userPropsCM, managedPropsCM, workflow)
+	newChecksum, err := calculateHash(userPropsCM, managedPropsCM, workflow, referencesVersions)
 	if err != nil {
 		return err
 	}
@@ -150,9 +155,23 @@ func dataFromCM(cm *v1.ConfigMap, key string) string {
 	return data
 }
 
-func calculateHash(userPropsCM, managedPropsCM *v1.ConfigMap, workflow *operatorapi.SonataFlow) (string, error) {
+func calculateHash(userPropsCM, managedPropsCM *v1.ConfigMap, workflow *operatorapi.SonataFlow, referencesVersions map[string]string) (string, error) {
 	aggregatedProps := fmt.Sprintf("%s,%s", dataFromCM(userPropsCM, workflowproj.ApplicationPropertiesFileName),
 		dataFromCM(managedPropsCM, workflowproj.GetManagedPropertiesFileName(workflow)))
+	if workflow.Spec.Properties != nil {
+		// the Secret-backed properties never reach the managed ConfigMap, their references and the versions of the
+		// referenced objects are part of the configuration
+		secretProps, err := json.Marshal(workflow.Spec.Properties)
+		if err != nil {
+			return "", err
+		}
+		var versions []string
+		for name, version := range referencesVersions {
+			versions = append(versions, fmt.Sprintf("%s=%s", name, version))
+		}
+		sort.Strings(versions)
+		aggregatedProps = fmt.Sprintf("%s,%s,%s", aggregatedProps, secretProps, strings.Join(versions, ","))
+	}
 	hash := sha256.New()
 	_, err := hash.Write([]byte(aggregatedProps))
 	if err != nil {
@@ -40,6 +40,23 @@ func VolumeProjectionAddConfigMap(volumeSource *corev1.ProjectedVolumeSource, cm
 
 }
 
+// VolumeProjectionAddSecretItem adds the given item of a Secret to the ProjectedVolumeSource sources.
+// The items of a Secret already in the list are kept.
+func VolumeProjectionAddSecretItem(volumeSource *corev1.ProjectedVolumeSource, secretName string, optional *bool, item corev1.KeyToPath) {
+	for _, source := range volumeSource.Sources {
+		if source.Secret != nil && source.Secret.Name == secretName {
+			source.Secret.Items = append(source.Secret.Items, item)
+			return
+		}
+	}
+
+	volumeSource.Sources = append(volumeSource.Sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Items:                []corev1.KeyToPath{item},
+		Optional:             optional,
+	}})
+}
+
 // VolumeAddVolumeProjectionConfigMap adds a new ConfigMapProjection to the given Volume array.
 // It looks for the given mount name in the Volume array.
 // If finds it, adds a new projection for the given ConfigMap.